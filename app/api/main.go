@@ -3,80 +3,220 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
-	"os/signal"
-	"time"
 
 	"github.com/joho/godotenv"
 
 	httptreemux "github.com/dimfeld/httptreemux/v5"
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/kelseyhightower/envconfig"
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/lifecycle"
+	lifecycleConstructor "littlerollingsushi.com/example/lifecycle/constructor"
+	loggingConstructor "littlerollingsushi.com/example/logging/constructor"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	"littlerollingsushi.com/example/middleware"
+	middlewareConstructor "littlerollingsushi.com/example/middleware/constructor"
+	tlsConstructor "littlerollingsushi.com/example/tlsconfig/constructor"
+	tracingConstructor "littlerollingsushi.com/example/tracing/constructor"
+	accountDeletionConstructor "littlerollingsushi.com/example/usecase/accountdeletion/constructor"
+	auditConstructor "littlerollingsushi.com/example/usecase/audit/constructor"
+	challengeConstructor "littlerollingsushi.com/example/usecase/challenge/constructor"
+	dataExportConstructor "littlerollingsushi.com/example/usecase/dataexport/constructor"
+	emailChangeConstructor "littlerollingsushi.com/example/usecase/emailchange/constructor"
+	healthConstructor "littlerollingsushi.com/example/usecase/health/constructor"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperConstructor "littlerollingsushi.com/example/usecase/helper/constructor"
 	loginConstructor "littlerollingsushi.com/example/usecase/login/constructor"
+	passwordResetConstructor "littlerollingsushi.com/example/usecase/passwordreset/constructor"
 	registrationConstructor "littlerollingsushi.com/example/usecase/registration/constructor"
+	sessionConstructor "littlerollingsushi.com/example/usecase/session/constructor"
+	userAdminConstructor "littlerollingsushi.com/example/usecase/useradmin/constructor"
 )
 
-type SqlConfig struct {
-	Driver   string `envconfig:"DRIVER" default:"mysql"`
-	Host     string `envconfig:"HOST" default:"127.0.0.1"`
-	Port     int    `envconfig:"PORT" default:"3306"`
-	Username string `envconfig:"USERNAME" default:"example"`
-	Password string `envconfig:"PASSWORD" default:"example"`
-	Database string `envconfig:"DATABASE" default:"example"`
-}
-
-func (c *SqlConfig) DSN() string {
-	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s",
-		c.Username,
-		c.Password,
-		c.Host,
-		c.Port,
-		c.Database,
-	)
-}
-
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the effective configuration, with secrets redacted, and exit")
+	flag.Parse()
+
 	_ = godotenv.Load(".env")
 
-	sqlConfig := SqlConfig{}
-	envconfig.Process("sql", &sqlConfig)
-	db, err := sql.Open(sqlConfig.Driver, sqlConfig.DSN())
+	cfg, err := config.Load(os.LookupEnv)
 	if err != nil {
-		log.Fatalf("Error opening database connection: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(lifecycle.ExitFailure)
+	}
+	if *printConfig {
+		if err := config.Write(os.Stdout, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(lifecycle.ExitFailure)
+		}
+		return
 	}
-	defer db.Close()
 
-	handler := httptreemux.New()
-	handler.POST("/v1/register", registrationConstructor.ConstructRegisterHandler(db).Register)
-	handler.POST("/v1/login", loginConstructor.ConstructLoginHandler(db).Login)
+	logger := loggingConstructor.ConstructLogger(cfg)
+	app := lifecycleConstructor.ConstructLifecycle(cfg, logger)
+	shutdownTracing, err := tracingConstructor.ConstructTracerProvider(cfg)
+	if err != nil {
+		logger.Error("constructing tracer provider failed", "error", err)
+		os.Exit(lifecycle.ExitFailure)
+	}
+	app.OnStop("tracing", shutdownTracing)
 
-	server := &http.Server{
-		Addr:           ":7070",
-		Handler:        handler,
-		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
-		MaxHeaderBytes: 1 << 20,
+	db, err := sql.Open(cfg.SQL.Driver, cfg.SQL.DSN())
+	if err != nil {
+		logger.Error("opening database connection failed", "error", err)
+		os.Exit(lifecycle.ExitFailure)
+	}
+	app.OnStop("database", func(context.Context) error { return db.Close() })
+	metricsConstructor.ConstructMetrics().RegisterDB(db, cfg.SQL.Database)
+
+	// The login signs access tokens with the private key, the authentication
+	// verifies them with the public key and the readiness probe checks the
+	// very same pair.
+	privateKey, err := helper.LoadRSAPrivateKey(cfg.RSA.PrivateKeyPath)
+	if err != nil {
+		logger.Error("loading RSA private key failed", "error", err)
+		os.Exit(lifecycle.ExitFailure)
+	}
+	publicKey, err := helper.LoadRSAPublicKey(cfg.RSA.PublicKeyPath)
+	if err != nil {
+		logger.Error("loading RSA public key failed", "error", err)
+		os.Exit(lifecycle.ExitFailure)
 	}
 
-	idleConnsClosed := make(chan struct{})
-	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt)
-		<-sigint
+	// Like the keys, the TLS config is built before any worker is started, so
+	// an invalid certificate exits with nothing running.
+	tlsConfig, certificates, err := tlsConstructor.ConstructTLSConfig(cfg, logger)
+	if err != nil {
+		logger.Error("constructing TLS config failed", "error", err)
+		os.Exit(lifecycle.ExitFailure)
+	}
 
-		if err := server.Shutdown(context.Background()); err != nil {
-			log.Printf("Error from closing listeners, or context timeout: %v", err)
-		}
-		close(idleConnsClosed)
-	}()
+	redisClient := helperConstructor.ConstructRedisClient(cfg)
+	if redisClient != nil {
+		app.OnStop("redis", func(context.Context) error { return redisClient.Close() })
+	}
+
+	notificationMailer := loginConstructor.ConstructNotificationMailer(cfg, logger)
+
+	handler := httptreemux.New()
+	standardMiddleware := middlewareConstructor.ConstructStandardMiddleware(cfg, logger)
+	handler.Use(standardMiddleware)
+	handler.OptionsHandler = middleware.Wrap(middlewareConstructor.ConstructCORSPreflightHandler(cfg, handler), standardMiddleware)
+
+	health := healthConstructor.ConstructHealthUsecase(cfg, db, privateKey, publicKey)
+	app.OnDrain(health.BeginShutdown)
+	healthHandler := healthConstructor.ConstructHealthHandler(health)
+	handler.GET("/healthz", healthHandler.Live)
+	handler.GET("/readyz", healthHandler.Ready)
+
+	challenge := handler.NewGroup("/v1/challenge")
+	challenge.Use(middlewareConstructor.ConstructRateLimitMiddleware(
+		cfg,
+		redisClient,
+		"CHALLENGE_IP",
+		cfg.RateLimit.ChallengeIP(),
+		middleware.KeyByClientIP("challenge"),
+	))
+	challenge.GET("", challengeConstructor.ConstructChallengeHandler(cfg, redisClient).IssueChallenge)
+
+	register := handler.NewGroup("/v1/register")
+	register.Use(middlewareConstructor.ConstructRateLimitMiddleware(
+		cfg,
+		redisClient,
+		"REGISTER_IP",
+		cfg.RateLimit.RegisterIP(),
+		middleware.KeyByClientIP("register"),
+	))
+	register.POST("", registrationConstructor.ConstructRegisterHandler(
+		cfg,
+		db,
+		challengeConstructor.ConstructChallengeUsecase(cfg, redisClient),
+	).Register)
+
+	login := handler.NewGroup("/v1/login")
+	login.Use(middlewareConstructor.ConstructRateLimitMiddleware(
+		cfg,
+		redisClient,
+		"LOGIN_IP",
+		cfg.RateLimit.LoginIP(),
+		middleware.KeyByClientIP("login"),
+	))
+	login.Use(middlewareConstructor.ConstructRateLimitMiddleware(
+		cfg,
+		redisClient,
+		"LOGIN_EMAIL",
+		cfg.RateLimit.LoginEmail(),
+		middleware.KeyByBodyField("login", "email"),
+	))
+	login.POST("", loginConstructor.ConstructLoginHandler(cfg, db, notificationMailer, privateKey).Login)
+
+	emailChangeHandler := emailChangeConstructor.ConstructEmailChangeHandler(cfg, db)
+	handler.GET("/v1/email/confirm", emailChangeHandler.ShowEmailChangeConfirmation)
+	handler.POST("/v1/email/confirm", emailChangeHandler.ConfirmEmailChange)
+	handler.POST("/v1/password/reset", passwordResetConstructor.ConstructPasswordResetHandler(cfg, db).ResetPassword)
+
+	authenticate := middlewareConstructor.ConstructAuthenticateMiddleware(cfg, db, publicKey)
 
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
-		log.Fatalf("Error starting or closing listener: %v", err)
+	authenticated := handler.NewGroup("/v1/me")
+	authenticated.Use(authenticate)
+	authenticated.POST("/email", emailChangeHandler.RequestEmailChange)
+	authenticated.DELETE("", accountDeletionConstructor.ConstructAccountDeletionHandler(cfg, db).DeleteAccount)
+	authenticated.GET("/export", dataExportConstructor.ConstructDataExportHandler(
+		db,
+		emailChangeConstructor.ConstructPersonalDataExporter(db),
+		auditConstructor.ConstructPersonalDataExporter(db),
+		sessionConstructor.ConstructPersonalDataExporter(db),
+		loginConstructor.ConstructPersonalDataExporter(db),
+	).ExportPersonalData)
+
+	sessionHandler := sessionConstructor.ConstructSessionHandler(cfg, db)
+	authenticated.GET("/sessions", sessionHandler.ListSessions)
+	authenticated.DELETE("/sessions", sessionHandler.RevokeOtherSessions)
+	authenticated.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+
+	userAdminHandler := userAdminConstructor.ConstructUserAdminHandler(cfg, db)
+	admin := handler.NewGroup("/v1/admin")
+	admin.Use(authenticate)
+
+	usersReader := admin.NewGroup("/users")
+	usersReader.Use(middlewareConstructor.RequirePermission("users:read"))
+	usersReader.GET("", userAdminHandler.ListUsers)
+	usersReader.GET("/:id", userAdminHandler.GetUser)
+
+	usersWriter := admin.NewGroup("/users")
+	usersWriter.Use(middlewareConstructor.RequirePermission("users:write"))
+	usersWriter.POST("/:id/disable", userAdminHandler.DisableUser)
+	usersWriter.POST("/:id/suspend", userAdminHandler.SuspendUser)
+	usersWriter.POST("/:id/enable", userAdminHandler.EnableUser)
+	usersWriter.POST("/:id/password-reset", userAdminHandler.ForcePasswordReset)
+
+	rolesWriter := admin.NewGroup("/users")
+	rolesWriter.Use(middlewareConstructor.RequirePermission("roles:write"))
+	rolesWriter.PUT("/:id/roles", userAdminHandler.AssignRoles)
+
+	auditReader := admin.NewGroup("/audit-events")
+	auditReader.Use(middlewareConstructor.RequirePermission("audit:read"))
+	auditReader.GET("", auditConstructor.ConstructAuditHandler(db).ListAuditEvents)
+
+	app.Go("purge_worker", accountDeletionConstructor.ConstructPurgeWorker(cfg, db, logger).Run)
+	app.Go("notification_mailer", notificationMailer.Run)
+
+	if certificates != nil {
+		app.Go("certificate_reloader", certificates.Run)
 	}
 
-	<-idleConnsClosed
+	app.Serve("admin_listener", metricsConstructor.ConstructAdminServer(cfg))
+	app.Serve("listener", &http.Server{
+		Addr:           cfg.HTTP.Addr,
+		Handler:        handler,
+		TLSConfig:      tlsConfig,
+		ReadTimeout:    cfg.HTTP.ReadTimeout,
+		WriteTimeout:   cfg.HTTP.WriteTimeout,
+		MaxHeaderBytes: cfg.HTTP.MaxHeaderBytes,
+	})
+
+	os.Exit(app.Wait())
 }
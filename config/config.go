@@ -0,0 +1,204 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// Config is the whole configuration of the API. Every field is read from the
+// environment variable named by joining the env tags of its sections and its
+// own, e.g. SQL_HOST for SQL.Host.
+type Config struct {
+	HTTP            HTTP                `env:"HTTP"`
+	TLS             TLS                 `env:"TLS"`
+	CORS            CORS                `env:"CORS"`
+	SQL             SQL                 `env:"SQL"`
+	RSA             RSA                 `env:"RSA"`
+	Password        Password            `env:"PASSWORD"`
+	Log             Log                 `env:"LOG"`
+	AccessLog       AccessLog           `env:"ACCESS_LOG"`
+	Metrics         Metrics             `env:"METRICS"`
+	Tracing         Tracing             `env:"TRACING"`
+	Health          Health              `env:"HEALTH"`
+	Shutdown        Shutdown            `env:"SHUTDOWN"`
+	Store           Store               `env:"STORE"`
+	Redis           helper.RedisConfig  `env:"REDIS"`
+	RateLimit       RateLimit           `env:"RATE_LIMIT"`
+	SMTP            helper.MailerConfig `env:"SMTP"`
+	MailQueue       MailQueue           `env:"MAIL_QUEUE"`
+	Challenge       Challenge           `env:"CHALLENGE"`
+	EmailChange     EmailChange         `env:"EMAIL_CHANGE"`
+	PasswordReset   PasswordReset       `env:"PASSWORD_RESET"`
+	AccountDeletion AccountDeletion     `env:"ACCOUNT_DELETION"`
+	Session         Session             `env:"SESSION"`
+}
+
+type HTTP struct {
+	Addr           string        `env:"ADDR" default:":7070"`
+	ReadTimeout    time.Duration `env:"READ_TIMEOUT" default:"10s"`
+	WriteTimeout   time.Duration `env:"WRITE_TIMEOUT" default:"10s"`
+	MaxHeaderBytes int           `env:"MAX_HEADER_BYTES" default:"1048576"`
+	MaxBodyBytes   int64         `env:"MAX_BODY_BYTES" default:"1048576"`
+	TrustedProxies []string      `env:"TRUSTED_PROXIES"`
+}
+
+// TLS serves the API listener over TLS when CertFile is set. ClientAuth other
+// than none verifies client certificates against the ClientCAFile bundle.
+type TLS struct {
+	CertFile       string        `env:"CERT_FILE"`
+	KeyFile        string        `env:"KEY_FILE"`
+	MinVersion     string        `env:"MIN_VERSION" default:"1.2"`
+	CipherSuites   []string      `env:"CIPHER_SUITES"`
+	ClientAuth     string        `env:"CLIENT_AUTH" default:"none"`
+	ClientCAFile   string        `env:"CLIENT_CA_FILE"`
+	ReloadInterval time.Duration `env:"RELOAD_INTERVAL" default:"1m"`
+}
+
+func (c TLS) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// CORS lets browser frontends on AllowedOrigins call the API. Cross-origin
+// requests are not allowed while AllowedOrigins is empty.
+type CORS struct {
+	AllowedOrigins   []string      `env:"ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `env:"ALLOWED_METHODS" default:"GET,POST,PUT,DELETE"`
+	AllowedHeaders   []string      `env:"ALLOWED_HEADERS" default:"Authorization,Content-Type,X-Request-ID"`
+	ExposedHeaders   []string      `env:"EXPOSED_HEADERS" default:"X-Request-ID,Retry-After,Content-Disposition"`
+	AllowCredentials bool          `env:"ALLOW_CREDENTIALS" default:"false"`
+	MaxAge           time.Duration `env:"MAX_AGE" default:"10m"`
+}
+
+type SQL struct {
+	Driver   string `env:"DRIVER" default:"mysql"`
+	Host     string `env:"HOST" default:"127.0.0.1"`
+	Port     int    `env:"PORT" default:"3306"`
+	Username string `env:"USERNAME" default:"example"`
+	Password string `env:"PASSWORD" default:"example" secret:"true"`
+	Database string `env:"DATABASE" default:"example"`
+}
+
+func (c SQL) DSN() string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s:%d)/%s?parseTime=true",
+		c.Username,
+		c.Password,
+		c.Host,
+		c.Port,
+		c.Database,
+	)
+}
+
+type RSA struct {
+	PrivateKeyPath string `env:"PRIVATE_KEY_PATH" required:"true"`
+	PublicKeyPath  string `env:"PUBLIC_KEY_PATH" required:"true"`
+}
+
+type Password struct {
+	BcryptCost int `env:"BCRYPT_COST" default:"10"`
+}
+
+type Log struct {
+	Level slog.Level `env:"LEVEL" default:"info"`
+}
+
+type AccessLog struct {
+	Format      string             `env:"FORMAT" default:"json"`
+	SampleRates map[string]float64 `env:"SAMPLE_RATES"`
+	Redact      []string           `env:"REDACT" default:"token,password,secret,code,access_token,refresh_token"`
+}
+
+type Metrics struct {
+	Addr string `env:"ADDR" default:"127.0.0.1:9090"`
+}
+
+type Tracing struct {
+	Exporter    string  `env:"EXPORTER" default:"none"`
+	ServiceName string  `env:"SERVICE_NAME" default:"example-api"`
+	SampleRatio float64 `env:"SAMPLE_RATIO" default:"1"`
+}
+
+type Health struct {
+	CheckTimeout time.Duration `env:"CHECK_TIMEOUT" default:"2s"`
+}
+
+type Shutdown struct {
+	DrainDelay time.Duration `env:"DRAIN_DELAY" default:"0s"`
+	Timeout    time.Duration `env:"TIMEOUT" default:"30s"`
+}
+
+type Store struct {
+	Backend string `env:"BACKEND" default:"memory"`
+}
+
+// Limit allows Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimit holds the limit of every rate limited route, named after the
+// route and what requests are counted by.
+type RateLimit struct {
+	ChallengeIPRequests int           `env:"CHALLENGE_IP_REQUESTS" default:"30"`
+	ChallengeIPPeriod   time.Duration `env:"CHALLENGE_IP_PERIOD" default:"1h"`
+	RegisterIPRequests  int           `env:"REGISTER_IP_REQUESTS" default:"10"`
+	RegisterIPPeriod    time.Duration `env:"REGISTER_IP_PERIOD" default:"1h"`
+	LoginIPRequests     int           `env:"LOGIN_IP_REQUESTS" default:"30"`
+	LoginIPPeriod       time.Duration `env:"LOGIN_IP_PERIOD" default:"1m"`
+	LoginEmailRequests  int           `env:"LOGIN_EMAIL_REQUESTS" default:"10"`
+	LoginEmailPeriod    time.Duration `env:"LOGIN_EMAIL_PERIOD" default:"15m"`
+}
+
+func (c RateLimit) ChallengeIP() Limit {
+	return Limit{Requests: c.ChallengeIPRequests, Period: c.ChallengeIPPeriod}
+}
+
+func (c RateLimit) RegisterIP() Limit {
+	return Limit{Requests: c.RegisterIPRequests, Period: c.RegisterIPPeriod}
+}
+
+func (c RateLimit) LoginIP() Limit {
+	return Limit{Requests: c.LoginIPRequests, Period: c.LoginIPPeriod}
+}
+
+func (c RateLimit) LoginEmail() Limit {
+	return Limit{Requests: c.LoginEmailRequests, Period: c.LoginEmailPeriod}
+}
+
+type MailQueue struct {
+	Size int `env:"SIZE" default:"100"`
+}
+
+type Challenge struct {
+	Enabled        bool          `env:"ENABLED" default:"false"`
+	Secret         string        `env:"SECRET" secret:"true"`
+	Expiration     time.Duration `env:"EXPIRATION" default:"5m"`
+	Difficulty     int           `env:"DIFFICULTY" default:"20"`
+	MaxDifficulty  int           `env:"MAX_DIFFICULTY" default:"26"`
+	SpikeThreshold int64         `env:"SPIKE_THRESHOLD" default:"30"`
+	SpikeWindow    time.Duration `env:"SPIKE_WINDOW" default:"1m"`
+}
+
+type EmailChange struct {
+	ConfirmationURL string        `env:"CONFIRMATION_URL" default:"http://localhost:7070/v1/email/confirm"`
+	TokenExpiration time.Duration `env:"TOKEN_EXPIRATION" default:"24h"`
+}
+
+type PasswordReset struct {
+	URL        string        `env:"URL" default:"http://localhost:7070/v1/password/reset"`
+	Expiration time.Duration `env:"EXPIRATION" default:"24h"`
+}
+
+type AccountDeletion struct {
+	GracePeriod   time.Duration `env:"GRACE_PERIOD" default:"720h"`
+	PurgeMode     string        `env:"PURGE_MODE" default:"anonymize"`
+	PurgeInterval time.Duration `env:"PURGE_INTERVAL" default:"1h"`
+}
+
+type Session struct {
+	TouchInterval time.Duration `env:"TOUCH_INTERVAL" default:"1m"`
+}
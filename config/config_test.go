@@ -0,0 +1,189 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/config"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+
+	env map[string]string
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, &ConfigSuite{})
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.env = map[string]string{
+		"RSA_PRIVATE_KEY_PATH": "dev/private_key",
+		"RSA_PUBLIC_KEY_PATH":  "dev/public_key",
+	}
+}
+
+func (s *ConfigSuite) lookup(key string) (string, bool) {
+	value, ok := s.env[key]
+	return value, ok
+}
+
+func (s *ConfigSuite) TestLoad_RequiredOnly_ApplyDefaults() {
+	cfg, err := config.Load(s.lookup)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(":7070", cfg.HTTP.Addr)
+	a.Equal(10*time.Second, cfg.HTTP.ReadTimeout)
+	a.Equal(slog.LevelInfo, cfg.Log.Level)
+	a.Equal(10, cfg.Password.BcryptCost)
+	a.Equal([]string{"token", "password", "secret", "code", "access_token", "refresh_token"}, cfg.AccessLog.Redact)
+	a.Equal(config.Limit{Requests: 10, Period: 15 * time.Minute}, cfg.RateLimit.LoginEmail())
+	a.Equal("example:example@tcp(127.0.0.1:3306)/example?parseTime=true", cfg.SQL.DSN())
+}
+
+func (s *ConfigSuite) TestLoad_ValuesSet_OverrideDefaults() {
+	s.env["HTTP_ADDR"] = "127.0.0.1:8080"
+	s.env["HTTP_TRUSTED_PROXIES"] = "10.0.0.0/8, 192.168.0.1"
+	s.env["LOG_LEVEL"] = "debug"
+	s.env["ACCESS_LOG_SAMPLE_RATES"] = "/healthz:0,/v1/me:0.5"
+	s.env["CHALLENGE_ENABLED"] = "true"
+	s.env["CHALLENGE_SECRET"] = "secret"
+	s.env["SHUTDOWN_TIMEOUT"] = "1m"
+	s.env["SQL_PASSWORD"] = ""
+
+	cfg, err := config.Load(s.lookup)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal("127.0.0.1:8080", cfg.HTTP.Addr)
+	a.Equal([]string{"10.0.0.0/8", "192.168.0.1"}, cfg.HTTP.TrustedProxies)
+	a.Equal(slog.LevelDebug, cfg.Log.Level)
+	a.Equal(map[string]float64{"/healthz": 0, "/v1/me": 0.5}, cfg.AccessLog.SampleRates)
+	a.True(cfg.Challenge.Enabled)
+	a.Equal(time.Minute, cfg.Shutdown.Timeout)
+	a.Equal("example", cfg.SQL.Password)
+}
+
+func (s *ConfigSuite) TestLoad_FileSuffix_ReadValueFromFile() {
+	path := filepath.Join(s.T().TempDir(), "password")
+	s.Require().NoError(os.WriteFile(path, []byte("s3cret\n"), 0o600))
+	s.env["SQL_PASSWORD_FILE"] = path
+
+	cfg, err := config.Load(s.lookup)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal("s3cret", cfg.SQL.Password)
+}
+
+func (s *ConfigSuite) TestLoad_InvalidValues_ReportEveryProblem() {
+	delete(s.env, "RSA_PUBLIC_KEY_PATH")
+	s.env["SQL_PORT"] = "abc"
+	s.env["SQL_PASSWORD"] = "example"
+	s.env["SQL_PASSWORD_FILE"] = "/run/secrets/sql_password"
+	s.env["SMTP_PASSWORD_FILE"] = filepath.Join(s.T().TempDir(), "missing")
+	s.env["HTTP_READ_TIMEOUT"] = "10"
+	s.env["PASSWORD_BCRYPT_COST"] = "3"
+	s.env["STORE_BACKEND"] = "memcached"
+	s.env["CHALLENGE_ENABLED"] = "true"
+
+	cfg, err := config.Load(s.lookup)
+
+	a := s.Assert()
+	a.Nil(cfg)
+	var configErr *config.Error
+	s.Require().ErrorAs(err, &configErr)
+	a.Len(configErr.Problems, 8)
+	a.Equal(`HTTP_READ_TIMEOUT: invalid duration "10"`, configErr.Problems[0])
+	a.Equal(`SQL_PORT: invalid integer "abc"`, configErr.Problems[1])
+	a.Equal("SQL_PASSWORD: cannot be set together with SQL_PASSWORD_FILE", configErr.Problems[2])
+	a.Equal("RSA_PUBLIC_KEY_PATH: is required", configErr.Problems[3])
+	a.Contains(configErr.Problems[4], "SMTP_PASSWORD_FILE: open ")
+	a.Equal("PASSWORD_BCRYPT_COST: must be between 4 and 31, got 3", configErr.Problems[5])
+	a.Equal(`STORE_BACKEND: must be one of ["memory" "redis"], got "memcached"`, configErr.Problems[6])
+	a.Equal("CHALLENGE_SECRET: is required when CHALLENGE_ENABLED is true", configErr.Problems[7])
+	a.Contains(err.Error(), "invalid configuration:\n  - HTTP_READ_TIMEOUT")
+}
+
+func (s *ConfigSuite) TestWrite_Secrets_Redacted() {
+	s.env["SQL_PASSWORD"] = "s3cret"
+	s.env["ACCESS_LOG_SAMPLE_RATES"] = "/v1/me:0.5,/healthz:0"
+	cfg, err := config.Load(s.lookup)
+	s.Require().NoError(err)
+
+	out := &bytes.Buffer{}
+	s.Require().NoError(config.Write(out, cfg))
+
+	a := s.Assert()
+	a.Contains(out.String(), "HTTP_ADDR=:7070\n")
+	a.Contains(out.String(), "SQL_PASSWORD=REDACTED\n")
+	a.Contains(out.String(), "CHALLENGE_SECRET=\n")
+	a.Contains(out.String(), "LOG_LEVEL=INFO\n")
+	a.Contains(out.String(), "ACCESS_LOG_SAMPLE_RATES=/healthz:0,/v1/me:0.5\n")
+	a.Contains(out.String(), "SHUTDOWN_TIMEOUT=30s\n")
+	a.NotContains(out.String(), "s3cret")
+}
+
+func (s *ConfigSuite) TestWrite_EveryCredential_Redacted() {
+	credentials := map[string]string{
+		"SQL_PASSWORD":     "sql-s3cret",
+		"REDIS_PASSWORD":   "redis-s3cret",
+		"SMTP_PASSWORD":    "smtp-s3cret",
+		"CHALLENGE_SECRET": "challenge-s3cret",
+	}
+	for key, value := range credentials {
+		s.env[key] = value
+	}
+	cfg, err := config.Load(s.lookup)
+	s.Require().NoError(err)
+
+	out := &bytes.Buffer{}
+	s.Require().NoError(config.Write(out, cfg))
+
+	a := s.Assert()
+	for key, value := range credentials {
+		a.Contains(out.String(), key+"=REDACTED\n")
+		a.NotContains(out.String(), value)
+	}
+}
+
+func (s *ConfigSuite) TestLoad_InvalidTLS_ReportEveryProblem() {
+	s.env["TLS_CERT_FILE"] = "tls.crt"
+	s.env["TLS_MIN_VERSION"] = "1.1"
+	s.env["TLS_CIPHER_SUITES"] = "TLS_RSA_WITH_RC4_128_SHA"
+	s.env["TLS_CLIENT_AUTH"] = "require"
+
+	_, err := config.Load(s.lookup)
+
+	var configErr *config.Error
+	s.Require().ErrorAs(err, &configErr)
+	s.Assert().Equal([]string{
+		"TLS_KEY_FILE: must be set together with TLS_CERT_FILE",
+		`TLS_MIN_VERSION: unsupported TLS version "1.1", expected 1.2 or 1.3`,
+		`TLS_CIPHER_SUITES: unsupported cipher suite "TLS_RSA_WITH_RC4_128_SHA"`,
+		"TLS_CLIENT_CA_FILE: is required when TLS_CLIENT_AUTH is require",
+	}, configErr.Problems)
+}
+
+func (s *ConfigSuite) TestLoad_InvalidCORSOrigins_ReportEveryProblem() {
+	s.env["CORS_ALLOWED_ORIGINS"] = "https://app.example.com,https://*.example.com,*,https://app.example.com/login,app.example.com,https://a.*.example.com"
+	s.env["CORS_ALLOW_CREDENTIALS"] = "true"
+
+	_, err := config.Load(s.lookup)
+
+	var configErr *config.Error
+	s.Require().ErrorAs(err, &configErr)
+	s.Assert().Equal([]string{
+		"CORS_ALLOWED_ORIGINS: cannot contain * when CORS_ALLOW_CREDENTIALS is true",
+		`CORS_ALLOWED_ORIGINS: must contain origins like https://app.example.com or https://*.example.com, got "https://app.example.com/login"`,
+		`CORS_ALLOWED_ORIGINS: must contain origins like https://app.example.com or https://*.example.com, got "app.example.com"`,
+		`CORS_ALLOWED_ORIGINS: must contain origins like https://app.example.com or https://*.example.com, got "https://a.*.example.com"`,
+	}, configErr.Problems)
+}
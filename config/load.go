@@ -0,0 +1,220 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileSuffix marks a variable holding the path of a file to read the value
+// from, e.g. SQL_PASSWORD_FILE for secrets mounted by the orchestrator.
+const fileSuffix = "_FILE"
+
+// Error lists every problem found while loading the configuration, so all of
+// them can be fixed at once.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load reads the configuration with lookup, usually os.LookupEnv. Unset and
+// empty variables take the default of their field. A variable suffixed with
+// _FILE is read from the file it names instead, without its trailing newline.
+// The returned error is an *Error.
+func Load(lookup func(string) (string, bool)) (*Config, error) {
+	cfg := &Config{}
+	problems := []string{}
+
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		if f.def != "" {
+			if err := decode(f.value, f.def); err != nil {
+				panic(fmt.Sprintf("invalid default of %s: %v", f.key, err))
+			}
+		}
+
+		raw, err := lookupValue(lookup, f.key)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if raw == "" {
+			if f.required {
+				problems = append(problems, fmt.Sprintf("%s: is required", f.key))
+			}
+			continue
+		}
+
+		if err := decode(f.value, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", f.key, err))
+		}
+	}
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+func lookupValue(lookup func(string) (string, bool), key string) (string, error) {
+	value, _ := lookup(key)
+	path, _ := lookup(key + fileSuffix)
+	if path == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("%s: cannot be set together with %s%s", key, key, fileSuffix)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s%s: %v", key, fileSuffix, err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+type field struct {
+	key      string
+	value    reflect.Value
+	def      string
+	required bool
+	secret   bool
+}
+
+// fields flattens the sections of v into the fields they hold, keyed by their
+// environment variable.
+func fields(v reflect.Value, prefix string) []field {
+	result := []field{}
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		key := prefix + structField.Tag.Get("env")
+		value := v.Field(i)
+
+		if value.Kind() == reflect.Struct && !isText(value) {
+			result = append(result, fields(value, key+"_")...)
+			continue
+		}
+
+		result = append(result, field{
+			key:      key,
+			value:    value,
+			def:      structField.Tag.Get("default"),
+			required: structField.Tag.Get("required") == "true",
+			secret:   structField.Tag.Get("secret") == "true",
+		})
+	}
+
+	return result
+}
+
+func isText(v reflect.Value) bool {
+	_, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	return ok
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decode sets v from raw. Lists are comma separated and maps are comma
+// separated key:value pairs.
+func decode(v reflect.Value, raw string) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		v.Set(reflect.ValueOf(splitList(raw)))
+	case reflect.Map:
+		m := map[string]float64{}
+		for _, pair := range splitList(raw) {
+			key, value, found := strings.Cut(pair, ":")
+			f, err := strconv.ParseFloat(value, 64)
+			if !found || err != nil {
+				return fmt.Errorf("invalid key:number pair %q", pair)
+			}
+			m[key] = f
+		}
+		v.Set(reflect.ValueOf(m))
+	default:
+		panic(fmt.Sprintf("unsupported config type %s", v.Type()))
+	}
+
+	return nil
+}
+
+// encode is the reverse of decode.
+func encode(v reflect.Value) string {
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, _ := marshaler.MarshalText()
+		return string(text)
+	}
+
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	case reflect.Map:
+		m := v.Interface().(map[string]float64)
+		pairs := make([]string, 0, len(m))
+		for key, value := range m {
+			pairs = append(pairs, key+":"+strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func splitList(raw string) []string {
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"littlerollingsushi.com/example/tlsconfig"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// problems collects the values failing validation.
+type problems []string
+
+func (p *problems) check(ok bool, key string, format string, args ...any) {
+	if !ok {
+		*p = append(*p, key+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (p *problems) oneOf(value, key string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+
+	p.check(false, key, "must be one of %q, got %q", allowed, value)
+}
+
+func (p *problems) port(port int, key string) {
+	p.check(port > 0 && port <= 65535, key, "must be a port between 1 and 65535, got %d", port)
+}
+
+func (p *problems) absoluteURL(raw, key string) {
+	u, err := url.Parse(raw)
+	p.check(err == nil && u.IsAbs() && u.Host != "", key, "must be an absolute URL, got %q", raw)
+}
+
+// corsOrigin accepts *, unless credentials are allowed, and origins without
+// path whose host may start with a *. wildcard label.
+func (p *problems) corsOrigin(origin string, allowCredentials bool) {
+	if origin == "*" {
+		p.check(!allowCredentials, "CORS_ALLOWED_ORIGINS", "cannot contain * when CORS_ALLOW_CREDENTIALS is true")
+		return
+	}
+
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+	p.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "" && u.RawQuery == "" && !strings.Contains(u.Host, "*"),
+		"CORS_ALLOWED_ORIGINS", "must contain origins like https://app.example.com or https://*.example.com, got %q", origin)
+}
+
+// validate checks the values a field type alone cannot constrain, such as
+// ranges and the dependencies between fields.
+func (c *Config) validate() []string {
+	p := problems{}
+
+	p.check(c.HTTP.Addr != "", "HTTP_ADDR", "is required")
+	p.check(c.HTTP.ReadTimeout > 0, "HTTP_READ_TIMEOUT", "must be positive")
+	p.check(c.HTTP.WriteTimeout > 0, "HTTP_WRITE_TIMEOUT", "must be positive")
+	p.check(c.HTTP.MaxHeaderBytes > 0, "HTTP_MAX_HEADER_BYTES", "must be positive")
+	p.check(c.HTTP.MaxBodyBytes > 0, "HTTP_MAX_BODY_BYTES", "must be positive")
+	_, err := helper.ParseTrustedProxies(c.HTTP.TrustedProxies)
+	p.check(err == nil, "HTTP_TRUSTED_PROXIES", "%v", err)
+
+	p.check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "TLS_KEY_FILE", "must be set together with TLS_CERT_FILE")
+	_, err = tlsconfig.ParseVersion(c.TLS.MinVersion)
+	p.check(err == nil, "TLS_MIN_VERSION", "%v", err)
+	_, err = tlsconfig.ParseCipherSuites(c.TLS.CipherSuites)
+	p.check(err == nil, "TLS_CIPHER_SUITES", "%v", err)
+	_, err = tlsconfig.ParseClientAuth(c.TLS.ClientAuth)
+	p.check(err == nil, "TLS_CLIENT_AUTH", "%v", err)
+	if c.TLS.ClientAuth != tlsconfig.ClientAuthNone {
+		p.check(c.TLS.Enabled(), "TLS_CERT_FILE", "is required when TLS_CLIENT_AUTH is %s", c.TLS.ClientAuth)
+		p.check(c.TLS.ClientCAFile != "", "TLS_CLIENT_CA_FILE", "is required when TLS_CLIENT_AUTH is %s", c.TLS.ClientAuth)
+	}
+	p.check(c.TLS.ReloadInterval > 0, "TLS_RELOAD_INTERVAL", "must be positive")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		p.corsOrigin(origin, c.CORS.AllowCredentials)
+	}
+	p.check(c.CORS.MaxAge >= 0, "CORS_MAX_AGE", "must be at least 0")
+
+	p.port(c.SQL.Port, "SQL_PORT")
+
+	p.check(c.Password.BcryptCost >= bcrypt.MinCost && c.Password.BcryptCost <= bcrypt.MaxCost,
+		"PASSWORD_BCRYPT_COST", "must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, c.Password.BcryptCost)
+
+	p.oneOf(c.AccessLog.Format, "ACCESS_LOG_FORMAT", "json", "combined")
+	for prefix, rate := range c.AccessLog.SampleRates {
+		p.check(rate >= 0 && rate <= 1, "ACCESS_LOG_SAMPLE_RATES", "rate of %s must be between 0 and 1, got %v", prefix, rate)
+	}
+
+	p.check(c.Metrics.Addr != "", "METRICS_ADDR", "is required")
+
+	p.oneOf(c.Tracing.Exporter, "TRACING_EXPORTER", "none", "otlp", "stdout")
+	p.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	p.check(c.Health.CheckTimeout > 0, "HEALTH_CHECK_TIMEOUT", "must be positive")
+
+	p.check(c.Shutdown.Timeout > 0, "SHUTDOWN_TIMEOUT", "must be positive")
+	p.check(c.Shutdown.DrainDelay >= 0 && c.Shutdown.DrainDelay < c.Shutdown.Timeout,
+		"SHUTDOWN_DRAIN_DELAY", "must be at least 0 and shorter than SHUTDOWN_TIMEOUT, got %s", c.Shutdown.DrainDelay)
+
+	p.oneOf(c.Store.Backend, "STORE_BACKEND", "memory", "redis")
+
+	for _, limit := range []struct {
+		name  string
+		limit Limit
+	}{
+		{"CHALLENGE_IP", c.RateLimit.ChallengeIP()},
+		{"REGISTER_IP", c.RateLimit.RegisterIP()},
+		{"LOGIN_IP", c.RateLimit.LoginIP()},
+		{"LOGIN_EMAIL", c.RateLimit.LoginEmail()},
+	} {
+		p.check(limit.limit.Requests > 0, "RATE_LIMIT_"+limit.name+"_REQUESTS", "must be positive")
+		p.check(limit.limit.Period > 0, "RATE_LIMIT_"+limit.name+"_PERIOD", "must be positive")
+	}
+
+	p.port(c.SMTP.Port, "SMTP_PORT")
+	p.check(c.MailQueue.Size > 0, "MAIL_QUEUE_SIZE", "must be positive")
+
+	p.check(!c.Challenge.Enabled || c.Challenge.Secret != "", "CHALLENGE_SECRET", "is required when CHALLENGE_ENABLED is true")
+	p.check(c.Challenge.Expiration > 0, "CHALLENGE_EXPIRATION", "must be positive")
+	p.check(c.Challenge.Difficulty >= 0, "CHALLENGE_DIFFICULTY", "must be at least 0")
+	p.check(c.Challenge.MaxDifficulty >= c.Challenge.Difficulty && c.Challenge.MaxDifficulty <= 256,
+		"CHALLENGE_MAX_DIFFICULTY", "must be between CHALLENGE_DIFFICULTY and 256, got %d", c.Challenge.MaxDifficulty)
+
+	p.absoluteURL(c.EmailChange.ConfirmationURL, "EMAIL_CHANGE_CONFIRMATION_URL")
+	p.check(c.EmailChange.TokenExpiration > 0, "EMAIL_CHANGE_TOKEN_EXPIRATION", "must be positive")
+
+	p.absoluteURL(c.PasswordReset.URL, "PASSWORD_RESET_URL")
+	p.check(c.PasswordReset.Expiration > 0, "PASSWORD_RESET_EXPIRATION", "must be positive")
+
+	p.check(c.AccountDeletion.GracePeriod >= 0, "ACCOUNT_DELETION_GRACE_PERIOD", "must be at least 0")
+	p.oneOf(c.AccountDeletion.PurgeMode, "ACCOUNT_DELETION_PURGE_MODE", "delete", "anonymize")
+	p.check(c.AccountDeletion.PurgeInterval > 0, "ACCOUNT_DELETION_PURGE_INTERVAL", "must be positive")
+
+	p.check(c.Session.TouchInterval >= 0, "SESSION_TOUCH_INTERVAL", "must be at least 0")
+
+	return p
+}
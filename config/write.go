@@ -0,0 +1,26 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const redactedValue = "REDACTED"
+
+// Write writes cfg to w in the format of an env file, one KEY=value line per
+// field, with the values of secrets redacted.
+func Write(w io.Writer, cfg *Config) error {
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		value := encode(f.value)
+		if f.secret && value != "" {
+			value = redactedValue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s\n", f.key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE email_change_request (
+    id INTEGER PRIMARY KEY AUTO_INCREMENT,
+    user_id INTEGER NOT NULL,
+    new_email VARCHAR(191) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    confirmed_at DATETIME,
+    created_at DATETIME NOT NULL,
+    UNIQUE (token_hash),
+    FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
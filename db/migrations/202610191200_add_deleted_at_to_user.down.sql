@@ -0,0 +1,4 @@
+ALTER TABLE user
+    DROP INDEX idx_user_deleted_at,
+    DROP COLUMN anonymized_at,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+ALTER TABLE user
+    ADD COLUMN deleted_at DATETIME,
+    ADD COLUMN anonymized_at DATETIME,
+    ADD INDEX idx_user_deleted_at (deleted_at);
@@ -0,0 +1,3 @@
+DROP TABLE user_role;
+DROP TABLE role_permission;
+DROP TABLE role;
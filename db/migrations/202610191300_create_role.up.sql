@@ -0,0 +1,32 @@
+CREATE TABLE role (
+    id INTEGER PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(191) NOT NULL,
+    description VARCHAR(255),
+    created_at DATETIME NOT NULL,
+    UNIQUE (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE role_permission (
+    role_id INTEGER NOT NULL,
+    permission VARCHAR(191) NOT NULL,
+    PRIMARY KEY (role_id, permission),
+    FOREIGN KEY (role_id) REFERENCES role (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE user_role (
+    user_id INTEGER NOT NULL,
+    role_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, role_id),
+    FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE,
+    FOREIGN KEY (role_id) REFERENCES role (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO role (name, description, created_at) VALUES
+    ('admin', 'Full access to every administrative endpoint', UTC_TIMESTAMP()),
+    ('support', 'Read access to users for support staff', UTC_TIMESTAMP());
+
+INSERT INTO role_permission (role_id, permission)
+    SELECT id, '*' FROM role WHERE name = 'admin';
+INSERT INTO role_permission (role_id, permission)
+    SELECT id, 'users:read' FROM role WHERE name = 'support';
@@ -0,0 +1,13 @@
+CREATE TABLE audit_event (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    event_type VARCHAR(64) NOT NULL,
+    actor_user_id INTEGER,
+    user_id INTEGER,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    outcome VARCHAR(16) NOT NULL,
+    reason VARCHAR(255),
+    created_at DATETIME(3) NOT NULL,
+    INDEX idx_audit_event_user_id_created_at (user_id, created_at),
+    INDEX idx_audit_event_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
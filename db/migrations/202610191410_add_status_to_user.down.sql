@@ -0,0 +1,5 @@
+ALTER TABLE user
+    DROP COLUMN suspended_until,
+    DROP COLUMN status_changed_at,
+    DROP COLUMN status_reason,
+    DROP COLUMN status;
@@ -0,0 +1,5 @@
+ALTER TABLE user
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active',
+    ADD COLUMN status_reason VARCHAR(255),
+    ADD COLUMN status_changed_at DATETIME,
+    ADD COLUMN suspended_until DATETIME;
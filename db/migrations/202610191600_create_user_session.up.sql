@@ -0,0 +1,12 @@
+CREATE TABLE user_session (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id INTEGER NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at DATETIME(3) NOT NULL,
+    last_used_at DATETIME(3) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    revoked_at DATETIME(3),
+    INDEX idx_user_session_user_id_expires_at (user_id, expires_at),
+    FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+CREATE TABLE user_device (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id INTEGER NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    first_seen_at DATETIME(3) NOT NULL,
+    last_seen_at DATETIME(3) NOT NULL,
+    UNIQUE (user_id, fingerprint),
+    FOREIGN KEY (user_id) REFERENCES user (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
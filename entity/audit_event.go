@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent is an append-only record of something a user, or an admin acting
+// on a user, did. ActorUserID and UserID are nil when unknown.
+type AuditEvent struct {
+	ID          int64
+	Type        string
+	ActorUserID *int64
+	UserID      *int64
+	IPAddress   string
+	UserAgent   string
+	Outcome     string
+	Reason      string
+	CreatedAt   time.Time
+}
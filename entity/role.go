@@ -0,0 +1,6 @@
+package entity
+
+type Role struct {
+	Name        string
+	Permissions []string
+}
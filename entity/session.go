@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Session is created for every successful login and referenced by the sid
+// claim of the access token issued by that login.
+type Session struct {
+	ID         int64
+	UserID     int64
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+func (s Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -1,8 +1,47 @@
 package entity
 
+import "time"
+
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusDisabled  UserStatus = "disabled"
+	UserStatusPending   UserStatus = "pending"
+)
+
+// IsValid reports whether s is one of the known account statuses.
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusActive, UserStatusSuspended, UserStatusDisabled, UserStatusPending:
+		return true
+	default:
+		return false
+	}
+}
+
 type User struct {
+	ID              int64
 	FirstName       string
 	LastName        string
 	Email           string
 	CryptedPassword string
+	Status          UserStatus
+	StatusReason    string
+	StatusChangedAt *time.Time
+	SuspendedUntil  *time.Time
+	DeletedAt       *time.Time
+}
+
+// IsPendingDeletion reports whether the user asked to delete the account and
+// the grace period starting at DeletedAt has not elapsed yet at now.
+func (u User) IsPendingDeletion(now time.Time, gracePeriod time.Duration) bool {
+	return u.DeletedAt != nil && now.Before(u.DeletedAt.Add(gracePeriod))
+}
+
+// IsSuspended reports whether the user is suspended at now. A suspension
+// without SuspendedUntil lasts until it is lifted.
+func (u User) IsSuspended(now time.Time) bool {
+	return u.Status == UserStatusSuspended && (u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil))
 }
@@ -60,16 +60,15 @@ func (s *LoginSuite) TestLogin_RegisteredUser_ReturnAccessToken() {
 func (s *LoginSuite) TestLogin_UnregisteredUser_ReturnCreated() {
 	randomString, _ := helper.GenerateRandomString(31)
 	form := url.Values{}
-	form.Add("first_name", "john")
-	form.Add("last_name", "doe")
 	form.Add("email", randomString+"@email.com")
 	form.Add("password", randomString)
 
 	resp, respErr := http.Post("http://localhost:7070/v1/login", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
 	body, bodyErr := io.ReadAll(resp.Body)
 	unmarshalledBody := struct {
-		Message string `json:"message"`
-		Meta    struct {
+		Code   string `json:"code"`
+		Detail string `json:"detail"`
+		Meta   struct {
 			HttpStatus int       `json:"http_status"`
 			ServerTime time.Time `json:"server_time"`
 		}
@@ -80,6 +79,7 @@ func (s *LoginSuite) TestLogin_UnregisteredUser_ReturnCreated() {
 	a.Nil(respErr)
 	a.Nil(bodyErr)
 	a.Nil(unmarshallErr)
-	a.Equal("Invalid credentials.", unmarshalledBody.Message)
+	a.Equal("invalid_credentials", unmarshalledBody.Code)
+	a.Equal("Invalid credentials.", unmarshalledBody.Detail)
 	a.Equal(http.StatusUnauthorized, unmarshalledBody.Meta.HttpStatus)
 }
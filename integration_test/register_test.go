@@ -48,7 +48,7 @@ func (s *RegisterSuite) TestRegister_NewUser_ReturnCreated() {
 	a.Equal(http.StatusCreated, unmarshalledBody.Meta.HttpStatus)
 }
 
-func (s *RegisterSuite) TestRegister_DuplicateUser_ReturnUnprocessable() {
+func (s *RegisterSuite) TestRegister_DuplicateUser_ReturnConflict() {
 	randomString, _ := helper.GenerateRandomString(31)
 	form := url.Values{}
 	form.Add("first_name", "john")
@@ -63,8 +63,9 @@ func (s *RegisterSuite) TestRegister_DuplicateUser_ReturnUnprocessable() {
 	resp, respErr := http.Post("http://localhost:7070/v1/register", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
 	body, bodyErr := ioutil.ReadAll(resp.Body)
 	unmarshalledBody := struct {
-		Message string `json:"message"`
-		Meta    struct {
+		Code   string `json:"code"`
+		Detail string `json:"detail"`
+		Meta   struct {
 			HttpStatus int       `json:"http_status"`
 			ServerTime time.Time `json:"server_time"`
 		}
@@ -75,6 +76,7 @@ func (s *RegisterSuite) TestRegister_DuplicateUser_ReturnUnprocessable() {
 	a.Nil(respErr)
 	a.Nil(bodyErr)
 	a.Nil(unmarshallErr)
-	a.Equal("User already exists. Choose different email.", unmarshalledBody.Message)
-	a.Equal(http.StatusUnprocessableEntity, unmarshalledBody.Meta.HttpStatus)
+	a.Equal("user_already_exists", unmarshalledBody.Code)
+	a.Equal("User already exists. Choose different email.", unmarshalledBody.Detail)
+	a.Equal(http.StatusConflict, unmarshalledBody.Meta.HttpStatus)
 }
@@ -0,0 +1,17 @@
+package constructor
+
+import (
+	"log/slog"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/lifecycle"
+)
+
+// ConstructLifecycle returns the lifecycle of the API, configured with the
+// SHUTDOWN_* variables.
+func ConstructLifecycle(cfg *config.Config, logger *slog.Logger) *lifecycle.Lifecycle {
+	return lifecycle.New(logger, lifecycle.Config{
+		DrainDelay:      cfg.Shutdown.DrainDelay,
+		ShutdownTimeout: cfg.Shutdown.Timeout,
+	})
+}
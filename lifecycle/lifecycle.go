@@ -0,0 +1,215 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Exit codes returned by Wait.
+const (
+	// ExitOK means the application was asked to stop and every hook
+	// succeeded before the shutdown timeout.
+	ExitOK = 0
+	// ExitFailure means a server or worker stopped on its own with an error,
+	// e.g. a listener could not bind its address.
+	ExitFailure = 1
+	// ExitShutdownFailure means a hook failed, the shutdown timeout elapsed or
+	// a second signal cut the shutdown short.
+	ExitShutdownFailure = 2
+)
+
+type Config struct {
+	// DrainDelay is waited between the drain callbacks and the first stop
+	// hook, so load balancers notice the failing readiness probe before the
+	// listener closes.
+	DrainDelay time.Duration
+	// ShutdownTimeout bounds the whole shutdown, drain delay included.
+	ShutdownTimeout time.Duration
+}
+
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Lifecycle runs the servers and workers of the application until SIGINT or
+// SIGTERM is received, or one of them fails, then stops everything in order.
+type Lifecycle struct {
+	logger *slog.Logger
+	config Config
+
+	mu     sync.Mutex
+	drains []func()
+	hooks  []hook
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+	failed   bool
+}
+
+func New(logger *slog.Logger, config Config) *Lifecycle {
+	return &Lifecycle{logger: logger, config: config, stopped: make(chan struct{})}
+}
+
+// OnDrain registers a callback run as soon as shutdown begins, before the drain
+// delay, to stop advertising the application as ready.
+func (l *Lifecycle) OnDrain(drain func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.drains = append(l.drains, drain)
+}
+
+// OnStop registers a hook run on shutdown. Hooks run one after the other in
+// the reverse order of registration, like deferred calls, so what is set up
+// first, such as the database, is released last. The context of stop expires
+// with the shutdown timeout.
+func (l *Lifecycle) OnStop(name string, stop func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook{name: name, stop: stop})
+}
+
+// Serve starts server in the background, over TLS when it has a TLSConfig
+// providing the certificate, and registers a hook shutting it down
+// gracefully. A server failing to listen stops the application.
+func (l *Lifecycle) Serve(name string, server *http.Server) {
+	go func() {
+		listen := server.ListenAndServe
+		if server.TLSConfig != nil {
+			listen = func() error { return server.ListenAndServeTLS("", "") }
+		}
+
+		if err := listen(); !errors.Is(err, http.ErrServerClosed) {
+			l.fail(name, err)
+		}
+	}()
+
+	l.OnStop(name, server.Shutdown)
+}
+
+// Go starts a worker in the background and registers a hook cancelling its
+// context and waiting for it to return.
+func (l *Lifecycle) Go(name string, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	l.OnStop(name, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	})
+}
+
+// Stop begins the shutdown as if a signal had been received.
+func (l *Lifecycle) Stop() {
+	l.stopOnce.Do(func() { close(l.stopped) })
+}
+
+func (l *Lifecycle) fail(name string, err error) {
+	l.logger.Error("component stopped unexpectedly", "component", name, "error", err)
+
+	l.mu.Lock()
+	l.failed = true
+	l.mu.Unlock()
+
+	l.Stop()
+}
+
+// Wait blocks until SIGINT or SIGTERM is received or a component fails, runs
+// the drain callbacks and the stop hooks, and returns the exit code of the
+// process. A second signal cancels the context of the running hook.
+func (l *Lifecycle) Wait() int {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case sig := <-signals:
+		l.logger.Info("shutting down", "signal", sig.String())
+	case <-l.stopped:
+		l.logger.Info("shutting down")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.config.ShutdownTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case sig := <-signals:
+			l.logger.Warn("forcing shutdown", "signal", sig.String())
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	l.mu.Lock()
+	drains := append([]func(){}, l.drains...)
+	hooks := append([]hook{}, l.hooks...)
+	l.mu.Unlock()
+
+	for _, drain := range drains {
+		drain()
+	}
+	if len(drains) > 0 {
+		sleep(ctx, l.config.DrainDelay)
+	}
+
+	clean := true
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := l.runHook(ctx, hooks[i]); err != nil {
+			clean = false
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case l.failed:
+		return ExitFailure
+	case !clean:
+		return ExitShutdownFailure
+	default:
+		return ExitOK
+	}
+}
+
+func (l *Lifecycle) runHook(ctx context.Context, h hook) error {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = h.stop(ctx)
+	}
+	if err != nil {
+		l.logger.Error("shutdown hook failed", "hook", h.name, "error", err)
+		return err
+	}
+
+	l.logger.Info("shutdown hook done", "hook", h.name, "duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,118 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/lifecycle"
+	"littlerollingsushi.com/example/logging"
+)
+
+type LifecycleSuite struct {
+	suite.Suite
+
+	lifecycle *lifecycle.Lifecycle
+	calls     []string
+}
+
+func TestLifecycleSuite(t *testing.T) {
+	suite.Run(t, &LifecycleSuite{})
+}
+
+func (s *LifecycleSuite) SetupTest() {
+	s.lifecycle = lifecycle.New(logging.New(io.Discard, slog.LevelInfo), lifecycle.Config{
+		DrainDelay:      time.Millisecond,
+		ShutdownTimeout: 100 * time.Millisecond,
+	})
+	s.calls = []string{}
+}
+
+func (s *LifecycleSuite) record(name string, err error) func(context.Context) error {
+	return func(context.Context) error {
+		s.calls = append(s.calls, name)
+		return err
+	}
+}
+
+func (s *LifecycleSuite) TestWait_Stopped_DrainThenRunHooksInReverseOrder() {
+	s.lifecycle.OnStop("database", s.record("database", nil))
+	s.lifecycle.OnStop("listener", s.record("listener", nil))
+	s.lifecycle.OnDrain(func() { s.calls = append(s.calls, "drain") })
+
+	s.lifecycle.Stop()
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitOK, s.lifecycle.Wait())
+	a.Equal([]string{"drain", "listener", "database"}, s.calls)
+}
+
+func (s *LifecycleSuite) TestWait_HookFails_RunRemainingHooksAndReturnShutdownFailure() {
+	s.lifecycle.OnStop("database", s.record("database", nil))
+	s.lifecycle.OnStop("listener", s.record("listener", errors.New("mock error")))
+
+	s.lifecycle.Stop()
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitShutdownFailure, s.lifecycle.Wait())
+	a.Equal([]string{"listener", "database"}, s.calls)
+}
+
+func (s *LifecycleSuite) TestWait_TimeoutElapses_SkipRemainingHooksAndReturnShutdownFailure() {
+	s.lifecycle.OnStop("database", s.record("database", nil))
+	s.lifecycle.OnStop("listener", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	s.lifecycle.Stop()
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitShutdownFailure, s.lifecycle.Wait())
+	a.Empty(s.calls)
+}
+
+func (s *LifecycleSuite) TestGo_Stopped_CancelWorkerAndWaitForIt() {
+	returned := false
+	s.lifecycle.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		returned = true
+	})
+
+	s.lifecycle.Stop()
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitOK, s.lifecycle.Wait())
+	a.True(returned)
+}
+
+func (s *LifecycleSuite) TestServe_Stopped_ShutDownServer() {
+	server := &http.Server{Addr: "127.0.0.1:0"}
+	s.lifecycle.Serve("listener", server)
+
+	s.lifecycle.Stop()
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitOK, s.lifecycle.Wait())
+	a.ErrorIs(server.ListenAndServe(), http.ErrServerClosed)
+}
+
+func (s *LifecycleSuite) TestServe_AddressInUse_ReturnFailure() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	s.lifecycle.OnStop("database", s.record("database", nil))
+	s.lifecycle.Serve("listener", &http.Server{Addr: listener.Addr().String()})
+
+	a := s.Assert()
+	a.Equal(lifecycle.ExitFailure, s.lifecycle.Wait())
+	a.Equal([]string{"database"}, s.calls)
+}
@@ -0,0 +1,17 @@
+package constructor
+
+import (
+	"log/slog"
+	"os"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/logging"
+)
+
+// ConstructLogger returns the logger of the API and makes it the default one,
+// so records of the standard log package are written as JSON too.
+func ConstructLogger(cfg *config.Config) *slog.Logger {
+	logger := logging.New(os.Stderr, cfg.Log.Level)
+	slog.SetDefault(logger)
+	return logger
+}
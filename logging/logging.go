@@ -0,0 +1,38 @@
+// Package logging provides the structured logger of the API. Records are JSON
+// lines, and a request carries a logger in its context holding the fields of
+// the request (id, route, user), so everything logged while serving a request
+// can be correlated.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// New returns a logger writing JSON records of level and above to w.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+type loggerContextKey struct{}
+
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger of the request ctx belongs to, or the default
+// logger outside of a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// With returns a context whose logger adds args to every record, for fields
+// learned while the request is served, such as the authenticated user.
+func With(ctx context.Context, args ...any) context.Context {
+	return ContextWithLogger(ctx, FromContext(ctx).With(args...))
+}
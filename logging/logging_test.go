@@ -0,0 +1,68 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+)
+
+type LoggingSuite struct {
+	suite.Suite
+
+	output *bytes.Buffer
+	logger *slog.Logger
+}
+
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, &LoggingSuite{})
+}
+
+func (s *LoggingSuite) SetupTest() {
+	s.output = &bytes.Buffer{}
+	s.logger = logging.New(s.output, slog.LevelInfo)
+}
+
+func (s *LoggingSuite) record() map[string]interface{} {
+	record := map[string]interface{}{}
+	s.Require().NoError(json.Unmarshal(s.output.Bytes(), &record))
+	return record
+}
+
+func (s *LoggingSuite) TestNew_WriteJSONRecord() {
+	s.logger.Error("failed", "error", "boom")
+
+	record := s.record()
+	a := s.Assert()
+	a.Equal("ERROR", record["level"])
+	a.Equal("failed", record["msg"])
+	a.Equal("boom", record["error"])
+	a.Contains(record, "time")
+}
+
+func (s *LoggingSuite) TestNew_BelowLevel_Discard() {
+	s.logger.Debug("noise")
+
+	s.Assert().Zero(s.output.Len())
+}
+
+func (s *LoggingSuite) TestWith_AddFieldsToContextLogger() {
+	ctx := logging.ContextWithLogger(context.Background(), s.logger)
+	ctx = logging.With(ctx, "request_id", "abc")
+	ctx = logging.With(ctx, "user", "42")
+
+	logging.FromContext(ctx).Info("served")
+
+	record := s.record()
+	a := s.Assert()
+	a.Equal("abc", record["request_id"])
+	a.Equal("42", record["user"])
+}
+
+func (s *LoggingSuite) TestFromContext_NoLogger_ReturnDefault() {
+	s.Assert().Same(slog.Default(), logging.FromContext(context.Background()))
+}
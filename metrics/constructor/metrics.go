@@ -0,0 +1,39 @@
+package constructor
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/metrics"
+)
+
+var (
+	sharedMetrics     *metrics.Metrics
+	sharedMetricsOnce sync.Once
+)
+
+// ConstructMetrics returns the metrics shared by every constructor, so each
+// collector is registered once.
+func ConstructMetrics() *metrics.Metrics {
+	sharedMetricsOnce.Do(func() {
+		sharedMetrics = metrics.New()
+	})
+
+	return sharedMetrics
+}
+
+// ConstructAdminServer returns the server of the admin listener serving
+// /metrics. It listens on localhost unless METRICS_ADDR says otherwise, as the
+// metrics are meant for the scraper and not for API clients.
+func ConstructAdminServer(cfg *config.Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", ConstructMetrics().Handler())
+
+	return &http.Server{
+		Addr:              cfg.Metrics.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
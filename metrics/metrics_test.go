@@ -0,0 +1,73 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/metrics"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+
+	metrics *metrics.Metrics
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, &MetricsSuite{})
+}
+
+func (s *MetricsSuite) SetupTest() {
+	s.metrics = metrics.New()
+}
+
+func (s *MetricsSuite) scrape() string {
+	responseWriter := httptest.NewRecorder()
+	s.metrics.Handler().ServeHTTP(responseWriter, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, _ := io.ReadAll(responseWriter.Result().Body)
+	return string(body)
+}
+
+func (s *MetricsSuite) TestObserveRequest_CountByRouteAndStatus() {
+	s.metrics.ObserveRequest("GET", "/v1/admin/users/:id", http.StatusOK, 30*time.Millisecond)
+	s.metrics.ObserveRequest("GET", "/v1/admin/users/:id", http.StatusOK, 40*time.Millisecond)
+	s.metrics.ObserveRequest("GET", "/v1/admin/users/:id", http.StatusNotFound, 10*time.Millisecond)
+
+	a := s.Assert()
+	a.Equal(2.0, testutil.ToFloat64(s.metrics.HTTPRequests.WithLabelValues("GET", "/v1/admin/users/:id", "200")))
+	a.Equal(1.0, testutil.ToFloat64(s.metrics.HTTPRequests.WithLabelValues("GET", "/v1/admin/users/:id", "404")))
+	a.Contains(s.scrape(), `example_http_request_duration_seconds_count{method="GET",route="/v1/admin/users/:id"} 3`)
+}
+
+func (s *MetricsSuite) TestObservePasswordHash_ObserveByOperation() {
+	s.metrics.ObservePasswordHash("compare", 80*time.Millisecond)
+
+	s.Assert().Contains(s.scrape(), `example_password_hash_duration_seconds_bucket{operation="compare",le="0.1"} 1`)
+}
+
+func (s *MetricsSuite) TestOutcomeCounters_Expose() {
+	s.metrics.LoginAttempts.WithLabelValues("invalid_password").Inc()
+	s.metrics.Registrations.WithLabelValues("duplicate").Inc()
+
+	body := s.scrape()
+	a := s.Assert()
+	a.Contains(body, `example_login_attempts_total{outcome="invalid_password"} 1`)
+	a.Contains(body, `example_registrations_total{outcome="duplicate"} 1`)
+}
+
+func (s *MetricsSuite) TestRegisterDB_ExposePoolStats() {
+	db, _, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+
+	s.metrics.RegisterDB(db, "example")
+
+	s.Assert().Contains(s.scrape(), `go_sql_max_open_connections{db_name="example"}`)
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type AccessLogFormat string
+
+const (
+	AccessLogFormatJSON     AccessLogFormat = "json"
+	AccessLogFormatCombined AccessLogFormat = "combined"
+
+	redactedQueryValue = "REDACTED"
+	combinedTimeFormat = "02/Jan/2006:15:04:05 -0700"
+)
+
+type AccessLogConfig struct {
+	Format AccessLogFormat
+	// SampleRates maps route prefixes to the share of their requests that is
+	// logged, between 0 and 1. The longest matching prefix wins, other routes
+	// are always logged, and so are server errors whatever the rate.
+	SampleRates map[string]float64
+	// RedactedQueryParams are query parameters whose values are replaced
+	// before logging, matched case-insensitively.
+	RedactedQueryParams []string
+	// Sample returns a number in [0, 1) to decide whether a request is
+	// sampled, rand.Float64 when nil.
+	Sample func() float64
+}
+
+// AccessLog writes one entry per request to out, as a JSON record or a line in
+// the Apache combined format. The route pattern is logged instead of the path,
+// so the entries of a route can be grouped however many ids it serves. It
+// must run after RequestID and ClientIP.
+func AccessLog(out io.Writer, cfg AccessLogConfig, timer helper.Timer) httptreemux.MiddlewareFunc {
+	sample := cfg.Sample
+	if sample == nil {
+		sample = rand.Float64
+	}
+
+	redacted := map[string]bool{}
+	for _, param := range cfg.RedactedQueryParams {
+		redacted[strings.ToLower(param)] = true
+	}
+
+	write := writeCombinedAccessLog(out)
+	if cfg.Format != AccessLogFormatCombined {
+		write = writeJSONAccessLog(logging.New(out, slog.LevelInfo))
+	}
+
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			start := timer.NowInUTC()
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next(recorder, r, params)
+
+			route := routePattern(r.URL.Path, params)
+			if recorder.status < http.StatusInternalServerError && sample() >= sampleRate(cfg.SampleRates, route) {
+				return
+			}
+
+			write(r.Context(), accessLogEntry{
+				time:      start,
+				method:    r.Method,
+				route:     route,
+				query:     redactQuery(r.URL.Query(), redacted),
+				proto:     r.Proto,
+				status:    recorder.status,
+				bytes:     recorder.bytes,
+				latency:   timer.NowInUTC().Sub(start),
+				clientIP:  helper.ClientIP(r),
+				requestID: helper.RequestIDFromContext(r.Context()),
+				referer:   r.Referer(),
+				userAgent: r.UserAgent(),
+			})
+		}
+	}
+}
+
+type accessLogEntry struct {
+	time      time.Time
+	method    string
+	route     string
+	query     string
+	proto     string
+	status    int
+	bytes     int64
+	latency   time.Duration
+	clientIP  string
+	requestID string
+	referer   string
+	userAgent string
+}
+
+func (e accessLogEntry) target() string {
+	if e.query == "" {
+		return e.route
+	}
+
+	return e.route + "?" + e.query
+}
+
+func writeJSONAccessLog(logger *slog.Logger) func(context.Context, accessLogEntry) {
+	return func(ctx context.Context, e accessLogEntry) {
+		logger.LogAttrs(ctx, slog.LevelInfo, "access",
+			slog.String("method", e.method),
+			slog.String("route", e.route),
+			slog.String("query", e.query),
+			slog.Int("status", e.status),
+			slog.Int64("bytes", e.bytes),
+			slog.Float64("latency_ms", float64(e.latency.Microseconds())/1000),
+			slog.String("client_ip", e.clientIP),
+			slog.String("request_id", e.requestID),
+			slog.String("referer", e.referer),
+			slog.String("user_agent", e.userAgent),
+		)
+	}
+}
+
+// writeCombinedAccessLog writes the Apache combined format followed by the
+// request id and the latency in microseconds, which log parsers for the
+// combined format skip as trailing fields.
+func writeCombinedAccessLog(out io.Writer) func(context.Context, accessLogEntry) {
+	return func(_ context.Context, e accessLogEntry) {
+		fmt.Fprintf(out, "%s - - [%s] %q %d %s %q %q %s %d\n",
+			e.clientIP,
+			e.time.Format(combinedTimeFormat),
+			e.method+" "+e.target()+" "+e.proto,
+			e.status,
+			combinedBytes(e.bytes),
+			dashIfEmpty(e.referer),
+			dashIfEmpty(e.userAgent),
+			dashIfEmpty(e.requestID),
+			e.latency.Microseconds(),
+		)
+	}
+}
+
+func combinedBytes(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+
+	return fmt.Sprint(bytes)
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func sampleRate(rates map[string]float64, route string) float64 {
+	rate, matched := 1.0, ""
+	for prefix, r := range rates {
+		if strings.HasPrefix(route, prefix) && len(prefix) > len(matched) {
+			rate, matched = r, prefix
+		}
+	}
+
+	return rate
+}
+
+// redactQuery encodes query with the values of redacted parameters replaced.
+// Parameters are sorted, so the entries of one route read the same.
+func redactQuery(query url.Values, redacted map[string]bool) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{}
+	for _, name := range names {
+		for _, value := range query[name] {
+			if redacted[strings.ToLower(name)] {
+				value = redactedQueryValue
+			}
+			parts = append(parts, url.QueryEscape(name)+"="+url.QueryEscape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// responseRecorder remembers the status and size of the response for the
+// access log.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
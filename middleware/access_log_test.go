@@ -0,0 +1,129 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AccessLogSuite struct {
+	suite.Suite
+
+	request *http.Request
+	output  *bytes.Buffer
+	timer   *helperMocks.Timer
+	config  middleware.AccessLogConfig
+
+	expectedTimestamp time.Time
+}
+
+func TestAccessLogSuite(t *testing.T) {
+	suite.Run(t, &AccessLogSuite{})
+}
+
+func (s *AccessLogSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "/v1/admin/users/42?token=secret-token&limit=10", nil)
+	s.request.RemoteAddr = "10.0.0.2:51234"
+	s.request.Header.Set("X-Forwarded-For", "203.0.113.7")
+	s.request.Header.Set("User-Agent", "curl/7.86.0")
+	s.request.Header.Set(middleware.RequestIDHeader, "abc")
+	s.output = &bytes.Buffer{}
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.config = middleware.AccessLogConfig{
+		Format:              middleware.AccessLogFormatJSON,
+		RedactedQueryParams: []string{"Token"},
+		Sample:              func() float64 { return 0.5 },
+	}
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *AccessLogSuite) serve(status int) {
+	proxies, _ := helper.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	router := httptreemux.New()
+	router.Use(middleware.RequestID())
+	router.Use(middleware.ClientIP(proxies))
+	router.Use(middleware.AccessLog(s.output, s.config, s.timer))
+	router.GET("/v1/admin/users/:id", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"user":{}}`))
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), s.request)
+}
+
+func (s *AccessLogSuite) expectTimer() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp).Once()
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp.Add(1500 * time.Microsecond)).Once()
+}
+
+func (s *AccessLogSuite) TestAccessLog_JSON_LogRequest() {
+	s.expectTimer()
+
+	s.serve(http.StatusOK)
+
+	record := map[string]interface{}{}
+	a := s.Assert()
+	a.NoError(json.Unmarshal(s.output.Bytes(), &record))
+	a.Equal("access", record["msg"])
+	a.Equal("GET", record["method"])
+	a.Equal("/v1/admin/users/:id", record["route"])
+	a.Equal("limit=10&token=REDACTED", record["query"])
+	a.Equal(float64(200), record["status"])
+	a.Equal(float64(11), record["bytes"])
+	a.Equal(1.5, record["latency_ms"])
+	a.Equal("203.0.113.7", record["client_ip"])
+	a.Equal("abc", record["request_id"])
+	a.Equal("curl/7.86.0", record["user_agent"])
+	a.NotContains(s.output.String(), "secret-token")
+	a.NotContains(s.output.String(), "/42")
+}
+
+func (s *AccessLogSuite) TestAccessLog_Combined_LogRequest() {
+	s.config.Format = middleware.AccessLogFormatCombined
+	s.expectTimer()
+
+	s.serve(http.StatusNotFound)
+
+	s.Assert().Equal(
+		`203.0.113.7 - - [29/Oct/2022:23:59:59 +0000] "GET /v1/admin/users/:id?limit=10&token=REDACTED HTTP/1.1" 404 11 "-" "curl/7.86.0" abc 1500`+"\n",
+		s.output.String(),
+	)
+}
+
+func (s *AccessLogSuite) TestAccessLog_SampledOut_Skip() {
+	s.config.SampleRates = map[string]float64{"/v1/admin": 1, "/v1/admin/users": 0.1}
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp).Once()
+
+	s.serve(http.StatusOK)
+
+	s.Assert().Zero(s.output.Len())
+}
+
+func (s *AccessLogSuite) TestAccessLog_SampledIn_Log() {
+	s.config.SampleRates = map[string]float64{"/v1/admin": 0.1, "/v1/admin/users": 0.9}
+	s.expectTimer()
+
+	s.serve(http.StatusOK)
+
+	s.Assert().NotZero(s.output.Len())
+}
+
+func (s *AccessLogSuite) TestAccessLog_ServerErrorSampledOut_LogAnyway() {
+	s.config.SampleRates = map[string]float64{"/v1/admin": 0}
+	s.expectTimer()
+
+	s.serve(http.StatusInternalServerError)
+
+	s.Assert().Contains(s.output.String(), `"status":500`)
+}
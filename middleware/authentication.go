@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+//go:generate mockery --name=AccessTokenVerifier --output=./mocks
+type AccessTokenVerifier interface {
+	VerifyAuthorizationHeader(header string) (*helper.AccessTokenClaims, error)
+}
+
+//go:generate mockery --name=SessionChecker --output=./mocks
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, userID, sessionID int64) (bool, error)
+}
+
+// Authenticate rejects requests without a valid bearer access token, or whose
+// token belongs to a revoked or expired session, and stores the verified
+// claims in the request context for the next handler. Records logged for the
+// request carry the user id from then on.
+func Authenticate(verifier AccessTokenVerifier, sessions SessionChecker, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			claims, err := verifier.VerifyAuthorizationHeader(r.Header.Get("Authorization"))
+			if err != nil {
+				writeUnauthorizedResponse(w, timer)
+				return
+			}
+
+			active, err := sessions.IsSessionActive(r.Context(), claims.UserID, claims.SessionID)
+			if err != nil {
+				problem.WriteInternalError(w, r, timer, err)
+				return
+			}
+
+			if !active {
+				writeUnauthorizedResponse(w, timer)
+				return
+			}
+
+			ctx := helper.ContextWithAccessTokenClaims(r.Context(), claims)
+			ctx = logging.With(ctx, "user_id", claims.UserID)
+			next(w, r.WithContext(ctx), params)
+		}
+	}
+}
+
+func writeUnauthorizedResponse(w http.ResponseWriter, timer helper.Timer) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="littlerollingsushi.com"`)
+	problem.Write(w, timer, problem.Unauthorized, "Missing or invalid access token.")
+}
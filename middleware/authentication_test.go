@@ -0,0 +1,156 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/middleware/mocks"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AuthenticateSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	verifier *mocks.AccessTokenVerifier
+	sessions *mocks.SessionChecker
+	timer    *helperMocks.Timer
+
+	claims            *helper.AccessTokenClaims
+	nextClaims        *helper.AccessTokenClaims
+	nextCalled        bool
+	expectedTimestamp time.Time
+}
+
+func TestAuthenticateSuite(t *testing.T) {
+	suite.Run(t, &AuthenticateSuite{})
+}
+
+func (s *AuthenticateSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.request.Header.Set("Authorization", "Bearer very secure access token")
+	s.requestParams = map[string]string{}
+	s.responseWriter = httptest.NewRecorder()
+
+	s.verifier = mocks.NewAccessTokenVerifier(s.T())
+	s.sessions = mocks.NewSessionChecker(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.claims = &helper.AccessTokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "john.doe@email.com"}, UserID: 7, SessionID: 3}
+	s.nextClaims = nil
+	s.nextCalled = false
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *AuthenticateSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *AuthenticateSuite) next(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.nextCalled = true
+	s.nextClaims, _ = helper.AccessTokenClaimsFromContext(r.Context())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AuthenticateSuite) TestAuthenticate_InvalidToken_ReturnUnauthorized() {
+	s.verifier.On("VerifyAuthorizationHeader", "Bearer very secure access token").Return(nil, errors.New("mock error"))
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.Authenticate(s.verifier, s.sessions, s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.NotEmpty(resp.Header.Get("WWW-Authenticate"))
+	a.JSONEq(s.expectedProblemBody(problem.Unauthorized, "Missing or invalid access token."), string(body))
+}
+
+func (s *AuthenticateSuite) TestAuthenticate_InactiveSession_ReturnUnauthorized() {
+	s.verifier.On("VerifyAuthorizationHeader", "Bearer very secure access token").Return(s.claims, nil)
+	s.sessions.On("IsSessionActive", s.request.Context(), int64(7), int64(3)).Return(false, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.Authenticate(s.verifier, s.sessions, s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Unauthorized, "Missing or invalid access token."), string(body))
+}
+
+func (s *AuthenticateSuite) TestAuthenticate_SessionCheckError_ReturnInternalServerError() {
+	s.verifier.On("VerifyAuthorizationHeader", "Bearer very secure access token").Return(s.claims, nil)
+	s.sessions.On("IsSessionActive", s.request.Context(), int64(7), int64(3)).Return(false, errors.New("mock error"))
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.Authenticate(s.verifier, s.sessions, s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *AuthenticateSuite) TestAuthenticate_ValidToken_CallNextWithClaims() {
+	s.verifier.On("VerifyAuthorizationHeader", "Bearer very secure access token").Return(s.claims, nil)
+	s.sessions.On("IsSessionActive", s.request.Context(), int64(7), int64(3)).Return(true, nil)
+
+	middleware.Authenticate(s.verifier, s.sessions, s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	a := s.Assert()
+	a.True(s.nextCalled)
+	a.Equal(s.claims, s.nextClaims)
+	a.Equal(http.StatusNoContent, s.responseWriter.Result().StatusCode)
+}
+
+func (s *AuthenticateSuite) TestAuthenticate_ValidToken_AddUserIDToLogger() {
+	output := &bytes.Buffer{}
+	s.request = s.request.WithContext(logging.ContextWithLogger(s.request.Context(), logging.New(output, slog.LevelInfo)))
+	s.verifier.On("VerifyAuthorizationHeader", "Bearer very secure access token").Return(s.claims, nil)
+	s.sessions.On("IsSessionActive", s.request.Context(), int64(7), int64(3)).Return(true, nil)
+
+	middleware.Authenticate(s.verifier, s.sessions, s.timer)(func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		logging.FromContext(r.Context()).Info("handled")
+	})(s.responseWriter, s.request, s.requestParams)
+
+	record := map[string]interface{}{}
+	a := s.Assert()
+	a.NoError(json.Unmarshal(output.Bytes(), &record))
+	a.Equal(float64(7), record["user_id"])
+	a.NotContains(output.String(), "john.doe@email.com")
+}
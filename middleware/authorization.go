@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// RequirePermission rejects requests whose access token does not grant the
+// given permission. It must run after Authenticate.
+func RequirePermission(permission string, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+			if !ok {
+				writeUnauthorizedResponse(w, timer)
+				return
+			}
+
+			if !claims.HasPermission(permission) {
+				writeForbiddenResponse(w, timer)
+				return
+			}
+
+			next(w, r, params)
+		}
+	}
+}
+
+func writeForbiddenResponse(w http.ResponseWriter, timer helper.Timer) {
+	problem.Write(w, timer, problem.Forbidden, "You do not have permission to access this resource.")
+}
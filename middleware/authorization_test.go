@@ -0,0 +1,118 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type RequirePermissionSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	timer *helperMocks.Timer
+
+	nextCalled        bool
+	expectedTimestamp time.Time
+}
+
+func TestRequirePermissionSuite(t *testing.T) {
+	suite.Run(t, &RequirePermissionSuite{})
+}
+
+func (s *RequirePermissionSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/admin/users", nil)
+	s.requestParams = map[string]string{}
+	s.responseWriter = httptest.NewRecorder()
+
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.nextCalled = false
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *RequirePermissionSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *RequirePermissionSuite) next(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	s.nextCalled = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *RequirePermissionSuite) withPermissions(permissions ...string) {
+	claims := &helper.AccessTokenClaims{Permissions: permissions}
+	s.request = s.request.WithContext(helper.ContextWithAccessTokenClaims(s.request.Context(), claims))
+}
+
+func (s *RequirePermissionSuite) TestRequirePermission_NoClaims_ReturnUnauthorized() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.RequirePermission("users:read", s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusUnauthorized, s.responseWriter.Result().StatusCode)
+}
+
+func (s *RequirePermissionSuite) TestRequirePermission_MissingPermission_ReturnForbidden() {
+	s.withPermissions("audit:read", "users:write")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.RequirePermission("users:read", s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Forbidden, "You do not have permission to access this resource."), string(body))
+}
+
+func (s *RequirePermissionSuite) TestRequirePermission_ExactPermission_CallNext() {
+	s.withPermissions("users:read")
+
+	middleware.RequirePermission("users:read", s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().True(s.nextCalled)
+}
+
+func (s *RequirePermissionSuite) TestRequirePermission_ResourceWildcard_CallNext() {
+	s.withPermissions("users:*")
+
+	middleware.RequirePermission("users:read", s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().True(s.nextCalled)
+}
+
+func (s *RequirePermissionSuite) TestRequirePermission_SuperPermission_CallNext() {
+	s.withPermissions("*")
+
+	middleware.RequirePermission("users:read", s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().True(s.nextCalled)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// LimitRequestBody rejects requests declaring a body larger than maxBytes and
+// cuts off bodies that turn out larger while being read, so no handler reads
+// more than maxBytes whatever it does with the body.
+func LimitRequestBody(maxBytes int64, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			if r.ContentLength > maxBytes {
+				problem.Write(w, timer, problem.RequestBodyTooLarge, "Request body is too large.")
+				return
+			}
+
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+
+			next(w, r, params)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/problem"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type LimitRequestBodySuite struct {
+	suite.Suite
+
+	responseWriter *httptest.ResponseRecorder
+
+	timer *helperMocks.Timer
+
+	nextCalled        bool
+	readErr           error
+	expectedTimestamp time.Time
+}
+
+func TestLimitRequestBodySuite(t *testing.T) {
+	suite.Run(t, &LimitRequestBodySuite{})
+}
+
+func (s *LimitRequestBodySuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.nextCalled = false
+	s.readErr = nil
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *LimitRequestBodySuite) next(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.nextCalled = true
+	_, s.readErr = io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *LimitRequestBodySuite) TestLimitRequestBody_DeclaredTooLarge_ReturnRequestBodyTooLarge() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+	request := httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(strings.Repeat("a", 11)))
+
+	middleware.LimitRequestBody(10, s.timer)(s.next)(s.responseWriter, request, nil)
+
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(problem.RequestBodyTooLarge.Status, s.responseWriter.Result().StatusCode)
+}
+
+func (s *LimitRequestBodySuite) TestLimitRequestBody_UndeclaredTooLarge_FailRead() {
+	request := httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(strings.Repeat("a", 11)))
+	request.ContentLength = -1
+
+	middleware.LimitRequestBody(10, s.timer)(s.next)(s.responseWriter, request, nil)
+
+	var maxBytesErr *http.MaxBytesError
+	a := s.Assert()
+	a.True(s.nextCalled)
+	a.True(errors.As(s.readErr, &maxBytesErr))
+}
+
+func (s *LimitRequestBodySuite) TestLimitRequestBody_WithinLimit_CallNext() {
+	request := httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(strings.Repeat("a", 10)))
+
+	middleware.LimitRequestBody(10, s.timer)(s.next)(s.responseWriter, request, nil)
+
+	a := s.Assert()
+	a.True(s.nextCalled)
+	a.NoError(s.readErr)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import httptreemux "github.com/dimfeld/httptreemux/v5"
+
+// Chain composes middlewares into one. The first one runs outermost, the same
+// order successive Use calls on a router or group give, so a chain can be
+// shared between groups or attached to a single route with Wrap.
+func Chain(middlewares ...httptreemux.MiddlewareFunc) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+
+		return next
+	}
+}
+
+// Wrap applies middlewares to one route only, for middleware the other routes
+// of a group must not get. They run after the router and group middlewares.
+func Wrap(handler httptreemux.HandlerFunc, middlewares ...httptreemux.MiddlewareFunc) httptreemux.HandlerFunc {
+	return Chain(middlewares...)(handler)
+}
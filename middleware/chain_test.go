@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+)
+
+type ChainSuite struct {
+	suite.Suite
+
+	calls []string
+}
+
+func TestChainSuite(t *testing.T) {
+	suite.Run(t, &ChainSuite{})
+}
+
+func (s *ChainSuite) SetupTest() {
+	s.calls = nil
+}
+
+func (s *ChainSuite) record(name string) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			s.calls = append(s.calls, name)
+			next(w, r, params)
+		}
+	}
+}
+
+func (s *ChainSuite) handler(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	s.calls = append(s.calls, "handler")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *ChainSuite) TestChain_FirstMiddlewareRunsOutermost() {
+	chained := middleware.Chain(s.record("first"), s.record("second"), s.record("third"))(s.handler)
+
+	chained(httptest.NewRecorder(), httptest.NewRequest("GET", "http://test.com/", nil), nil)
+
+	s.Assert().Equal([]string{"first", "second", "third", "handler"}, s.calls)
+}
+
+func (s *ChainSuite) TestChain_NoMiddleware_CallHandler() {
+	middleware.Chain()(s.handler)(httptest.NewRecorder(), httptest.NewRequest("GET", "http://test.com/", nil), nil)
+
+	s.Assert().Equal([]string{"handler"}, s.calls)
+}
+
+func (s *ChainSuite) TestWrap_RunsAfterGroupMiddleware() {
+	router := httptreemux.New()
+	router.Use(s.record("router"))
+	group := router.NewGroup("/v1")
+	group.Use(s.record("group"))
+	group.GET("/wrapped", middleware.Wrap(s.handler, s.record("route")))
+	group.GET("/plain", s.handler)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/wrapped", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/plain", nil))
+
+	s.Assert().Equal([]string{"router", "group", "route", "handler", "router", "group", "handler"}, s.calls)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// ClientIP resolves the address of the client behind the trusted proxies and
+// stores it in the request context, where helper.ClientIP reads it. The rate
+// limits, the access log and the handlers recording IP addresses then all see
+// the same client.
+func ClientIP(proxies helper.TrustedProxies) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			clientIP := proxies.ClientIP(r)
+			next(w, r.WithContext(helper.ContextWithClientIP(r.Context(), clientIP)), params)
+		}
+	}
+}
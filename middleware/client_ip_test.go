@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type ClientIPSuite struct {
+	suite.Suite
+
+	request *http.Request
+	proxies helper.TrustedProxies
+
+	contextClientIP string
+}
+
+func TestClientIPSuite(t *testing.T) {
+	suite.Run(t, &ClientIPSuite{})
+}
+
+func (s *ClientIPSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/login", nil)
+	s.request.RemoteAddr = "10.0.0.2:51234"
+	s.request.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	proxies, err := helper.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	s.Require().NoError(err)
+	s.proxies = proxies
+
+	s.contextClientIP = ""
+}
+
+func (s *ClientIPSuite) next(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.contextClientIP = helper.ClientIP(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *ClientIPSuite) TestClientIP_TrustedPeer_StoreForwardedClient() {
+	middleware.ClientIP(s.proxies)(s.next)(httptest.NewRecorder(), s.request, nil)
+
+	s.Assert().Equal("203.0.113.7", s.contextClientIP)
+}
+
+func (s *ClientIPSuite) TestClientIP_NoTrustedProxies_StorePeer() {
+	middleware.ClientIP(helper.TrustedProxies{})(s.next)(httptest.NewRecorder(), s.request, nil)
+
+	s.Assert().Equal("10.0.0.2", s.contextClientIP)
+}
+
+func (s *ClientIPSuite) TestKeyByClientIP_AfterClientIP_KeyByForwardedClient() {
+	key := ""
+	middleware.ClientIP(s.proxies)(func(_ http.ResponseWriter, r *http.Request, _ map[string]string) {
+		key = middleware.KeyByClientIP("login")(r)
+	})(httptest.NewRecorder(), s.request, nil)
+
+	s.Assert().Equal("login:ip:203.0.113.7", key)
+}
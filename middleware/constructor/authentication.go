@@ -0,0 +1,19 @@
+package constructor
+
+import (
+	"crypto/rsa"
+	"database/sql"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+	sessionConstructor "littlerollingsushi.com/example/usecase/session/constructor"
+)
+
+func ConstructAuthenticateMiddleware(cfg *config.Config, db *sql.DB, publicKey *rsa.PublicKey) httptreemux.MiddlewareFunc {
+	timer := &helper.TimerImplementation{}
+	verifier := helper.NewAccessTokenVerifier(publicKey, timer)
+	return middleware.Authenticate(verifier, sessionConstructor.ConstructSessionUsecase(cfg, db), timer)
+}
@@ -0,0 +1,14 @@
+package constructor
+
+import (
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// RequirePermission is meant to be used on route groups registered behind the
+// authentication middleware, e.g. group.Use(RequirePermission("users:read")).
+func RequirePermission(permission string) httptreemux.MiddlewareFunc {
+	return middleware.RequirePermission(permission, &helper.TimerImplementation{})
+}
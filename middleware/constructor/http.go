@@ -0,0 +1,68 @@
+package constructor
+
+import (
+	"log/slog"
+	"os"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// ConstructStandardMiddleware returns the middleware every route gets. It is
+// meant to be the first Use on the router, so the request id and logger are
+// set before anything can fail and panics anywhere below are recovered. The
+// client IP is resolved once, behind the trusted proxies, for every later
+// reader. The access log and metrics sit outside the recovery so recovered
+// panics count as 500s.
+func ConstructStandardMiddleware(cfg *config.Config, logger *slog.Logger) httptreemux.MiddlewareFunc {
+	// Trusted proxies were validated when the configuration was loaded.
+	proxies, _ := helper.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+
+	timer := &helper.TimerImplementation{}
+	return middleware.Chain(
+		middleware.RequestID(),
+		middleware.ClientIP(proxies),
+		middleware.RequestLogger(logger),
+		middleware.Trace(),
+		constructAccessLogMiddleware(cfg, timer),
+		middleware.Metrics(metricsConstructor.ConstructMetrics(), timer),
+		middleware.Recover(timer),
+		middleware.SecurityHeaders(),
+		middleware.CORS(corsPolicies(cfg)),
+		middleware.LimitRequestBody(cfg.HTTP.MaxBodyBytes, timer),
+	)
+}
+
+func constructAccessLogMiddleware(cfg *config.Config, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return middleware.AccessLog(os.Stdout, middleware.AccessLogConfig{
+		Format:              middleware.AccessLogFormat(cfg.AccessLog.Format),
+		SampleRates:         cfg.AccessLog.SampleRates,
+		RedactedQueryParams: cfg.AccessLog.Redact,
+	}, timer)
+}
+
+// ConstructCORSPreflightHandler returns the handler answering the OPTIONS
+// requests of router, to be set as its OptionsHandler behind the standard
+// middleware.
+func ConstructCORSPreflightHandler(cfg *config.Config, router middleware.RouteLookup) httptreemux.HandlerFunc {
+	return middleware.CORSPreflight(corsPolicies(cfg), router, &helper.TimerImplementation{})
+}
+
+func corsPolicies(cfg *config.Config) middleware.CORSPolicies {
+	if len(cfg.CORS.AllowedOrigins) == 0 {
+		return nil
+	}
+
+	return middleware.CORSPolicies{{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}}
+}
@@ -0,0 +1,27 @@
+package constructor
+
+import (
+	"strings"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/redis/go-redis/v9"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperConstructor "littlerollingsushi.com/example/usecase/helper/constructor"
+)
+
+// ConstructRateLimitMiddleware limits requests to limit.Requests per
+// limit.Period per key. Buckets are kept in the configured store backend,
+// namespaced by name.
+func ConstructRateLimitMiddleware(cfg *config.Config, redisClient *redis.Client, name string, limit config.Limit, key middleware.RateLimitKeyFunc) httptreemux.MiddlewareFunc {
+	timer := &helper.TimerImplementation{}
+	var limiter middleware.RateLimiter = middleware.NewMemoryRateLimiter(limit.Requests, limit.Period, timer)
+	if cfg.Store.Backend == helperConstructor.StoreBackendRedis {
+		prefix := helperConstructor.RedisKeyPrefix + "rate_limit:" + strings.ToLower(name) + ":"
+		limiter = middleware.NewRedisRateLimiter(redisClient, prefix, limit.Requests, limit.Period, timer)
+	}
+
+	return middleware.RateLimit(limiter, key, timer)
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// CORSPolicy is what cross-origin requests from its origins may do.
+type CORSPolicy struct {
+	// AllowedOrigins are origins like https://app.example.com, patterns like
+	// https://*.example.com matching every subdomain but not example.com
+	// itself, or * for any origin.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// matches reports whether origin is one of the allowed origins. Origins are
+// compared case-insensitively, as browsers lower-case them anyway.
+func (p CORSPolicy) matches(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range p.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		scheme, domain, found := strings.Cut(allowed, "://*.")
+		if found && strings.HasPrefix(origin, scheme+"://") && strings.HasSuffix(origin, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CORSPolicies are tried in order, the first one matching the origin of a
+// request applies, so origins can be given different policies.
+type CORSPolicies []CORSPolicy
+
+func (p CORSPolicies) match(origin string) (CORSPolicy, bool) {
+	for _, policy := range p {
+		if policy.matches(origin) {
+			return policy, true
+		}
+	}
+
+	return CORSPolicy{}, false
+}
+
+// RouteLookup finds the handler of a request, as httptreemux.TreeMux does.
+type RouteLookup interface {
+	Lookup(w http.ResponseWriter, r *http.Request) (httptreemux.LookupResult, bool)
+}
+
+// corsMethods are the methods looked up to list those a route allows.
+var corsMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// CORS lets browsers read the responses to cross-origin requests from the
+// origins of policies. The headers are set before next runs, so error
+// responses of later middlewares carry them too. Requests from other origins
+// are served without them and the browser withholds the response. Preflight
+// requests are left to CORSPreflight.
+func CORS(policies CORSPolicies) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			if isPreflight(r) {
+				next(w, r, params)
+				return
+			}
+
+			if len(policies) > 0 {
+				w.Header().Add("Vary", "Origin")
+			}
+
+			origin := r.Header.Get("Origin")
+			if policy, ok := policies.match(origin); ok && origin != "" {
+				setAllowOrigin(w.Header(), policy, origin)
+				if len(policy.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(policy.ExposedHeaders, ", "))
+				}
+			}
+
+			next(w, r, params)
+		}
+	}
+}
+
+// CORSPreflight answers OPTIONS requests, meant to be the OptionsHandler of
+// the router so handlers never see them. Preflight requests are checked
+// against the policy of their origin and the methods routes has for the path.
+// Other OPTIONS requests get the allowed methods in an Allow header.
+func CORSPreflight(policies CORSPolicies, routes RouteLookup, timer helper.Timer) httptreemux.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		routed := routedMethods(routes, w, r)
+
+		if !isPreflight(r) {
+			w.Header().Set("Allow", strings.Join(append(routed, http.MethodOptions), ", "))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		origin := r.Header.Get("Origin")
+		requestedMethod := r.Header.Get("Access-Control-Request-Method")
+
+		w.Header().Add("Vary", "Origin, Access-Control-Request-Method, Access-Control-Request-Headers")
+
+		policy, ok := policies.match(origin)
+		if !ok {
+			problem.Write(w, timer, problem.CrossOriginRejected, "Origin "+origin+" is not allowed.")
+			return
+		}
+
+		methods := intersect(policy.AllowedMethods, routed)
+		if !containsFold(methods, requestedMethod) {
+			problem.Write(w, timer, problem.CrossOriginRejected, "Method "+requestedMethod+" is not allowed.")
+			return
+		}
+
+		for _, header := range splitHeaderList(r.Header.Get("Access-Control-Request-Headers")) {
+			if !containsFold(policy.AllowedHeaders, header) {
+				problem.Write(w, timer, problem.CrossOriginRejected, "Header "+header+" is not allowed.")
+				return
+			}
+		}
+
+		header := w.Header()
+		setAllowOrigin(header, policy, origin)
+		header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(policy.AllowedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+		}
+		if policy.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Origin") != "" && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// setAllowOrigin echoes origin rather than sending *, which browsers refuse
+// for requests with credentials.
+func setAllowOrigin(header http.Header, policy CORSPolicy, origin string) {
+	header.Set("Access-Control-Allow-Origin", origin)
+	if policy.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func routedMethods(routes RouteLookup, w http.ResponseWriter, r *http.Request) []string {
+	methods := []string{}
+	for _, method := range corsMethods {
+		lookup := r.Clone(r.Context())
+		lookup.Method = method
+		if result, found := routes.Lookup(w, lookup); found && result.StatusCode == http.StatusOK {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+func intersect(allowed, routed []string) []string {
+	methods := []string{}
+	for _, method := range routed {
+		if containsFold(allowed, method) {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitHeaderList(list string) []string {
+	headers := []string{}
+	for _, header := range strings.Split(list, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
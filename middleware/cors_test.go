@@ -0,0 +1,197 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type CORSSuite struct {
+	suite.Suite
+
+	router         *httptreemux.TreeMux
+	responseWriter *httptest.ResponseRecorder
+	timer          *helperMocks.Timer
+
+	expectedTimestamp time.Time
+}
+
+func TestCORSSuite(t *testing.T) {
+	suite.Run(t, &CORSSuite{})
+}
+
+func (s *CORSSuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+	s.timer = helperMocks.NewTimer(s.T())
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+
+	policies := middleware.CORSPolicies{
+		{
+			AllowedOrigins: []string{"https://partner.example.org"},
+			AllowedMethods: []string{"GET"},
+			AllowedHeaders: []string{"Authorization"},
+		},
+		{
+			AllowedOrigins:   []string{"https://app.example.com", "https://*.preview.example.com"},
+			AllowedMethods:   []string{"GET", "POST", "DELETE"},
+			AllowedHeaders:   []string{"Authorization", "Content-Type"},
+			ExposedHeaders:   []string{"X-Request-ID", "Retry-After"},
+			AllowCredentials: true,
+			MaxAge:           10 * time.Minute,
+		},
+	}
+
+	s.router = httptreemux.New()
+	s.router.Use(middleware.CORS(policies))
+	s.router.POST("/v1/login", s.handle)
+	s.router.GET("/v1/me/sessions", s.handle)
+	s.router.DELETE("/v1/me/sessions/:id", s.handle)
+	s.router.OptionsHandler = middleware.CORSPreflight(policies, s.router, s.timer)
+}
+
+func (s *CORSSuite) handle(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *CORSSuite) serve(method, path, origin string, headers map[string]string) *http.Response {
+	request := httptest.NewRequest(method, path, nil)
+	if origin != "" {
+		request.Header.Set("Origin", origin)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	s.router.ServeHTTP(s.responseWriter, request)
+	return s.responseWriter.Result()
+}
+
+func (s *CORSSuite) TestCORS_AllowedOrigin_SetHeaders() {
+	resp := s.serve("POST", "/v1/login", "https://app.example.com", nil)
+
+	a := s.Assert()
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+	a.Equal("https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	a.Equal("true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	a.Equal("X-Request-ID, Retry-After", resp.Header.Get("Access-Control-Expose-Headers"))
+	a.Equal("Origin", resp.Header.Get("Vary"))
+}
+
+func (s *CORSSuite) TestCORS_WildcardSubdomain_SetHeaders() {
+	resp := s.serve("POST", "/v1/login", "https://pr-42.preview.example.com", nil)
+
+	s.Assert().Equal("https://pr-42.preview.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func (s *CORSSuite) TestCORS_WildcardParentOrLookalike_ServeWithoutHeaders() {
+	for _, origin := range []string{"https://preview.example.com", "https://evilpreview.example.com", "http://pr-42.preview.example.com"} {
+		s.responseWriter = httptest.NewRecorder()
+
+		resp := s.serve("POST", "/v1/login", origin, nil)
+
+		a := s.Assert()
+		a.Equal(http.StatusNoContent, resp.StatusCode, origin)
+		a.Empty(resp.Header.Get("Access-Control-Allow-Origin"), origin)
+		a.Equal("Origin", resp.Header.Get("Vary"), origin)
+	}
+}
+
+func (s *CORSSuite) TestCORS_OriginWithOwnPolicy_ApplyFirstMatchingPolicy() {
+	resp := s.serve("GET", "/v1/me/sessions", "https://partner.example.org", nil)
+
+	a := s.Assert()
+	a.Equal("https://partner.example.org", resp.Header.Get("Access-Control-Allow-Origin"))
+	a.Empty(resp.Header.Get("Access-Control-Allow-Credentials"))
+	a.Empty(resp.Header.Get("Access-Control-Expose-Headers"))
+}
+
+func (s *CORSSuite) TestCORSPreflight_Allowed_ReturnNoContentWithPolicy() {
+	resp := s.serve("OPTIONS", "/v1/me/sessions/42", "https://app.example.com", map[string]string{
+		"Access-Control-Request-Method":  "DELETE",
+		"Access-Control-Request-Headers": "authorization",
+	})
+
+	a := s.Assert()
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+	a.Equal("https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	a.Equal("DELETE", resp.Header.Get("Access-Control-Allow-Methods"))
+	a.Equal("Authorization, Content-Type", resp.Header.Get("Access-Control-Allow-Headers"))
+	a.Equal("true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	a.Equal("600", resp.Header.Get("Access-Control-Max-Age"))
+	a.Equal("Origin, Access-Control-Request-Method, Access-Control-Request-Headers", resp.Header.Get("Vary"))
+}
+
+func (s *CORSSuite) TestCORSPreflight_OriginNotAllowed_ReturnCrossOriginRejected() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	resp := s.serve("OPTIONS", "/v1/login", "https://evil.example.net", map[string]string{
+		"Access-Control-Request-Method": "POST",
+	})
+
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+	a.JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/cross_origin_rejected",
+			"code": "cross_origin_rejected",
+			"title": "Cross-origin request not allowed",
+			"status": 403,
+			"detail": "Origin https://evil.example.net is not allowed.",
+			"meta": {
+				"http_status": 403,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *CORSSuite) TestCORSPreflight_MethodNotRoutedOrNotAllowed_ReturnCrossOriginRejected() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	for origin, method := range map[string]string{
+		"https://app.example.com":     "GET",
+		"https://partner.example.org": "POST",
+	} {
+		s.responseWriter = httptest.NewRecorder()
+
+		resp := s.serve("OPTIONS", "/v1/login", origin, map[string]string{
+			"Access-Control-Request-Method": method,
+		})
+
+		a := s.Assert()
+		a.Equal(http.StatusForbidden, resp.StatusCode, origin)
+		a.Empty(resp.Header.Get("Access-Control-Allow-Methods"), origin)
+	}
+}
+
+func (s *CORSSuite) TestCORSPreflight_HeaderNotAllowed_ReturnCrossOriginRejected() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	resp := s.serve("OPTIONS", "/v1/login", "https://app.example.com", map[string]string{
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "content-type, x-debug",
+	})
+
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.Contains(string(body), `"detail":"Header x-debug is not allowed."`)
+}
+
+func (s *CORSSuite) TestCORSPreflight_NotPreflight_ReturnAllowedMethods() {
+	resp := s.serve("OPTIONS", "/v1/me/sessions", "", nil)
+
+	a := s.Assert()
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+	a.Equal("GET, OPTIONS", resp.Header.Get("Allow"))
+	a.Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+}
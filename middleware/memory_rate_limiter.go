@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryRateLimiter is a token bucket RateLimiter kept in process memory. It
+// only limits the requests served by one instance.
+type MemoryRateLimiter struct {
+	burst     float64
+	perToken  time.Duration
+	timer     helper.Timer
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimiter allows bursts of up to requests requests and refills
+// the bucket at requests per period.
+func NewMemoryRateLimiter(requests int, period time.Duration, timer helper.Timer) *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		burst:    float64(requests),
+		perToken: period / time.Duration(requests),
+		timer:    timer,
+		buckets:  map[string]*bucket{},
+	}
+}
+
+func (l *MemoryRateLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	now := l.timer.NowInUTC()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, updatedAt: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.updatedAt = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) * float64(l.perToken)), nil
+	}
+
+	b.tokens--
+	return true, 0, nil
+}
+
+func (l *MemoryRateLimiter) refill(b *bucket, now time.Time) float64 {
+	tokens := b.tokens + float64(now.Sub(b.updatedAt))/float64(l.perToken)
+	if tokens > l.burst {
+		return l.burst
+	}
+
+	return tokens
+}
+
+// sweep drops the buckets that have refilled completely, they behave exactly
+// like missing ones. It runs at most once per full refill period.
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	period := time.Duration(l.burst) * l.perToken
+	if now.Sub(l.lastSweep) < period {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
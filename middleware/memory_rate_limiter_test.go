@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type MemoryRateLimiterSuite struct {
+	suite.Suite
+
+	context context.Context
+	timer   *helperMocks.Timer
+	limiter *middleware.MemoryRateLimiter
+	now     time.Time
+}
+
+func TestMemoryRateLimiterSuite(t *testing.T) {
+	suite.Run(t, &MemoryRateLimiterSuite{})
+}
+
+func (s *MemoryRateLimiterSuite) SetupTest() {
+	s.context = context.Background()
+	s.timer = helperMocks.NewTimer(s.T())
+	s.limiter = middleware.NewMemoryRateLimiter(2, time.Minute, s.timer)
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *MemoryRateLimiterSuite) allowAt(now time.Time, key string) (bool, time.Duration) {
+	s.timer.On("NowInUTC").Return(now).Once()
+	allowed, retryAfter, err := s.limiter.Allow(s.context, key)
+	s.Require().Nil(err)
+	return allowed, retryAfter
+}
+
+func (s *MemoryRateLimiterSuite) TestAllow_BurstExhausted_ReturnRetryAfter() {
+	a := s.Assert()
+	allowed, _ := s.allowAt(s.now, "key")
+	a.True(allowed)
+	allowed, _ = s.allowAt(s.now, "key")
+	a.True(allowed)
+
+	allowed, retryAfter := s.allowAt(s.now.Add(10*time.Second), "key")
+	a.False(allowed)
+	a.Equal(20*time.Second, retryAfter)
+}
+
+func (s *MemoryRateLimiterSuite) TestAllow_TokenRefilled_ReturnAllowed() {
+	s.allowAt(s.now, "key")
+	s.allowAt(s.now, "key")
+
+	allowed, _ := s.allowAt(s.now.Add(30*time.Second), "key")
+
+	s.Assert().True(allowed)
+}
+
+func (s *MemoryRateLimiterSuite) TestAllow_DifferentKeys_UseSeparateBuckets() {
+	s.allowAt(s.now, "key")
+	s.allowAt(s.now, "key")
+
+	allowed, _ := s.allowAt(s.now, "other")
+
+	s.Assert().True(allowed)
+}
+
+func (s *MemoryRateLimiterSuite) TestAllow_AfterFullRefill_StartWithFullBucket() {
+	s.allowAt(s.now, "key")
+	s.allowAt(s.now, "key")
+
+	a := s.Assert()
+	allowed, _ := s.allowAt(s.now.Add(2*time.Minute), "key")
+	a.True(allowed)
+	allowed, _ = s.allowAt(s.now.Add(2*time.Minute), "key")
+	a.True(allowed)
+	allowed, _ = s.allowAt(s.now.Add(2*time.Minute), "key")
+	a.False(allowed)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+//go:generate mockery --name=RequestObserver --output=./mocks
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// Metrics reports every request to observer by route pattern, keeping the
+// number of series bounded however many ids the routes serve.
+func Metrics(observer RequestObserver, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			start := timer.NowInUTC()
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next(recorder, r, params)
+
+			observer.ObserveRequest(r.Method, routePattern(r.URL.Path, params), recorder.status, timer.NowInUTC().Sub(start))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/middleware/mocks"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+
+	observer *mocks.RequestObserver
+	timer    *helperMocks.Timer
+
+	expectedTimestamp time.Time
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, &MetricsSuite{})
+}
+
+func (s *MetricsSuite) SetupTest() {
+	s.observer = mocks.NewRequestObserver(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *MetricsSuite) TestMetrics_ObserveRequestByRoutePattern() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp).Once()
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp.Add(250 * time.Millisecond)).Once()
+	s.observer.On("ObserveRequest", "DELETE", "/v1/me/sessions/:id", http.StatusNotFound, 250*time.Millisecond).Once()
+
+	router := httptreemux.New()
+	router.Use(middleware.Metrics(s.observer, s.timer))
+	router.DELETE("/v1/me/sessions/:id", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/v1/me/sessions/12", nil))
+}
+
+func (s *MetricsSuite) TestMetrics_EscapedSlashInParam_ObserveUnmatched() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+	s.observer.On("ObserveRequest", "DELETE", "unmatched", http.StatusNotFound, time.Duration(0)).Once()
+
+	router := httptreemux.New()
+	router.Use(middleware.Metrics(s.observer, s.timer))
+	router.DELETE("/v1/me/sessions/:id", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/v1/me/sessions/a%2Fb", nil))
+}
+
+func (s *MetricsSuite) TestMetrics_NoExplicitStatus_ObserveOK() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+	s.observer.On("ObserveRequest", "GET", "/v1/me", http.StatusOK, time.Duration(0)).Once()
+
+	middleware.Metrics(s.observer, s.timer)(func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.Write([]byte("{}"))
+	})(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/me", nil), nil)
+}
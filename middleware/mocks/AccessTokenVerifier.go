@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	helper "littlerollingsushi.com/example/usecase/helper"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessTokenVerifier is an autogenerated mock type for the AccessTokenVerifier type
+type AccessTokenVerifier struct {
+	mock.Mock
+}
+
+// VerifyAuthorizationHeader provides a mock function with given fields: header
+func (_m *AccessTokenVerifier) VerifyAuthorizationHeader(header string) (*helper.AccessTokenClaims, error) {
+	ret := _m.Called(header)
+
+	var r0 *helper.AccessTokenClaims
+	if rf, ok := ret.Get(0).(func(string) *helper.AccessTokenClaims); ok {
+		r0 = rf(header)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*helper.AccessTokenClaims)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(header)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAccessTokenVerifier interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessTokenVerifier creates a new instance of AccessTokenVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessTokenVerifier(t mockConstructorTestingTNewAccessTokenVerifier) *AccessTokenVerifier {
+	mock := &AccessTokenVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
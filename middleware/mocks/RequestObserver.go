@@ -0,0 +1,34 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RequestObserver is an autogenerated mock type for the RequestObserver type
+type RequestObserver struct {
+	mock.Mock
+}
+
+// ObserveRequest provides a mock function with given fields: method, route, status, duration
+func (_m *RequestObserver) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	_m.Called(method, route, status, duration)
+}
+
+type mockConstructorTestingTNewRequestObserver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRequestObserver creates a new instance of RequestObserver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRequestObserver(t mockConstructorTestingTNewRequestObserver) *RequestObserver {
+	mock := &RequestObserver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
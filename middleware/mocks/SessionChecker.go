@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionChecker is an autogenerated mock type for the SessionChecker type
+type SessionChecker struct {
+	mock.Mock
+}
+
+// IsSessionActive provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionChecker) IsSessionActive(ctx context.Context, userID int64, sessionID int64) (bool, error) {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewSessionChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionChecker creates a new instance of SessionChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionChecker(t mockConstructorTestingTNewSessionChecker) *SessionChecker {
+	mock := &SessionChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// RateLimiter is the store behind RateLimit. Allow takes one token from the
+// bucket of key and, when the bucket is empty, returns how long to wait until
+// a token is available again.
+//
+//go:generate mockery --name=RateLimiter --output=./mocks
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (bool, time.Duration, error)
+}
+
+// RateLimitKeyFunc returns the bucket a request is counted in. Requests for
+// which it returns an empty key are not limited.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// KeyByClientIP counts requests per client IP address.
+func KeyByClientIP(prefix string) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		return prefix + ":ip:" + helper.ClientIP(r)
+	}
+}
+
+// KeyByBodyField counts requests per value of a JSON or form body field,
+// case-insensitively, e.g. per submitted email address. The body is left for
+// the handler to decode.
+func KeyByBodyField(prefix, field string) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		value := strings.ToLower(strings.TrimSpace(helper.PeekRequestBodyField(r, helper.MaxRequestBodyBytes, field)))
+		if value == "" {
+			return ""
+		}
+
+		return prefix + ":" + field + ":" + value
+	}
+}
+
+// RateLimit rejects requests with 429 Too Many Requests once the bucket their
+// key maps to is empty. A failing store lets requests through, so an outage of
+// a shared store does not take the routes down with it.
+func RateLimit(limiter RateLimiter, key RateLimitKeyFunc, timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			k := key(r)
+			if k == "" {
+				next(w, r, params)
+				return
+			}
+
+			allowed, retryAfter, err := limiter.Allow(r.Context(), k)
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("rate limit store failed, letting the request through", "error", err, "key", k)
+				next(w, r, params)
+				return
+			}
+
+			if !allowed {
+				writeTooManyRequestsResponse(w, retryAfter, timer)
+				return
+			}
+
+			next(w, r, params)
+		}
+	}
+}
+
+func writeTooManyRequestsResponse(w http.ResponseWriter, retryAfter time.Duration, timer helper.Timer) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	problem.Write(w, timer, problem.TooManyRequests, "Too many requests. Try again later.")
+}
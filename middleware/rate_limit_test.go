@@ -0,0 +1,137 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/middleware/mocks"
+	"littlerollingsushi.com/example/problem"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type RateLimitSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	limiter *mocks.RateLimiter
+	timer   *helperMocks.Timer
+
+	nextCalled        bool
+	expectedTimestamp time.Time
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, &RateLimitSuite{})
+}
+
+func (s *RateLimitSuite) SetupTest() {
+	form := url.Values{}
+	form.Add("email", " John.Doe@email.com ")
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(form.Encode()))
+	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request.RemoteAddr = "192.0.2.1:54321"
+	s.requestParams = map[string]string{}
+	s.responseWriter = httptest.NewRecorder()
+
+	s.limiter = mocks.NewRateLimiter(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.nextCalled = false
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *RateLimitSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *RateLimitSuite) next(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	s.nextCalled = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *RateLimitSuite) TestKeyByClientIP_ReturnPrefixedIP() {
+	s.Assert().Equal("login:ip:192.0.2.1", middleware.KeyByClientIP("login")(s.request))
+}
+
+func (s *RateLimitSuite) TestKeyByBodyField_ReturnNormalizedValue() {
+	s.Assert().Equal("login:email:john.doe@email.com", middleware.KeyByBodyField("login", "email")(s.request))
+}
+
+func (s *RateLimitSuite) TestKeyByBodyField_JSONBody_ReturnNormalizedValueAndKeepBody() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(`{"email":" John.Doe@email.com "}`))
+	s.request.Header.Set("Content-Type", "application/json")
+
+	key := middleware.KeyByBodyField("login", "email")(s.request)
+
+	body, _ := io.ReadAll(s.request.Body)
+	a := s.Assert()
+	a.Equal("login:email:john.doe@email.com", key)
+	a.JSONEq(`{"email":" John.Doe@email.com "}`, string(body))
+}
+
+func (s *RateLimitSuite) TestKeyByBodyField_MissingField_ReturnEmptyKey() {
+	s.Assert().Empty(middleware.KeyByBodyField("login", "username")(s.request))
+}
+
+func (s *RateLimitSuite) TestRateLimit_EmptyKey_CallNextWithoutLimiting() {
+	middleware.RateLimit(s.limiter, middleware.KeyByBodyField("login", "username"), s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().True(s.nextCalled)
+}
+
+func (s *RateLimitSuite) TestRateLimit_LimiterError_CallNext() {
+	s.limiter.On("Allow", s.request.Context(), "login:ip:192.0.2.1").Return(false, time.Duration(0), errors.New("mock error"))
+
+	middleware.RateLimit(s.limiter, middleware.KeyByClientIP("login"), s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().True(s.nextCalled)
+}
+
+func (s *RateLimitSuite) TestRateLimit_Allowed_CallNext() {
+	s.limiter.On("Allow", s.request.Context(), "login:ip:192.0.2.1").Return(true, time.Duration(0), nil)
+
+	middleware.RateLimit(s.limiter, middleware.KeyByClientIP("login"), s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	a := s.Assert()
+	a.True(s.nextCalled)
+	a.Equal(http.StatusNoContent, s.responseWriter.Result().StatusCode)
+}
+
+func (s *RateLimitSuite) TestRateLimit_Limited_ReturnTooManyRequests() {
+	s.limiter.On("Allow", s.request.Context(), "login:ip:192.0.2.1").Return(false, 1500*time.Millisecond, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.RateLimit(s.limiter, middleware.KeyByClientIP("login"), s.timer)(s.next)(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.False(s.nextCalled)
+	a.Equal(http.StatusTooManyRequests, resp.StatusCode)
+	a.Equal("2", resp.Header.Get("Retry-After"))
+	a.JSONEq(s.expectedProblemBody(problem.TooManyRequests, "Too many requests. Try again later."), string(body))
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// Recover turns a panicking handler into a 500 problem response instead of a
+// dropped connection. http.ErrAbortHandler is re-raised, as net/http expects.
+func Recover(timer helper.Timer) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				problem.WriteInternalError(w, r, timer, fmt.Errorf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, rec, debug.Stack()))
+			}()
+
+			next(w, r, params)
+		}
+	}
+}
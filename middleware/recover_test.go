@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/problem"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type RecoverSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	responseWriter *httptest.ResponseRecorder
+
+	timer *helperMocks.Timer
+
+	expectedTimestamp time.Time
+}
+
+func TestRecoverSuite(t *testing.T) {
+	suite.Run(t, &RecoverSuite{})
+}
+
+func (s *RecoverSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.responseWriter = httptest.NewRecorder()
+
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *RecoverSuite) TestRecover_Panic_ReturnInternalError() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	middleware.Recover(s.timer)(func(http.ResponseWriter, *http.Request, map[string]string) {
+		panic("boom")
+	})(s.responseWriter, s.request, nil)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.Equal(problem.ContentType, resp.Header.Get("Content-Type"))
+	a.JSONEq(`
+		{
+			"type": "`+problem.InternalError.TypeURI()+`",
+			"title": "`+problem.InternalError.Title+`",
+			"status": 500,
+			"code": "`+problem.InternalError.Code+`",
+			"detail": "Oops! Something went wrong.",
+			"meta": {
+				"http_status": 500,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *RecoverSuite) TestRecover_NoPanic_PassResponseThrough() {
+	middleware.Recover(s.timer)(func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusNoContent)
+	})(s.responseWriter, s.request, nil)
+
+	s.Assert().Equal(http.StatusNoContent, s.responseWriter.Result().StatusCode)
+}
+
+func (s *RecoverSuite) TestRecover_AbortHandler_Repanic() {
+	s.Assert().PanicsWithValue(http.ErrAbortHandler, func() {
+		middleware.Recover(s.timer)(func(http.ResponseWriter, *http.Request, map[string]string) {
+			panic(http.ErrAbortHandler)
+		})(s.responseWriter, s.request, nil)
+	})
+}
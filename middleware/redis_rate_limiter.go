@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// tokenBucketScript is the token bucket of MemoryRateLimiter run atomically on
+// the Redis server. The bucket expires once it would be full again.
+var tokenBucketScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local per_token = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1])
+local updated_at = tonumber(state[2])
+if tokens == nil or updated_at == nil then
+	tokens = burst
+	updated_at = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - updated_at) / per_token)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.floor((1 - tokens) * per_token + 0.5)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * per_token))
+return {allowed, retry_after}
+`)
+
+// RedisRateLimiter is the token bucket RateLimiter shared by every instance
+// through Redis.
+type RedisRateLimiter struct {
+	client   redis.UniversalClient
+	prefix   string
+	burst    int
+	perToken time.Duration
+	timer    helper.Timer
+}
+
+func NewRedisRateLimiter(client redis.UniversalClient, prefix string, requests int, period time.Duration, timer helper.Timer) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client:   client,
+		prefix:   prefix,
+		burst:    requests,
+		perToken: period / time.Duration(requests),
+		timer:    timer,
+	}
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	now := l.timer.NowInUTC().UnixMilli()
+	perToken := float64(l.perToken) / float64(time.Millisecond)
+
+	result, err := tokenBucketScript.Run(
+		ctx,
+		l.client,
+		[]string{l.prefix + key},
+		l.burst,
+		strconv.FormatFloat(perToken, 'f', -1, 64),
+		now,
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type RedisRateLimiterSuite struct {
+	suite.Suite
+
+	server  *miniredis.Miniredis
+	client  *redis.Client
+	timer   *helperMocks.Timer
+	limiter *middleware.RedisRateLimiter
+
+	context context.Context
+	now     time.Time
+}
+
+func TestRedisRateLimiterSuite(t *testing.T) {
+	suite.Run(t, &RedisRateLimiterSuite{})
+}
+
+func (s *RedisRateLimiterSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.client = redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	s.timer = helperMocks.NewTimer(s.T())
+	s.limiter = middleware.NewRedisRateLimiter(s.client, "example:rate_limit:test:", 2, time.Minute, s.timer)
+
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *RedisRateLimiterSuite) TearDownTest() {
+	s.client.Close()
+}
+
+func (s *RedisRateLimiterSuite) allowAt(now time.Time, key string) (bool, time.Duration) {
+	s.timer.On("NowInUTC").Return(now).Once()
+	allowed, retryAfter, err := s.limiter.Allow(s.context, key)
+	s.Require().Nil(err)
+	return allowed, retryAfter
+}
+
+func (s *RedisRateLimiterSuite) TestAllow_BurstExhausted_ReturnRetryAfter() {
+	a := s.Assert()
+	allowed, _ := s.allowAt(s.now, "key")
+	a.True(allowed)
+	allowed, _ = s.allowAt(s.now, "key")
+	a.True(allowed)
+
+	allowed, retryAfter := s.allowAt(s.now.Add(10*time.Second), "key")
+	a.False(allowed)
+	a.Equal(20*time.Second, retryAfter)
+}
+
+func (s *RedisRateLimiterSuite) TestAllow_TokenRefilled_ReturnAllowed() {
+	s.allowAt(s.now, "key")
+	s.allowAt(s.now, "key")
+
+	allowed, _ := s.allowAt(s.now.Add(30*time.Second), "key")
+
+	s.Assert().True(allowed)
+}
+
+func (s *RedisRateLimiterSuite) TestAllow_DifferentKeys_UseSeparateBuckets() {
+	s.allowAt(s.now, "key")
+	s.allowAt(s.now, "key")
+
+	allowed, _ := s.allowAt(s.now, "other")
+
+	a := s.Assert()
+	a.True(allowed)
+	a.True(s.server.Exists("example:rate_limit:test:other"))
+}
+
+func (s *RedisRateLimiterSuite) TestAllow_Bucket_ExpireWhenFull() {
+	s.allowAt(s.now, "key")
+
+	s.Assert().Equal(time.Minute, s.server.TTL("example:rate_limit:test:key"))
+}
+
+func (s *RedisRateLimiterSuite) TestAllow_ServerDown_ReturnError() {
+	s.server.Close()
+	s.timer.On("NowInUTC").Return(s.now)
+
+	_, _, err := s.limiter.Allow(s.context, "key")
+
+	s.Assert().NotNil(err)
+}
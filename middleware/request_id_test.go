@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type RequestIDSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	responseWriter *httptest.ResponseRecorder
+
+	contextRequestID string
+}
+
+func TestRequestIDSuite(t *testing.T) {
+	suite.Run(t, &RequestIDSuite{})
+}
+
+func (s *RequestIDSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.responseWriter = httptest.NewRecorder()
+
+	s.contextRequestID = ""
+}
+
+func (s *RequestIDSuite) next(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.contextRequestID = helper.RequestIDFromContext(r.Context())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *RequestIDSuite) TestRequestID_ValidIncomingID_Propagate() {
+	s.request.Header.Set(middleware.RequestIDHeader, "abc-123_DEF.4")
+
+	middleware.RequestID()(s.next)(s.responseWriter, s.request, nil)
+
+	a := s.Assert()
+	a.Equal("abc-123_DEF.4", s.contextRequestID)
+	a.Equal("abc-123_DEF.4", s.responseWriter.Result().Header.Get(middleware.RequestIDHeader))
+}
+
+func (s *RequestIDSuite) TestRequestID_NoIncomingID_Generate() {
+	middleware.RequestID()(s.next)(s.responseWriter, s.request, nil)
+
+	a := s.Assert()
+	a.Len(s.contextRequestID, 32)
+	a.Equal(s.contextRequestID, s.responseWriter.Result().Header.Get(middleware.RequestIDHeader))
+}
+
+func (s *RequestIDSuite) TestRequestID_InvalidIncomingID_Replace() {
+	for _, requestID := range []string{"has space", "new\nline", strings.Repeat("a", 129)} {
+		s.SetupTest()
+		s.request.Header.Set(middleware.RequestIDHeader, requestID)
+
+		middleware.RequestID()(s.next)(s.responseWriter, s.request, nil)
+
+		a := s.Assert()
+		a.Len(s.contextRequestID, 32, requestID)
+		a.Equal(s.contextRequestID, s.responseWriter.Result().Header.Get(middleware.RequestIDHeader))
+	}
+}
+
+func (s *RequestIDSuite) TestRequestIDFromContext_NoRequestID_ReturnEmpty() {
+	s.Assert().Empty(helper.RequestIDFromContext(s.request.Context()))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// RequestLogger puts logger in the request context with the request id and
+// route as fields, for logging.FromContext. It must run after RequestID.
+func RequestLogger(logger *slog.Logger) httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			requestLogger := logger.With(
+				"request_id", helper.RequestIDFromContext(r.Context()),
+				"route", routePattern(r.URL.Path, params),
+			)
+
+			next(w, r.WithContext(logging.ContextWithLogger(r.Context(), requestLogger)), params)
+		}
+	}
+}
+
+// unmatchedRoute stands for the route of a request whose path cannot be turned
+// back into its pattern. The raw path is never used instead, clients would
+// choose the values of every label and field keyed by route.
+const unmatchedRoute = "unmatched"
+
+// routePattern turns the path of a request back into the pattern of its route,
+// such as /v1/admin/users/:id, so records of one route can be grouped. TreeMux
+// does not tell middlewares the route, so the path segments holding a
+// parameter value are swapped for the parameter name. TreeMux unescapes
+// parameter values, so a value holding an escaped slash matches no segment and
+// the route is unmatchedRoute; catch-all parameters are not supported.
+func routePattern(path string, params map[string]string) string {
+	if len(params) == 0 {
+		return path
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	segments := strings.Split(path, "/")
+	replaced := make([]bool, len(segments))
+	for _, name := range names {
+		found := false
+		for i := len(segments) - 1; i >= 0; i-- {
+			if !replaced[i] && segments[i] == params[name] {
+				segments[i] = ":" + name
+				replaced[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return unmatchedRoute
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/middleware"
+)
+
+type RequestLoggerSuite struct {
+	suite.Suite
+
+	output *bytes.Buffer
+	router *httptreemux.TreeMux
+}
+
+func TestRequestLoggerSuite(t *testing.T) {
+	suite.Run(t, &RequestLoggerSuite{})
+}
+
+func (s *RequestLoggerSuite) SetupTest() {
+	s.output = &bytes.Buffer{}
+	s.router = httptreemux.New()
+	s.router.Use(middleware.RequestID())
+	s.router.Use(middleware.RequestLogger(logging.New(s.output, slog.LevelInfo)))
+}
+
+func (s *RequestLoggerSuite) handler(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	logging.FromContext(r.Context()).Info("handled")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *RequestLoggerSuite) serve(path string) map[string]interface{} {
+	request := httptest.NewRequest("GET", path, nil)
+	request.Header.Set(middleware.RequestIDHeader, "abc")
+	s.router.ServeHTTP(httptest.NewRecorder(), request)
+
+	record := map[string]interface{}{}
+	s.Require().NoError(json.Unmarshal(s.output.Bytes(), &record))
+	return record
+}
+
+func (s *RequestLoggerSuite) TestRequestLogger_StaticRoute_LogRequestFields() {
+	s.router.GET("/v1/me/sessions", s.handler)
+
+	record := s.serve("/v1/me/sessions")
+
+	a := s.Assert()
+	a.Equal("abc", record["request_id"])
+	a.Equal("/v1/me/sessions", record["route"])
+}
+
+func (s *RequestLoggerSuite) TestRequestLogger_ParamRoute_LogRoutePattern() {
+	s.router.POST("/v1/admin/users/:id/roles", s.handler)
+	s.router.GET("/v1/admin/users/:id/roles", s.handler)
+
+	record := s.serve("/v1/admin/users/42/roles")
+
+	s.Assert().Equal("/v1/admin/users/:id/roles", record["route"])
+}
+
+func (s *RequestLoggerSuite) TestRequestLogger_ParamValueMatchesStaticSegment_ReplaceLastMatch() {
+	s.router.GET("/v1/users/:name", s.handler)
+
+	record := s.serve("/v1/users/users")
+
+	s.Assert().Equal("/v1/users/:name", record["route"])
+}
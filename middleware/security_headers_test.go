@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/middleware"
+)
+
+type SecurityHeadersSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	responseWriter *httptest.ResponseRecorder
+}
+
+func TestSecurityHeadersSuite(t *testing.T) {
+	suite.Run(t, &SecurityHeadersSuite{})
+}
+
+func (s *SecurityHeadersSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.responseWriter = httptest.NewRecorder()
+}
+
+func (s *SecurityHeadersSuite) next(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *SecurityHeadersSuite) TestSecurityHeaders_PlainHTTP_SetHeadersWithoutHSTS() {
+	middleware.SecurityHeaders()(s.next)(s.responseWriter, s.request, nil)
+
+	header := s.responseWriter.Result().Header
+	a := s.Assert()
+	a.Equal("nosniff", header.Get("X-Content-Type-Options"))
+	a.Equal("DENY", header.Get("X-Frame-Options"))
+	a.Equal("default-src 'none'; frame-ancestors 'none'", header.Get("Content-Security-Policy"))
+	a.Equal("no-referrer", header.Get("Referrer-Policy"))
+	a.Equal("no-store", header.Get("Cache-Control"))
+	a.Empty(header.Get("Strict-Transport-Security"))
+}
+
+func (s *SecurityHeadersSuite) TestSecurityHeaders_TLS_SetHSTS() {
+	s.request.TLS = &tls.ConnectionState{}
+
+	middleware.SecurityHeaders()(s.next)(s.responseWriter, s.request, nil)
+
+	s.Assert().Equal("max-age=63072000; includeSubDomains", s.responseWriter.Result().Header.Get("Strict-Transport-Security"))
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/tracing"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// Trace starts the server span of a request, continuing the trace of the
+// caller when it sent a W3C traceparent header, and adds the trace id to the
+// request logger. It must run after RequestLogger.
+func Trace() httptreemux.MiddlewareFunc {
+	return func(next httptreemux.HandlerFunc) httptreemux.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			route := routePattern(r.URL.Path, params)
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracing.Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", route),
+					attribute.String("http.request.id", helper.RequestIDFromContext(r.Context())),
+				),
+			)
+			defer span.End()
+
+			if spanContext := span.SpanContext(); spanContext.IsValid() {
+				ctx = logging.With(ctx, "trace_id", spanContext.TraceID().String())
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next(recorder, r.WithContext(ctx), params)
+
+			span.SetAttributes(attribute.Int("http.response.status_code", recorder.status))
+			if recorder.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(recorder.status))
+			}
+		}
+	}
+}
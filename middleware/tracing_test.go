@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httptreemux "github.com/dimfeld/httptreemux/v5"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/middleware"
+)
+
+type TraceSuite struct {
+	suite.Suite
+
+	recorder *tracetest.SpanRecorder
+	output   *bytes.Buffer
+	router   *httptreemux.TreeMux
+	status   int
+}
+
+func TestTraceSuite(t *testing.T) {
+	suite.Run(t, &TraceSuite{})
+}
+
+func (s *TraceSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	s.output = &bytes.Buffer{}
+	s.status = http.StatusOK
+	s.router = httptreemux.New()
+	s.router.Use(middleware.RequestID())
+	s.router.Use(middleware.RequestLogger(logging.New(s.output, slog.LevelInfo)))
+	s.router.Use(middleware.Trace())
+	s.router.GET("/v1/admin/users/:id", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		logging.FromContext(r.Context()).Info("handled")
+		w.WriteHeader(s.status)
+	})
+}
+
+func (s *TraceSuite) TearDownTest() {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+}
+
+func (s *TraceSuite) TestTrace_TraceParent_ContinueTrace() {
+	request := httptest.NewRequest("GET", "/v1/admin/users/42", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	s.router.ServeHTTP(httptest.NewRecorder(), request)
+
+	spans := s.recorder.Ended()
+	record := map[string]interface{}{}
+	a := s.Assert()
+	a.Len(spans, 1)
+	a.Equal("GET /v1/admin/users/:id", spans[0].Name())
+	a.Equal(trace.SpanKindServer, spans[0].SpanKind())
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	a.Equal("00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	a.Contains(spans[0].Attributes(), attribute.String("http.route", "/v1/admin/users/:id"))
+	a.Contains(spans[0].Attributes(), attribute.Int("http.response.status_code", 200))
+	a.NoError(json.Unmarshal(s.output.Bytes(), &record))
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+}
+
+func (s *TraceSuite) TestTrace_NoTraceParent_StartTrace() {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/admin/users/42", nil))
+
+	spans := s.recorder.Ended()
+	a := s.Assert()
+	a.Len(spans, 1)
+	a.True(spans[0].SpanContext().IsValid())
+	a.False(spans[0].Parent().IsValid())
+}
+
+func (s *TraceSuite) TestTrace_ServerError_MarkFailed() {
+	s.status = http.StatusInternalServerError
+
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/admin/users/42", nil))
+
+	s.Assert().Equal(codes.Error, s.recorder.Ended()[0].Status().Code)
+}
+
+func (s *TraceSuite) TestTrace_EscapedSlashInParam_NameUnmatched() {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/admin/users/a%2Fb", nil))
+
+	spans := s.recorder.Ended()
+	a := s.Assert()
+	a.Len(spans, 1)
+	a.Equal("GET unmatched", spans[0].Name())
+	a.Contains(spans[0].Attributes(), attribute.String("http.route", "unmatched"))
+}
@@ -0,0 +1,34 @@
+package problem
+
+import "net/http"
+
+// Generic problems, shared by every route.
+var (
+	InternalError        = Code{Code: "internal_error", Status: http.StatusInternalServerError, Title: "Internal server error"}
+	Unauthorized         = Code{Code: "unauthorized", Status: http.StatusUnauthorized, Title: "Missing or invalid access token"}
+	InvalidCredentials   = Code{Code: "invalid_credentials", Status: http.StatusUnauthorized, Title: "Invalid credentials"}
+	Forbidden            = Code{Code: "forbidden", Status: http.StatusForbidden, Title: "Permission denied"}
+	TooManyRequests      = Code{Code: "too_many_requests", Status: http.StatusTooManyRequests, Title: "Too many requests"}
+	UnsupportedMediaType = Code{Code: "unsupported_media_type", Status: http.StatusUnsupportedMediaType, Title: "Unsupported media type"}
+	RequestBodyTooLarge  = Code{Code: "request_body_too_large", Status: http.StatusRequestEntityTooLarge, Title: "Request body too large"}
+	MalformedRequestBody = Code{Code: "malformed_request_body", Status: http.StatusBadRequest, Title: "Malformed request body"}
+	InvalidParameter     = Code{Code: "invalid_parameter", Status: http.StatusBadRequest, Title: "Invalid parameter"}
+	InvalidCursor        = Code{Code: "invalid_cursor", Status: http.StatusBadRequest, Title: "Invalid cursor"}
+	RequiredFieldMissing = Code{Code: "required_field_missing", Status: http.StatusUnprocessableEntity, Title: "Required field missing"}
+	CrossOriginRejected  = Code{Code: "cross_origin_rejected", Status: http.StatusForbidden, Title: "Cross-origin request not allowed"}
+)
+
+// Password reset.
+var (
+	InvalidResetLink = Code{Code: "invalid_reset_link", Status: http.StatusUnprocessableEntity, Title: "Invalid reset link"}
+)
+
+// Sessions, audit log and user administration.
+var (
+	SessionNotFound   = Code{Code: "session_not_found", Status: http.StatusNotFound, Title: "Session not found"}
+	InvalidTimeRange  = Code{Code: "invalid_time_range", Status: http.StatusBadRequest, Title: "Invalid time range"}
+	UserNotFound      = Code{Code: "user_not_found", Status: http.StatusNotFound, Title: "User not found"}
+	RoleNotFound      = Code{Code: "role_not_found", Status: http.StatusUnprocessableEntity, Title: "Role not found"}
+	InvalidSuspension = Code{Code: "invalid_suspension", Status: http.StatusUnprocessableEntity, Title: "Invalid suspension"}
+	SelfModification  = Code{Code: "self_modification", Status: http.StatusUnprocessableEntity, Title: "Action not allowed on own account"}
+)
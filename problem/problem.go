@@ -0,0 +1,153 @@
+// Package problem renders error responses as RFC 7807 problem details.
+//
+// Every error a handler can return maps to a code, either one declared in
+// codes.go or the code of a helper.DomainError. The code and the type URI
+// derived from it are part of the API, clients may switch on them, so existing
+// codes must not be renamed.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const (
+	ContentType = "application/problem+json"
+	TypeBaseURI = "https://littlerollingsushi.com/problems/"
+
+	timeFormat = "2006-01-02T15:04:05.999Z"
+)
+
+// kinds is the one place a kind of domain error is given its HTTP status.
+// Internal errors are left out on purpose, their message is never shown.
+var kinds = map[helper.ErrorKind]struct {
+	Status int
+	Title  string
+}{
+	helper.ErrorKindInvalid:         {Status: http.StatusBadRequest, Title: "Invalid request"},
+	helper.ErrorKindUnauthenticated: {Status: http.StatusUnauthorized, Title: "Authentication failed"},
+	helper.ErrorKindForbidden:       {Status: http.StatusForbidden, Title: "Forbidden"},
+	helper.ErrorKindConflict:        {Status: http.StatusConflict, Title: "Conflict"},
+	helper.ErrorKindUnprocessable:   {Status: http.StatusUnprocessableEntity, Title: "Unprocessable request"},
+}
+
+// Code identifies a kind of problem and the HTTP status it is answered with.
+type Code struct {
+	Code   string
+	Status int
+	Title  string
+}
+
+// TypeURI returns the "type" member of problems with this code.
+func (c Code) TypeURI() string {
+	return TypeBaseURI + c.Code
+}
+
+// Problem is a problem details object. Extensions are added as top-level
+// members next to the standard ones.
+type Problem struct {
+	Code       Code
+	Detail     string
+	Extensions map[string]interface{}
+}
+
+func New(code Code, detail string) Problem {
+	return Problem{Code: code, Detail: detail}
+}
+
+// With returns a copy of the problem with an extension member added.
+func (p Problem) With(key string, value interface{}) Problem {
+	extensions := map[string]interface{}{}
+	for k, v := range p.Extensions {
+		extensions[k] = v
+	}
+	extensions[key] = value
+	p.Extensions = extensions
+
+	return p
+}
+
+// Write answers with a problem of the given code.
+func Write(w http.ResponseWriter, timer helper.Timer, code Code, detail string) {
+	WriteProblem(w, timer, New(code, detail))
+}
+
+// WriteInternalError logs err with the logger of r and answers with a generic
+// internal error, so nothing about the failure leaks to the client.
+func WriteInternalError(w http.ResponseWriter, r *http.Request, timer helper.Timer, err error) {
+	logging.FromContext(r.Context()).Error("internal error", "error", err)
+	Write(w, timer, InternalError, "Oops! Something went wrong.")
+}
+
+// WriteProblem answers with p. The meta member carried by every response of
+// the API is kept as an extension.
+func WriteProblem(w http.ResponseWriter, timer helper.Timer, p Problem) {
+	data := map[string]interface{}{}
+	for k, v := range p.Extensions {
+		data[k] = v
+	}
+
+	data["type"] = p.Code.TypeURI()
+	data["title"] = p.Code.Title
+	data["status"] = p.Code.Status
+	data["code"] = p.Code.Code
+	if p.Detail != "" {
+		data["detail"] = p.Detail
+	}
+	data["meta"] = map[string]interface{}{
+		"http_status": p.Code.Status,
+		"server_time": timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Code.Status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// WriteError answers with the problem err maps to: the kind and code of a
+// domain error, or the code of a request body error. Anything else, including
+// domain errors of the internal kind, is answered as an internal error.
+func WriteError(w http.ResponseWriter, r *http.Request, timer helper.Timer, err error) {
+	if p, ok := FromError(err); ok {
+		WriteProblem(w, timer, p)
+		return
+	}
+
+	WriteInternalError(w, r, timer, err)
+}
+
+// FromError returns the problem for a domain error or an error of
+// helper.DecodeRequestBody, or false for any other error.
+func FromError(err error) (Problem, bool) {
+	var domainErr *helper.DomainError
+	if errors.As(err, &domainErr) {
+		kind, ok := kinds[domainErr.Kind]
+		if !ok {
+			return Problem{}, false
+		}
+
+		return New(Code{Code: domainErr.Code, Status: kind.Status, Title: kind.Title}, domainErr.Message), true
+	}
+
+	return FromRequestBodyError(err)
+}
+
+// FromRequestBodyError returns the problem for an error of
+// helper.DecodeRequestBody, or false for errors it did not produce.
+func FromRequestBodyError(err error) (Problem, bool) {
+	var malformedErr *helper.MalformedRequestBodyError
+	switch {
+	case errors.Is(err, helper.ErrUnsupportedMediaType):
+		return New(UnsupportedMediaType, "Unsupported content type. Send application/json or a form."), true
+	case errors.Is(err, helper.ErrRequestBodyTooLarge):
+		return New(RequestBodyTooLarge, "Request body is too large."), true
+	case errors.As(err, &malformedErr):
+		return New(MalformedRequestBody, "Malformed request body.").With("details", malformedErr.Details), true
+	default:
+		return Problem{}, false
+	}
+}
@@ -0,0 +1,233 @@
+package problem_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type ProblemSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	responseWriter *httptest.ResponseRecorder
+	timer          *helperMocks.Timer
+	logOutput      *bytes.Buffer
+
+	expectedTimestamp time.Time
+}
+
+func TestProblemSuite(t *testing.T) {
+	suite.Run(t, &ProblemSuite{})
+}
+
+func (s *ProblemSuite) SetupTest() {
+	s.logOutput = &bytes.Buffer{}
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.request = s.request.WithContext(logging.ContextWithLogger(s.request.Context(), logging.New(s.logOutput, slog.LevelInfo).With("request_id", "abc")))
+	s.responseWriter = httptest.NewRecorder()
+	s.timer = helperMocks.NewTimer(s.T())
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+}
+
+func (s *ProblemSuite) TestWrite_ReturnProblemDetails() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	problem.Write(s.responseWriter, s.timer, problem.InvalidResetLink, "Reset link is invalid or expired.")
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+	a.JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/invalid_reset_link",
+			"title": "Invalid reset link",
+			"status": 422,
+			"code": "invalid_reset_link",
+			"detail": "Reset link is invalid or expired.",
+			"meta": {
+				"http_status": 422,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *ProblemSuite) TestWriteProblem_WithExtension_AddTopLevelMember() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	problem.WriteProblem(s.responseWriter, s.timer, problem.New(problem.InvalidParameter, "").With("parameter", "limit"))
+
+	body, _ := io.ReadAll(s.responseWriter.Result().Body)
+	s.Assert().JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/invalid_parameter",
+			"title": "Invalid parameter",
+			"status": 400,
+			"code": "invalid_parameter",
+			"parameter": "limit",
+			"meta": {
+				"http_status": 400,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *ProblemSuite) TestWriteInternalError_HideOriginalError() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	problem.WriteInternalError(s.responseWriter, s.request, s.timer, errors.New("dial tcp 127.0.0.1:3306: connection refused"))
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.NotContains(string(body), "3306")
+	a.Contains(string(body), `"code":"internal_error"`)
+}
+
+func (s *ProblemSuite) TestWriteInternalError_LogWithRequestLogger() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	problem.WriteInternalError(s.responseWriter, s.request, s.timer, errors.New("dial tcp 127.0.0.1:3306: connection refused"))
+
+	record := map[string]interface{}{}
+	a := s.Assert()
+	a.NoError(json.Unmarshal(s.logOutput.Bytes(), &record))
+	a.Equal("ERROR", record["level"])
+	a.Equal("abc", record["request_id"])
+	a.Equal("dial tcp 127.0.0.1:3306: connection refused", record["error"])
+}
+
+func (s *ProblemSuite) TestWith_DoNotModifyOriginal() {
+	original := problem.New(problem.InvalidParameter, "").With("parameter", "limit")
+
+	original.With("parameter", "cursor")
+
+	s.Assert().Equal("limit", original.Extensions["parameter"])
+}
+
+func (s *ProblemSuite) TestFromRequestBodyError_MalformedBody_AddDetails() {
+	p, ok := problem.FromRequestBodyError(&helper.MalformedRequestBodyError{Details: []string{`unknown field "username"`}})
+
+	a := s.Assert()
+	a.True(ok)
+	a.Equal(problem.MalformedRequestBody, p.Code)
+	a.Equal([]string{`unknown field "username"`}, p.Extensions["details"])
+}
+
+func (s *ProblemSuite) TestFromRequestBodyError_KnownErrors_ReturnMatchingCode() {
+	a := s.Assert()
+
+	p, ok := problem.FromRequestBodyError(helper.ErrUnsupportedMediaType)
+	a.True(ok)
+	a.Equal(problem.UnsupportedMediaType, p.Code)
+
+	p, ok = problem.FromRequestBodyError(helper.ErrRequestBodyTooLarge)
+	a.True(ok)
+	a.Equal(problem.RequestBodyTooLarge, p.Code)
+}
+
+func (s *ProblemSuite) TestFromRequestBodyError_OtherError_ReturnFalse() {
+	_, ok := problem.FromRequestBodyError(errors.New("mock error"))
+
+	s.Assert().False(ok)
+}
+
+func (s *ProblemSuite) TestFromError_DomainErrorKinds_ReturnMappedStatus() {
+	statuses := map[helper.ErrorKind]int{
+		helper.ErrorKindInvalid:         http.StatusBadRequest,
+		helper.ErrorKindUnauthenticated: http.StatusUnauthorized,
+		helper.ErrorKindForbidden:       http.StatusForbidden,
+		helper.ErrorKindConflict:        http.StatusConflict,
+		helper.ErrorKindUnprocessable:   http.StatusUnprocessableEntity,
+	}
+
+	a := s.Assert()
+	for kind, status := range statuses {
+		p, ok := problem.FromError(&helper.DomainError{Kind: kind, Code: "some_code", Message: "Safe message.", Reason: "internal reason"})
+		a.True(ok)
+		a.Equal(status, p.Code.Status)
+		a.Equal("some_code", p.Code.Code)
+		a.Equal("Safe message.", p.Detail)
+	}
+}
+
+func (s *ProblemSuite) TestFromError_WrappedDomainError_ReturnProblem() {
+	err := fmt.Errorf("register: %w", &helper.DomainError{Kind: helper.ErrorKindConflict, Code: "user_already_exists"})
+
+	p, ok := problem.FromError(err)
+
+	a := s.Assert()
+	a.True(ok)
+	a.Equal(http.StatusConflict, p.Code.Status)
+}
+
+func (s *ProblemSuite) TestFromError_InternalDomainError_ReturnFalse() {
+	_, ok := problem.FromError(&helper.DomainError{Kind: helper.ErrorKindInternal, Code: "internal_error", Reason: "private key is not valid"})
+
+	s.Assert().False(ok)
+}
+
+func (s *ProblemSuite) TestWriteError_DomainError_HideReason() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	problem.WriteError(s.responseWriter, s.request, s.timer, &helper.DomainError{
+		Kind:    helper.ErrorKindUnauthenticated,
+		Code:    "invalid_credentials",
+		Message: "Invalid credentials.",
+		Reason:  "login password is not valid",
+	})
+
+	body, _ := io.ReadAll(s.responseWriter.Result().Body)
+	s.Assert().JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/invalid_credentials",
+			"title": "Authentication failed",
+			"status": 401,
+			"code": "invalid_credentials",
+			"detail": "Invalid credentials.",
+			"meta": {
+				"http_status": 401,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *ProblemSuite) TestCodes_Unique() {
+	codes := []problem.Code{
+		problem.InternalError, problem.Unauthorized, problem.InvalidCredentials, problem.Forbidden,
+		problem.TooManyRequests, problem.UnsupportedMediaType, problem.RequestBodyTooLarge,
+		problem.MalformedRequestBody, problem.InvalidParameter, problem.InvalidCursor,
+		problem.RequiredFieldMissing, problem.InvalidResetLink, problem.SessionNotFound,
+		problem.InvalidTimeRange, problem.UserNotFound, problem.RoleNotFound,
+		problem.InvalidSuspension, problem.SelfModification,
+	}
+
+	seen := map[string]bool{}
+	a := s.Assert()
+	for _, code := range codes {
+		a.False(seen[code.Code], "duplicate code %s", code.Code)
+		a.NotEmpty(code.Title)
+		a.GreaterOrEqual(code.Status, 400)
+		seen[code.Code] = true
+	}
+}
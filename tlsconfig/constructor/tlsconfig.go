@@ -0,0 +1,40 @@
+package constructor
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/tlsconfig"
+)
+
+// ConstructTLSConfig returns the TLS config of the API listener, nil when TLS
+// is disabled, and the reloader whose Run method keeps its certificate up to
+// date. An error is returned when the certificate or the client CA bundle
+// cannot be loaded.
+func ConstructTLSConfig(cfg *config.Config, logger *slog.Logger) (*tls.Config, *tlsconfig.CertificateReloader, error) {
+	if !cfg.TLS.Enabled() {
+		return nil, nil, nil
+	}
+
+	certificates, err := tlsconfig.NewCertificateReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ReloadInterval, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid TLS certificate: %w", err)
+	}
+
+	// Versions, suites and client auth were validated when the configuration
+	// was loaded.
+	opts := tlsconfig.Options{}
+	opts.MinVersion, _ = tlsconfig.ParseVersion(cfg.TLS.MinVersion)
+	opts.CipherSuites, _ = tlsconfig.ParseCipherSuites(cfg.TLS.CipherSuites)
+	opts.ClientAuth, _ = tlsconfig.ParseClientAuth(cfg.TLS.ClientAuth)
+	if cfg.TLS.ClientCAFile != "" {
+		opts.ClientCAs, err = tlsconfig.LoadCertPool(cfg.TLS.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid TLS client CA bundle: %w", err)
+		}
+	}
+
+	return tlsconfig.New(certificates, opts), certificates, nil
+}
@@ -0,0 +1,106 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertificateReloader serves a certificate and key pair read from files and
+// reloads them when they change, so renewed certificates are picked up
+// without a restart.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	modTimes    [2]time.Time
+}
+
+// NewCertificateReloader loads the pair once and fails if it is invalid, as
+// the listener cannot start without it.
+func NewCertificateReloader(certFile, keyFile string, interval time.Duration, logger *slog.Logger) (*CertificateReloader, error) {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile, interval: interval, logger: logger}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.certificate, nil
+}
+
+// Reload reads the pair again if either file was modified since the last
+// load and reports whether it did. An invalid pair leaves the current
+// certificate in place, e.g. when the key is renewed before the certificate.
+func (r *CertificateReloader) Reload() (bool, error) {
+	modTimes, err := r.readModTimes()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.certificate != nil && modTimes == r.modTimes
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	r.modTimes = modTimes
+	return true, nil
+}
+
+func (r *CertificateReloader) readModTimes() ([2]time.Time, error) {
+	modTimes := [2]time.Time{}
+	for i, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+// Run checks the files on every tick until ctx is done.
+func (r *CertificateReloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := r.Reload()
+		if err != nil {
+			r.logger.Error("reloading TLS certificate failed", "error", err)
+			continue
+		}
+		if reloaded {
+			r.logger.Info("reloaded TLS certificate", "cert_file", r.certFile)
+		}
+	}
+}
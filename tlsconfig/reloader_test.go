@@ -0,0 +1,84 @@
+package tlsconfig_test
+
+import (
+	"crypto/x509"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/tlsconfig"
+)
+
+type CertificateReloaderSuite struct {
+	suite.Suite
+
+	dir      string
+	certFile string
+	keyFile  string
+	modTime  time.Time
+	reloader *tlsconfig.CertificateReloader
+}
+
+func TestCertificateReloaderSuite(t *testing.T) {
+	suite.Run(t, &CertificateReloaderSuite{})
+}
+
+func (s *CertificateReloaderSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.modTime = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.certFile, s.keyFile = newTestCertificate(s.T(), 1, false, nil).write(s.T(), s.dir, s.modTime)
+
+	reloader, err := tlsconfig.NewCertificateReloader(s.certFile, s.keyFile, time.Minute, logging.New(io.Discard, slog.LevelInfo))
+	s.Require().NoError(err)
+	s.reloader = reloader
+}
+
+func (s *CertificateReloaderSuite) serial() int64 {
+	certificate, err := s.reloader.GetCertificate(nil)
+	s.Require().NoError(err)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	s.Require().NoError(err)
+	return leaf.SerialNumber.Int64()
+}
+
+func (s *CertificateReloaderSuite) TestNewCertificateReloader_MissingFile_ReturnError() {
+	_, err := tlsconfig.NewCertificateReloader(s.certFile, s.dir+"/missing.key", time.Minute, nil)
+
+	s.Assert().ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *CertificateReloaderSuite) TestReload_FilesUnchanged_KeepCertificate() {
+	reloaded, err := s.reloader.Reload()
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(reloaded)
+	a.Equal(int64(1), s.serial())
+}
+
+func (s *CertificateReloaderSuite) TestReload_FilesRenewed_ServeNewCertificate() {
+	newTestCertificate(s.T(), 2, false, nil).write(s.T(), s.dir, s.modTime.Add(time.Hour))
+
+	reloaded, err := s.reloader.Reload()
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(reloaded)
+	a.Equal(int64(2), s.serial())
+}
+
+func (s *CertificateReloaderSuite) TestReload_KeyRenewedAlone_KeepCertificateAndReturnError() {
+	renewed := newTestCertificate(s.T(), 2, false, nil)
+	s.Require().NoError(os.WriteFile(s.keyFile, renewed.keyPEM, 0o600))
+
+	reloaded, err := s.reloader.Reload()
+
+	a := s.Assert()
+	a.Error(err)
+	a.False(reloaded)
+	a.Equal(int64(1), s.serial())
+}
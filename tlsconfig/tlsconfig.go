@@ -0,0 +1,118 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Client authentication modes of the listener.
+const (
+	ClientAuthNone          = "none"
+	ClientAuthVerifyIfGiven = "verify_if_given"
+	ClientAuthRequire       = "require"
+)
+
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	ClientAuthNone:          tls.NoClientCert,
+	ClientAuthVerifyIfGiven: tls.VerifyClientCertIfGiven,
+	ClientAuthRequire:       tls.RequireAndVerifyClientCert,
+}
+
+type Options struct {
+	MinVersion uint16
+	// CipherSuites restricts the suites of TLS 1.2 connections, Go's defaults
+	// when empty. TLS 1.3 suites are not configurable.
+	CipherSuites []uint16
+	ClientAuth   tls.ClientAuthType
+	// ClientCAs verifies client certificates when ClientAuth asks for them.
+	ClientCAs *x509.CertPool
+}
+
+// New returns the server TLS config serving the current certificate of
+// certificates.
+func New(certificates *CertificateReloader, opts Options) *tls.Config {
+	return &tls.Config{
+		GetCertificate: certificates.GetCertificate,
+		MinVersion:     opts.MinVersion,
+		CipherSuites:   opts.CipherSuites,
+		ClientAuth:     opts.ClientAuth,
+		ClientCAs:      opts.ClientCAs,
+	}
+}
+
+// ParseVersion parses a minimum version, "1.2" or "1.3".
+func ParseVersion(version string) (uint16, error) {
+	v, ok := versions[version]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version %q, expected 1.2 or 1.3", version)
+	}
+
+	return v, nil
+}
+
+// ParseCipherSuites parses cipher suite names such as
+// TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, nil when there are none. Suites
+// with known weaknesses are rejected, and so are lists HTTP/2 cannot be
+// served with.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	var ids []uint16
+	http2Ready := false
+	for _, name := range names {
+		id, ok := cipherSuiteID(name)
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher suite %q", name)
+		}
+		ids = append(ids, id)
+		http2Ready = http2Ready || id == tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 || id == tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256
+	}
+
+	if len(ids) > 0 && !http2Ready {
+		return nil, errors.New("cipher suites must include TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 or TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, required by HTTP/2")
+	}
+
+	return ids, nil
+}
+
+func cipherSuiteID(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseClientAuth parses a client authentication mode, one of none,
+// verify_if_given and require.
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	clientAuth, ok := clientAuthTypes[mode]
+	if !ok {
+		return 0, fmt.Errorf("unsupported client auth %q, expected none, verify_if_given or require", mode)
+	}
+
+	return clientAuth, nil
+}
+
+// LoadCertPool reads a bundle of PEM encoded CA certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no PEM certificate found in " + path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,154 @@
+package tlsconfig_test
+
+import (
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/tlsconfig"
+)
+
+type TLSConfigSuite struct {
+	suite.Suite
+
+	ca           *testCertificate
+	server       *testCertificate
+	caFile       string
+	certificates *tlsconfig.CertificateReloader
+}
+
+func TestTLSConfigSuite(t *testing.T) {
+	suite.Run(t, &TLSConfigSuite{})
+}
+
+func (s *TLSConfigSuite) SetupTest() {
+	dir := s.T().TempDir()
+	s.ca = newTestCertificate(s.T(), 1, true, nil)
+	s.server = newTestCertificate(s.T(), 2, false, s.ca)
+
+	s.caFile = filepath.Join(dir, "ca.crt")
+	s.Require().NoError(os.WriteFile(s.caFile, s.ca.certPEM, 0o600))
+
+	certFile, keyFile := s.server.write(s.T(), dir, time.Now())
+	certificates, err := tlsconfig.NewCertificateReloader(certFile, keyFile, time.Minute, logging.New(io.Discard, slog.LevelInfo))
+	s.Require().NoError(err)
+	s.certificates = certificates
+}
+
+// handshake runs a handshake between a server with config and a client
+// trusting the CA, presenting clientCertificates.
+func (s *TLSConfigSuite) handshake(config *tls.Config, clientCertificates []tls.Certificate, maxVersion uint16) error {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- conn.(*tls.Conn).Handshake()
+	}()
+
+	roots, err := tlsconfig.LoadCertPool(s.caFile)
+	s.Require().NoError(err)
+	conn, clientErr := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+		ServerName:   "example.test",
+		RootCAs:      roots,
+		Certificates: clientCertificates,
+		MaxVersion:   maxVersion,
+	})
+	if clientErr == nil {
+		conn.Close()
+	}
+
+	// TLS 1.3 clients finish before the server has verified them, so the
+	// verdict of the server comes first.
+	if err := <-serverErr; err != nil {
+		return err
+	}
+
+	return clientErr
+}
+
+func (s *TLSConfigSuite) TestNew_NoClientAuth_ServeCertificate() {
+	config := tlsconfig.New(s.certificates, tlsconfig.Options{MinVersion: tls.VersionTLS12})
+
+	s.Assert().NoError(s.handshake(config, nil, 0))
+}
+
+func (s *TLSConfigSuite) TestNew_ClientBelowMinVersion_RejectHandshake() {
+	config := tlsconfig.New(s.certificates, tlsconfig.Options{MinVersion: tls.VersionTLS13})
+
+	s.Assert().Error(s.handshake(config, nil, tls.VersionTLS12))
+}
+
+func (s *TLSConfigSuite) TestNew_RequireClientCertificate_VerifyAgainstCA() {
+	pool, err := tlsconfig.LoadCertPool(s.caFile)
+	s.Require().NoError(err)
+	config := tlsconfig.New(s.certificates, tlsconfig.Options{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	})
+
+	a := s.Assert()
+	a.Error(s.handshake(config, nil, 0))
+	a.Error(s.handshake(config, []tls.Certificate{newTestCertificate(s.T(), 3, false, nil).tlsCertificate(s.T())}, 0))
+	a.NoError(s.handshake(config, []tls.Certificate{newTestCertificate(s.T(), 4, false, s.ca).tlsCertificate(s.T())}, 0))
+}
+
+func (s *TLSConfigSuite) TestLoadCertPool_NoCertificate_ReturnError() {
+	path := filepath.Join(s.T().TempDir(), "empty.crt")
+	s.Require().NoError(os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+	_, err := tlsconfig.LoadCertPool(path)
+
+	s.Assert().EqualError(err, "no PEM certificate found in "+path)
+}
+
+func (s *TLSConfigSuite) TestParseVersion() {
+	a := s.Assert()
+	version, err := tlsconfig.ParseVersion("1.3")
+	a.Nil(err)
+	a.Equal(uint16(tls.VersionTLS13), version)
+
+	_, err = tlsconfig.ParseVersion("1.0")
+	a.EqualError(err, `unsupported TLS version "1.0", expected 1.2 or 1.3`)
+}
+
+func (s *TLSConfigSuite) TestParseCipherSuites() {
+	a := s.Assert()
+	suites, err := tlsconfig.ParseCipherSuites([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"})
+	a.Nil(err)
+	a.Equal([]uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, suites)
+
+	_, err = tlsconfig.ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+	a.EqualError(err, `unsupported cipher suite "TLS_RSA_WITH_RC4_128_SHA"`)
+
+	_, err = tlsconfig.ParseCipherSuites([]string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"})
+	a.ErrorContains(err, "required by HTTP/2")
+
+	suites, err = tlsconfig.ParseCipherSuites(nil)
+	a.Nil(err)
+	a.Nil(suites)
+}
+
+func (s *TLSConfigSuite) TestParseClientAuth() {
+	a := s.Assert()
+	clientAuth, err := tlsconfig.ParseClientAuth(tlsconfig.ClientAuthVerifyIfGiven)
+	a.Nil(err)
+	a.Equal(tls.VerifyClientCertIfGiven, clientAuth)
+
+	_, err = tlsconfig.ParseClientAuth("optional")
+	a.Error(err)
+}
@@ -0,0 +1,42 @@
+// Package tracing creates the OpenTelemetry spans of the API. Spans go to the
+// global tracer provider, which does nothing until the tracing constructor
+// installs an exporting one, so instrumented code needs no setup in tests.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "littlerollingsushi.com/example"
+
+// Start starts a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// StartQuery starts a client span for a SQL statement run by a gateway. The
+// statement is recorded with its placeholders, never with the arguments.
+func StartQuery(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.statement", query),
+		),
+	)
+}
+
+// End marks span as failed when err is not nil and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,79 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"littlerollingsushi.com/example/tracing"
+)
+
+type TracingSuite struct {
+	suite.Suite
+
+	recorder *tracetest.SpanRecorder
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, &TracingSuite{})
+}
+
+func (s *TracingSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *TracingSuite) TearDownTest() {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+}
+
+func (s *TracingSuite) TestStart_ChildOfSpanInContext() {
+	ctx, parent := tracing.Start(context.Background(), "LoginUsecase.Login")
+	_, child := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	child.End()
+	parent.End()
+
+	spans := s.recorder.Ended()
+	a := s.Assert()
+	a.Len(spans, 2)
+	a.Equal("bcrypt.CompareHashAndPassword", spans[0].Name())
+	a.Equal(spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func (s *TracingSuite) TestStartQuery_RecordStatement() {
+	_, span := tracing.StartQuery(context.Background(), "GetUserByEmailGateway.GetUserByEmail", "SELECT id FROM user WHERE email = ?")
+	span.End()
+
+	spans := s.recorder.Ended()
+	a := s.Assert()
+	a.Len(spans, 1)
+	a.Equal(trace.SpanKindClient, spans[0].SpanKind())
+	a.Contains(spans[0].Attributes(), attribute.String("db.statement", "SELECT id FROM user WHERE email = ?"))
+	a.Contains(spans[0].Attributes(), attribute.String("db.system", "mysql"))
+}
+
+func (s *TracingSuite) TestEnd_Error_MarkFailed() {
+	_, span := tracing.Start(context.Background(), "RegisterUsecase.Register")
+	tracing.End(span, errors.New("duplicate entry"))
+
+	spans := s.recorder.Ended()
+	a := s.Assert()
+	a.Equal(codes.Error, spans[0].Status().Code)
+	a.Equal("duplicate entry", spans[0].Status().Description)
+	a.Len(spans[0].Events(), 1)
+}
+
+func (s *TracingSuite) TestEnd_NoError_LeaveStatusUnset() {
+	_, span := tracing.Start(context.Background(), "RegisterUsecase.Register")
+	tracing.End(span, nil)
+
+	s.Assert().Equal(codes.Unset, s.recorder.Ended()[0].Status().Code)
+}
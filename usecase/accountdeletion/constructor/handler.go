@@ -0,0 +1,44 @@
+package constructor
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	"littlerollingsushi.com/example/usecase/accountdeletion/handler"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+	"littlerollingsushi.com/example/usecase/accountdeletion/worker"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+func ConstructAccountDeletionHandler(cfg *config.Config, db *sql.DB) *handler.AccountDeletionHandler {
+	timer := &helper.TimerImplementation{}
+	return handler.NewAccountDeletionHandler(constructUsecase(cfg, db), timer)
+}
+
+func ConstructPurgeWorker(cfg *config.Config, db *sql.DB, logger *slog.Logger) *worker.PurgeWorker {
+	return worker.NewPurgeWorker(constructUsecase(cfg, db), cfg.AccountDeletion.PurgeInterval, logger)
+}
+
+func constructUsecase(cfg *config.Config, db *sql.DB) *internal.AccountDeletionUsecase {
+	return internal.NewAccountDeletionUsecase(
+		internal.AccountDeletionUsecaseConfig{
+			GracePeriod: cfg.AccountDeletion.GracePeriod,
+			PurgeMode:   cfg.AccountDeletion.PurgeMode,
+		},
+		struct {
+			*internal.GetUserByIDGateway
+			*internal.SoftDeleteUserGateway
+			*internal.PurgeDeletedUsersGateway
+			*helper.PasswordEncrypter
+			helper.Timer
+		}{
+			GetUserByIDGateway:       internal.NewGetUserByIDGateway(db),
+			SoftDeleteUserGateway:    internal.NewSoftDeleteUserGateway(db),
+			PurgeDeletedUsersGateway: internal.NewPurgeDeletedUsersGateway(db),
+			PasswordEncrypter:        &helper.PasswordEncrypter{Observer: metricsConstructor.ConstructMetrics()},
+			Timer:                    &helper.TimerImplementation{},
+		},
+	)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type AccountDeletionHandler struct {
+	usecase AccountDeletionUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=AccountDeletionUsecase --output=./mocks
+type AccountDeletionUsecase interface {
+	DeleteAccount(context.Context, internal.DeleteAccountUsecaseInput) (internal.DeleteAccountUsecaseOutput, error)
+}
+
+type deleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+func NewAccountDeletionHandler(usecase AccountDeletionUsecase, timer helper.Timer) *AccountDeletionHandler {
+	return &AccountDeletionHandler{usecase: usecase, timer: timer}
+}
+
+// DeleteAccount must be registered behind the authentication middleware,
+// the account is taken from the user id of the access token.
+func (h *AccountDeletionHandler) DeleteAccount(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok {
+		problem.Write(w, h.timer, problem.InvalidCredentials, "Invalid credentials.")
+		return
+	}
+
+	req := deleteAccountRequest{}
+	if err := helper.DecodeRequestBody(w, r, helper.MaxRequestBodyBytes, &req); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
+	in := internal.DeleteAccountUsecaseInput{
+		UserID:   claims.UserID,
+		Password: req.Password,
+	}
+
+	out, err := h.usecase.DeleteAccount(r.Context(), in)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeDeleteAccountResponse(w, out)
+}
+
+func (h *AccountDeletionHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case internal.ErrUserNotFound, internal.ErrInvalidPassword:
+		problem.Write(w, h.timer, problem.InvalidCredentials, "Invalid credentials.")
+	case internal.ErrEmptyPassword:
+		problem.Write(w, h.timer, problem.RequiredFieldMissing, "Password is required to delete the account.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *AccountDeletionHandler) writeDeleteAccountResponse(w http.ResponseWriter, out internal.DeleteAccountUsecaseOutput) {
+	data := map[string]interface{}{
+		"message":  "Account scheduled for deletion. Login with cancel_deletion=true before purge_at to keep it.",
+		"purge_at": out.PurgeAt.Format("2006-01-02T15:04:05.999Z"),
+		"meta": map[string]interface{}{
+			"http_status": http.StatusAccepted,
+			"server_time": h.timer.NowInUTC().Format("2006-01-02T15:04:05.999Z"),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,182 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/accountdeletion/handler"
+	"littlerollingsushi.com/example/usecase/accountdeletion/handler/mocks"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AccountDeletionHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.AccountDeletionUsecase
+	timer   *helperMocks.Timer
+	handler *handler.AccountDeletionHandler
+
+	expectedUsecaseInput        internal.DeleteAccountUsecaseInput
+	expectedUsecaseOutput       internal.DeleteAccountUsecaseOutput
+	expectedTimestamp           time.Time
+	expectedSuccessResponseBody string
+	errMock                     error
+}
+
+func TestAccountDeletionHandlerSuite(t *testing.T) {
+	suite.Run(t, &AccountDeletionHandlerSuite{})
+}
+
+func (s *AccountDeletionHandlerSuite) SetupTest() {
+	form := url.Values{}
+	form.Add("password", "verysecure")
+	s.request = httptest.NewRequest("DELETE", "http://test.com/v1/me", strings.NewReader(form.Encode()))
+	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request = s.request.WithContext(helper.ContextWithAccessTokenClaims(
+		s.request.Context(),
+		&helper.AccessTokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "john.doe@email.com"}, UserID: 7},
+	))
+
+	s.responseWriter = httptest.NewRecorder()
+
+	s.requestParams = map[string]string{}
+
+	s.usecase = mocks.NewAccountDeletionUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewAccountDeletionHandler(s.usecase, s.timer)
+
+	s.expectedUsecaseInput = internal.DeleteAccountUsecaseInput{
+		UserID:   7,
+		Password: "verysecure",
+	}
+	s.expectedUsecaseOutput = internal.DeleteAccountUsecaseOutput{
+		PurgeAt: time.Date(2022, 11, 28, 23, 59, 59, 0, time.UTC),
+	}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.expectedSuccessResponseBody = `
+		{
+			"message": "Account scheduled for deletion. Login with cancel_deletion=true before purge_at to keep it.",
+			"purge_at": "2022-11-28T23:59:59Z",
+			"meta": {
+				"http_status": 202,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+
+	s.errMock = errors.New("mock error")
+}
+
+func (s *AccountDeletionHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_NoClaims_ReturnUnauthorized() {
+	s.request = httptest.NewRequest("DELETE", "http://test.com/v1/me", nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidCredentials, "Invalid credentials."), string(body))
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_UsecaseUnknownError_ReturnInternalServerError() {
+	s.usecase.On("DeleteAccount", s.request.Context(), s.expectedUsecaseInput).Return(internal.DeleteAccountUsecaseOutput{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_InvalidPassword_ReturnUnauthorized() {
+	s.usecase.On("DeleteAccount", s.request.Context(), s.expectedUsecaseInput).Return(internal.DeleteAccountUsecaseOutput{}, internal.ErrInvalidPassword)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidCredentials, "Invalid credentials."), string(body))
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_UsecaseSuccess_ReturnAccepted() {
+	s.usecase.On("DeleteAccount", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusAccepted, resp.StatusCode)
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_JSONBody_ReturnAccepted() {
+	claims, _ := helper.AccessTokenClaimsFromContext(s.request.Context())
+	s.request = httptest.NewRequest("DELETE", "http://test.com/v1/me", strings.NewReader(`{"password":"verysecure"}`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.request = s.request.WithContext(helper.ContextWithAccessTokenClaims(s.request.Context(), claims))
+	s.usecase.On("DeleteAccount", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusAccepted, resp.StatusCode)
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *AccountDeletionHandlerSuite) TestDeleteAccount_UnsupportedContentType_ReturnUnsupportedMediaType() {
+	s.request.Header.Set("Content-Type", "text/plain")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DeleteAccount(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.UnsupportedMediaType, "Unsupported content type. Send application/json or a form."), string(body))
+}
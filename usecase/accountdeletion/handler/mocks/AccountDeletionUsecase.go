@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/accountdeletion/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountDeletionUsecase is an autogenerated mock type for the AccountDeletionUsecase type
+type AccountDeletionUsecase struct {
+	mock.Mock
+}
+
+// DeleteAccount provides a mock function with given fields: _a0, _a1
+func (_m *AccountDeletionUsecase) DeleteAccount(_a0 context.Context, _a1 internal.DeleteAccountUsecaseInput) (internal.DeleteAccountUsecaseOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.DeleteAccountUsecaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, internal.DeleteAccountUsecaseInput) internal.DeleteAccountUsecaseOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.DeleteAccountUsecaseOutput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.DeleteAccountUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAccountDeletionUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccountDeletionUsecase creates a new instance of AccountDeletionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccountDeletionUsecase(t mockConstructorTestingTNewAccountDeletionUsecase) *AccountDeletionUsecase {
+	mock := &AccountDeletionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
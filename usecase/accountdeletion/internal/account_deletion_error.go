@@ -0,0 +1,10 @@
+package internal
+
+import "errors"
+
+var (
+	ErrEmptyPassword    = errors.New("account deletion password can not be empty")
+	ErrInvalidPassword  = errors.New("account deletion password is not valid")
+	ErrUserNotFound     = errors.New("user with given id is not found")
+	ErrInvalidPurgeMode = errors.New("account deletion purge mode is not valid")
+)
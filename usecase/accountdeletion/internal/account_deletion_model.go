@@ -0,0 +1,17 @@
+package internal
+
+import "time"
+
+const (
+	PurgeModeDelete    = "delete"
+	PurgeModeAnonymize = "anonymize"
+)
+
+type DeleteAccountUsecaseInput struct {
+	UserID   int64
+	Password string
+}
+
+type DeleteAccountUsecaseOutput struct {
+	PurgeAt time.Time
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+type AccountDeletionUsecase struct {
+	config  AccountDeletionUsecaseConfig
+	gateway AccountDeletionGateway
+}
+
+type AccountDeletionUsecaseConfig struct {
+	GracePeriod time.Duration
+	PurgeMode   string
+}
+
+//go:generate mockery --name=AccountDeletionGateway --output=./mocks
+type AccountDeletionGateway interface {
+	GetUserByID(ctx context.Context, userID int64) (entity.User, error)
+	SoftDeleteUser(ctx context.Context, userID int64, now time.Time) error
+	DeleteUsers(ctx context.Context, deletedBefore time.Time) (int64, error)
+	AnonymizeUsers(ctx context.Context, deletedBefore, now time.Time) (int64, error)
+	IsHashAndPasswordEqual(hash, password string) bool
+	NowInUTC() time.Time
+}
+
+func NewAccountDeletionUsecase(config AccountDeletionUsecaseConfig, gateway AccountDeletionGateway) *AccountDeletionUsecase {
+	return &AccountDeletionUsecase{config: config, gateway: gateway}
+}
+
+func (u *AccountDeletionUsecase) DeleteAccount(ctx context.Context, in DeleteAccountUsecaseInput) (DeleteAccountUsecaseOutput, error) {
+	if in.Password == "" {
+		return DeleteAccountUsecaseOutput{}, ErrEmptyPassword
+	}
+
+	user, err := u.gateway.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return DeleteAccountUsecaseOutput{}, err
+	}
+
+	if !u.gateway.IsHashAndPasswordEqual(user.CryptedPassword, in.Password) {
+		return DeleteAccountUsecaseOutput{}, ErrInvalidPassword
+	}
+
+	// Deleting twice keeps the original schedule instead of extending it.
+	if user.DeletedAt != nil {
+		return DeleteAccountUsecaseOutput{PurgeAt: user.DeletedAt.Add(u.config.GracePeriod)}, nil
+	}
+
+	now := u.gateway.NowInUTC()
+	if err := u.gateway.SoftDeleteUser(ctx, user.ID, now); err != nil {
+		return DeleteAccountUsecaseOutput{}, err
+	}
+
+	return DeleteAccountUsecaseOutput{PurgeAt: now.Add(u.config.GracePeriod)}, nil
+}
+
+// PurgeDeletedAccounts deletes or anonymizes, depending on the configured
+// purge mode, every account whose grace period has elapsed.
+func (u *AccountDeletionUsecase) PurgeDeletedAccounts(ctx context.Context) (int64, error) {
+	now := u.gateway.NowInUTC()
+	deletedBefore := now.Add(-u.config.GracePeriod)
+
+	switch u.config.PurgeMode {
+	case PurgeModeDelete:
+		return u.gateway.DeleteUsers(ctx, deletedBefore)
+	case PurgeModeAnonymize:
+		return u.gateway.AnonymizeUsers(ctx, deletedBefore, now)
+	default:
+		return 0, ErrInvalidPurgeMode
+	}
+}
@@ -0,0 +1,159 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal/mocks"
+)
+
+type AccountDeletionUsecaseSuite struct {
+	suite.Suite
+
+	config  internal.AccountDeletionUsecaseConfig
+	gateway *mocks.AccountDeletionGateway
+	usecase *internal.AccountDeletionUsecase
+
+	context context.Context
+	input   internal.DeleteAccountUsecaseInput
+	user    entity.User
+	now     time.Time
+	errMock error
+}
+
+func TestAccountDeletionUsecaseSuite(t *testing.T) {
+	suite.Run(t, &AccountDeletionUsecaseSuite{})
+}
+
+func (s *AccountDeletionUsecaseSuite) SetupTest() {
+	s.config = internal.AccountDeletionUsecaseConfig{
+		GracePeriod: 24 * time.Hour,
+		PurgeMode:   internal.PurgeModeAnonymize,
+	}
+	s.gateway = mocks.NewAccountDeletionGateway(s.T())
+	s.usecase = internal.NewAccountDeletionUsecase(s.config, s.gateway)
+
+	s.context = context.Background()
+	s.input = internal.DeleteAccountUsecaseInput{
+		UserID:   7,
+		Password: "verysecure",
+	}
+	s.user = entity.User{
+		ID:              7,
+		FirstName:       "John",
+		LastName:        "Doe",
+		Email:           "john.doe@email.com",
+		CryptedPassword: "verysecureencrypted",
+	}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_EmptyPassword_ReturnError() {
+	s.input.Password = ""
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrEmptyPassword)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_GetUserByIDError_ReturnOriginalError() {
+	s.gateway.On("GetUserByID", s.context, s.input.UserID).Return(entity.User{}, s.errMock)
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_InvalidPassword_ReturnError() {
+	s.gateway.On("GetUserByID", s.context, s.input.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(false)
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrInvalidPassword)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_AlreadyDeleted_KeepOriginalSchedule() {
+	deletedAt := s.now.Add(-time.Hour)
+	s.user.DeletedAt = &deletedAt
+	s.gateway.On("GetUserByID", s.context, s.input.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(deletedAt.Add(s.config.GracePeriod), output.PurgeAt)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_SoftDeleteError_ReturnOriginalError() {
+	s.gateway.On("GetUserByID", s.context, s.input.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("SoftDeleteUser", s.context, s.user.ID, s.now).Return(s.errMock)
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestDeleteAccount_Success_ReturnPurgeAt() {
+	s.gateway.On("GetUserByID", s.context, s.input.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("SoftDeleteUser", s.context, s.user.ID, s.now).Return(nil)
+
+	output, err := s.usecase.DeleteAccount(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.now.Add(s.config.GracePeriod), output.PurgeAt)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestPurgeDeletedAccounts_AnonymizeMode_AnonymizeUsers() {
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("AnonymizeUsers", s.context, s.now.Add(-s.config.GracePeriod), s.now).Return(int64(3), nil)
+
+	purged, err := s.usecase.PurgeDeletedAccounts(s.context)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(3), purged)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestPurgeDeletedAccounts_DeleteMode_DeleteUsers() {
+	s.config.PurgeMode = internal.PurgeModeDelete
+	s.usecase = internal.NewAccountDeletionUsecase(s.config, s.gateway)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("DeleteUsers", s.context, s.now.Add(-s.config.GracePeriod)).Return(int64(2), s.errMock)
+
+	purged, err := s.usecase.PurgeDeletedAccounts(s.context)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Equal(int64(2), purged)
+}
+
+func (s *AccountDeletionUsecaseSuite) TestPurgeDeletedAccounts_UnknownMode_ReturnError() {
+	s.config.PurgeMode = "shred"
+	s.usecase = internal.NewAccountDeletionUsecase(s.config, s.gateway)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	_, err := s.usecase.PurgeDeletedAccounts(s.context)
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidPurgeMode)
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	getUserByIDQuery = "SELECT id, crypted_password, deleted_at FROM user WHERE id = ?"
+)
+
+type GetUserByIDGateway struct {
+	sql *sql.DB
+}
+
+func NewGetUserByIDGateway(sql *sql.DB) *GetUserByIDGateway {
+	return &GetUserByIDGateway{sql: sql}
+}
+
+// GetUserByID reads only the columns the account deletion needs.
+func (g *GetUserByIDGateway) GetUserByID(ctx context.Context, userID int64) (entity.User, error) {
+	user := entity.User{}
+	err := g.sql.QueryRowContext(ctx, getUserByIDQuery, userID).Scan(&user.ID, &user.CryptedPassword, &user.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, ErrUserNotFound
+		}
+
+		return user, err
+	}
+
+	return user, nil
+}
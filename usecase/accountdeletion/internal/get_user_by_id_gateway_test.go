@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+)
+
+type GetUserByIDGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+
+	context context.Context
+	user    entity.User
+	gateway *internal.GetUserByIDGateway
+}
+
+func TestGetUserByIDGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetUserByIDGatewaySuite{})
+}
+
+func (s *GetUserByIDGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, crypted_password, deleted_at FROM user WHERE id = ?"
+
+	deletedAt := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.gateway = internal.NewGetUserByIDGateway(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7, CryptedPassword: "verysecureencrypted", DeletedAt: &deletedAt}
+}
+
+func (s *GetUserByIDGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_NoRows_ReturnUserNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnError(sql.ErrNoRows)
+
+	user, err := s.gateway.GetUserByID(s.context, s.user.ID)
+
+	a := s.Assert()
+	a.Empty(user)
+	a.ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_UserFound_ReturnUser() {
+	rows := sqlmock.NewRows([]string{"id", "crypted_password", "deleted_at"}).AddRow(s.user.ID, s.user.CryptedPassword, *s.user.DeletedAt)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	user, err := s.gateway.GetUserByID(s.context, s.user.ID)
+
+	a := s.Assert()
+	a.Equal(s.user, user)
+	a.Nil(err)
+}
@@ -0,0 +1,138 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AccountDeletionGateway is an autogenerated mock type for the AccountDeletionGateway type
+type AccountDeletionGateway struct {
+	mock.Mock
+}
+
+// AnonymizeUsers provides a mock function with given fields: ctx, deletedBefore, now
+func (_m *AccountDeletionGateway) AnonymizeUsers(ctx context.Context, deletedBefore time.Time, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) int64); ok {
+		r0 = rf(ctx, deletedBefore, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUsers provides a mock function with given fields: ctx, deletedBefore
+func (_m *AccountDeletionGateway) DeleteUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userID
+func (_m *AccountDeletionGateway) GetUserByID(ctx context.Context, userID int64) (entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsHashAndPasswordEqual provides a mock function with given fields: hash, password
+func (_m *AccountDeletionGateway) IsHashAndPasswordEqual(hash string, password string) bool {
+	ret := _m.Called(hash, password)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(hash, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *AccountDeletionGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// SoftDeleteUser provides a mock function with given fields: ctx, userID, now
+func (_m *AccountDeletionGateway) SoftDeleteUser(ctx context.Context, userID int64, now time.Time) error {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAccountDeletionGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccountDeletionGateway creates a new instance of AccountDeletionGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccountDeletionGateway(t mockConstructorTestingTNewAccountDeletionGateway) *AccountDeletionGateway {
+	mock := &AccountDeletionGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
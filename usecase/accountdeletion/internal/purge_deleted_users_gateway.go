@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	deleteUsersQuery    = "DELETE FROM user WHERE deleted_at IS NOT NULL AND deleted_at <= ?"
+	anonymizeUsersQuery = "UPDATE user SET first_name = NULL, last_name = NULL, " +
+		"email = CONCAT('deleted-', id, '@anonymized.invalid'), crypted_password = '', anonymized_at = ?, updated_at = ? " +
+		"WHERE deleted_at IS NOT NULL AND deleted_at <= ? AND anonymized_at IS NULL"
+	deleteUserDevicesQuery = "DELETE FROM user_device WHERE user_id IN " +
+		"(SELECT id FROM user WHERE deleted_at IS NOT NULL AND deleted_at <= ?)"
+)
+
+type PurgeDeletedUsersGateway struct {
+	sql *sql.DB
+}
+
+func NewPurgeDeletedUsersGateway(sql *sql.DB) *PurgeDeletedUsersGateway {
+	return &PurgeDeletedUsersGateway{sql: sql}
+}
+
+// DeleteUsers removes the rows of every user deleted at or before deletedBefore.
+func (g *PurgeDeletedUsersGateway) DeleteUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	res, err := g.sql.ExecContext(ctx, deleteUsersQuery, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// AnonymizeUsers strips personal data from every user deleted at or before
+// deletedBefore while keeping the row, so references to the id stay valid.
+// The devices of those users are deleted in the same transaction, being only
+// IP addresses and user agents.
+func (g *PurgeDeletedUsersGateway) AnonymizeUsers(ctx context.Context, deletedBefore, now time.Time) (int64, error) {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteUserDevicesQuery, deletedBefore); err != nil {
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, anonymizeUsersQuery, now, now, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	anonymized, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return anonymized, tx.Commit()
+}
@@ -0,0 +1,123 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+)
+
+type PurgeDeletedUsersGatewaySuite struct {
+	suite.Suite
+
+	db                         *sql.DB
+	mockDb                     sqlmock.Sqlmock
+	expectedDeleteQuery        string
+	expectedAnonymizeQuery     string
+	expectedDeleteDevicesQuery string
+	errMock                    error
+
+	context       context.Context
+	deletedBefore time.Time
+	now           time.Time
+	gateway       *internal.PurgeDeletedUsersGateway
+}
+
+func TestPurgeDeletedUsersGatewaySuite(t *testing.T) {
+	suite.Run(t, &PurgeDeletedUsersGatewaySuite{})
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedDeleteQuery = "DELETE FROM user WHERE deleted_at IS NOT NULL AND deleted_at <= ?"
+	s.expectedAnonymizeQuery = "UPDATE user SET first_name = NULL, last_name = NULL, " +
+		"email = CONCAT('deleted-', id, '@anonymized.invalid'), crypted_password = '', anonymized_at = ?, updated_at = ? " +
+		"WHERE deleted_at IS NOT NULL AND deleted_at <= ? AND anonymized_at IS NULL"
+	s.expectedDeleteDevicesQuery = "DELETE FROM user_device WHERE user_id IN " +
+		"(SELECT id FROM user WHERE deleted_at IS NOT NULL AND deleted_at <= ?)"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewPurgeDeletedUsersGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.deletedBefore = s.now.Add(-24 * time.Hour)
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TestDeleteUsers_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WillReturnError(s.errMock)
+
+	purged, err := s.gateway.DeleteUsers(s.context, s.deletedBefore)
+
+	a := s.Assert()
+	a.Zero(purged)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TestDeleteUsers_Success_ReturnRowsAffected() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WithArgs(s.deletedBefore).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := s.gateway.DeleteUsers(s.context, s.deletedBefore)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(2), purged)
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TestAnonymizeUsers_DeleteDevicesError_Rollback() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteDevicesQuery)).WithArgs(s.deletedBefore).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	purged, err := s.gateway.AnonymizeUsers(s.context, s.deletedBefore, s.now)
+
+	a := s.Assert()
+	a.Zero(purged)
+	a.ErrorIs(err, s.errMock)
+	a.NoError(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TestAnonymizeUsers_UnknownError_Rollback() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteDevicesQuery)).WithArgs(s.deletedBefore).WillReturnResult(sqlmock.NewResult(0, 4))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedAnonymizeQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	purged, err := s.gateway.AnonymizeUsers(s.context, s.deletedBefore, s.now)
+
+	a := s.Assert()
+	a.Zero(purged)
+	a.ErrorIs(err, s.errMock)
+	a.NoError(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *PurgeDeletedUsersGatewaySuite) TestAnonymizeUsers_Success_DeleteDevicesAndReturnRowsAffected() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteDevicesQuery)).WithArgs(s.deletedBefore).WillReturnResult(sqlmock.NewResult(0, 4))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedAnonymizeQuery)).
+		WithArgs(s.now, s.now, s.deletedBefore).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.mockDb.ExpectCommit()
+
+	purged, err := s.gateway.AnonymizeUsers(s.context, s.deletedBefore, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(3), purged)
+	a.NoError(s.mockDb.ExpectationsWereMet())
+}
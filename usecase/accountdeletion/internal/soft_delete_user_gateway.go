@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	softDeleteUserQuery     = "UPDATE user SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL"
+	revokeUserSessionsQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+)
+
+type SoftDeleteUserGateway struct {
+	sql *sql.DB
+}
+
+func NewSoftDeleteUserGateway(sql *sql.DB) *SoftDeleteUserGateway {
+	return &SoftDeleteUserGateway{sql: sql}
+}
+
+// SoftDeleteUser marks the user as deleted and revokes every session of the
+// user in a single transaction.
+func (g *SoftDeleteUserGateway) SoftDeleteUser(ctx context.Context, userID int64, now time.Time) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, softDeleteUserQuery, now, now, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, revokeUserSessionsQuery, now, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
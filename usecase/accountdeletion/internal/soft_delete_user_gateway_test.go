@@ -0,0 +1,91 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/accountdeletion/internal"
+)
+
+type SoftDeleteUserGatewaySuite struct {
+	suite.Suite
+
+	db                  *sql.DB
+	mockDb              sqlmock.Sqlmock
+	expectedQuery       string
+	expectedRevokeQuery string
+	errMock             error
+
+	context context.Context
+	userID  int64
+	now     time.Time
+	gateway *internal.SoftDeleteUserGateway
+}
+
+func TestSoftDeleteUserGatewaySuite(t *testing.T) {
+	suite.Run(t, &SoftDeleteUserGatewaySuite{})
+}
+
+func (s *SoftDeleteUserGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "UPDATE user SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL"
+	s.expectedRevokeQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewSoftDeleteUserGateway(s.db)
+	s.context = context.Background()
+	s.userID = 7
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *SoftDeleteUserGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *SoftDeleteUserGatewaySuite) TestSoftDeleteUser_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.SoftDeleteUser(s.context, s.userID, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *SoftDeleteUserGatewaySuite) TestSoftDeleteUser_RevokeError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.SoftDeleteUser(s.context, s.userID, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *SoftDeleteUserGatewaySuite) TestSoftDeleteUser_Success_RevokeSessions() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.now, s.now, s.userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WithArgs(s.now, s.userID).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.SoftDeleteUser(s.context, s.userID, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
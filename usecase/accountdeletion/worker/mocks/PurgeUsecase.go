@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PurgeUsecase is an autogenerated mock type for the PurgeUsecase type
+type PurgeUsecase struct {
+	mock.Mock
+}
+
+// PurgeDeletedAccounts provides a mock function with given fields: ctx
+func (_m *PurgeUsecase) PurgeDeletedAccounts(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPurgeUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPurgeUsecase creates a new instance of PurgeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPurgeUsecase(t mockConstructorTestingTNewPurgeUsecase) *PurgeUsecase {
+	mock := &PurgeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
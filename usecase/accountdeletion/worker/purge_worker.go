@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+//go:generate mockery --name=PurgeUsecase --output=./mocks
+type PurgeUsecase interface {
+	PurgeDeletedAccounts(ctx context.Context) (int64, error)
+}
+
+// PurgeWorker periodically purges accounts whose deletion grace period has
+// elapsed.
+type PurgeWorker struct {
+	usecase  PurgeUsecase
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewPurgeWorker(usecase PurgeUsecase, interval time.Duration, logger *slog.Logger) *PurgeWorker {
+	return &PurgeWorker{usecase: usecase, interval: interval, logger: logger}
+}
+
+// Run purges once immediately and then on every tick until ctx is done.
+func (w *PurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PurgeWorker) purge(ctx context.Context) {
+	purged, err := w.usecase.PurgeDeletedAccounts(ctx)
+	if err != nil {
+		w.logger.Error("purging deleted accounts failed", "error", err)
+		return
+	}
+
+	if purged > 0 {
+		w.logger.Info("purged deleted accounts", "count", purged)
+	}
+}
@@ -0,0 +1,50 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/accountdeletion/worker"
+	"littlerollingsushi.com/example/usecase/accountdeletion/worker/mocks"
+)
+
+type PurgeWorkerSuite struct {
+	suite.Suite
+
+	usecase *mocks.PurgeUsecase
+	worker  *worker.PurgeWorker
+}
+
+func TestPurgeWorkerSuite(t *testing.T) {
+	suite.Run(t, &PurgeWorkerSuite{})
+}
+
+func (s *PurgeWorkerSuite) SetupTest() {
+	s.usecase = mocks.NewPurgeUsecase(s.T())
+	s.worker = worker.NewPurgeWorker(s.usecase, time.Millisecond, logging.New(io.Discard, slog.LevelInfo))
+}
+
+func (s *PurgeWorkerSuite) TestRun_ContextCanceled_StopAfterPurging() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.usecase.On("PurgeDeletedAccounts", mock.Anything).Return(int64(1), nil).Once()
+	s.usecase.On("PurgeDeletedAccounts", mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(int64(0), errors.New("mock error"))
+
+	done := make(chan struct{})
+	go func() {
+		s.worker.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("worker did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,29 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/usecase/audit/handler"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+func ConstructAuditHandler(db *sql.DB) *handler.AuditHandler {
+	usecase := internal.NewAuditUsecase(
+		struct {
+			*internal.ListAuditEventsGateway
+			*internal.InsertAuditEventGateway
+			helper.Timer
+		}{
+			ListAuditEventsGateway:  internal.NewListAuditEventsGateway(db),
+			InsertAuditEventGateway: internal.NewInsertAuditEventGateway(db),
+			Timer:                   &helper.TimerImplementation{},
+		},
+	)
+	timer := &helper.TimerImplementation{}
+	return handler.NewAuditHandler(usecase, timer)
+}
+
+func ConstructPersonalDataExporter(db *sql.DB) helper.PersonalDataExporter {
+	return internal.NewAuditEventDataExporter(db)
+}
@@ -0,0 +1,12 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/usecase/audit/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+func ConstructAuditRecorder(db *sql.DB) helper.AuditRecorder {
+	return internal.NewInsertAuditEventGateway(db)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const timeFormat = "2006-01-02T15:04:05.999Z"
+
+type AuditHandler struct {
+	usecase AuditUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=AuditUsecase --output=./mocks
+type AuditUsecase interface {
+	ListAuditEvents(context.Context, internal.ListAuditEventsUsecaseInput) (internal.ListAuditEventsUsecaseOutput, error)
+}
+
+func NewAuditHandler(usecase AuditUsecase, timer helper.Timer) *AuditHandler {
+	return &AuditHandler{usecase: usecase, timer: timer}
+}
+
+// ListAuditEvents must be registered behind the authentication and permission
+// middlewares. It accepts optional user_id, RFC 3339 from and to, cursor and
+// limit query parameters.
+func (h *AuditHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok || claims.UserID == 0 {
+		problem.Write(w, h.timer, problem.Unauthorized, "Missing or invalid access token.")
+		return
+	}
+
+	in := internal.ListAuditEventsUsecaseInput{
+		Actor: internal.Actor{
+			UserID:    claims.UserID,
+			IPAddress: helper.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+		Cursor: r.FormValue("cursor"),
+	}
+
+	var err error
+	if in.UserID, err = parsePositiveInt(r.FormValue("user_id")); err != nil {
+		problem.Write(w, h.timer, problem.InvalidParameter, "User id must be a positive number.")
+		return
+	}
+
+	limit, err := parsePositiveInt(r.FormValue("limit"))
+	if err != nil {
+		problem.Write(w, h.timer, problem.InvalidParameter, "Limit must be a positive number.")
+		return
+	}
+	in.Limit = int(limit)
+
+	if in.From, err = parseTime(r.FormValue("from")); err != nil {
+		problem.Write(w, h.timer, problem.InvalidParameter, "From must be an RFC 3339 timestamp.")
+		return
+	}
+
+	if in.To, err = parseTime(r.FormValue("to")); err != nil {
+		problem.Write(w, h.timer, problem.InvalidParameter, "To must be an RFC 3339 timestamp.")
+		return
+	}
+
+	out, err := h.usecase.ListAuditEvents(r.Context(), in)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	events := []map[string]interface{}{}
+	for _, event := range out.Events {
+		events = append(events, h.buildEvent(event))
+	}
+
+	var nextCursor interface{}
+	if out.NextCursor != "" {
+		nextCursor = out.NextCursor
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{
+		"events":      events,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (h *AuditHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case internal.ErrInvalidCursor:
+		problem.Write(w, h.timer, problem.InvalidCursor, "Cursor is invalid.")
+	case internal.ErrInvalidTimeRange:
+		problem.Write(w, h.timer, problem.InvalidTimeRange, "From must be before to.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *AuditHandler) buildEvent(event entity.AuditEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            event.ID,
+		"type":          event.Type,
+		"actor_user_id": event.ActorUserID,
+		"user_id":       event.UserID,
+		"ip_address":    event.IPAddress,
+		"user_agent":    event.UserAgent,
+		"outcome":       event.Outcome,
+		"reason":        event.Reason,
+		"created_at":    event.CreatedAt.Format(timeFormat),
+	}
+}
+
+func parsePositiveInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if n <= 0 {
+		return 0, strconv.ErrRange
+	}
+
+	return n, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
+
+func (h *AuditHandler) writeDataResponse(w http.ResponseWriter, status int, data map[string]interface{}) {
+	data["meta"] = map[string]interface{}{
+		"http_status": status,
+		"server_time": h.timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,220 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/audit/handler"
+	"littlerollingsushi.com/example/usecase/audit/handler/mocks"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AuditHandlerSuite struct {
+	suite.Suite
+
+	responseWriter *httptest.ResponseRecorder
+	requestParams  map[string]string
+
+	usecase *mocks.AuditUsecase
+	timer   *helperMocks.Timer
+	handler *handler.AuditHandler
+
+	expectedActor     internal.Actor
+	expectedTimestamp time.Time
+	errMock           error
+}
+
+func TestAuditHandlerSuite(t *testing.T) {
+	suite.Run(t, &AuditHandlerSuite{})
+}
+
+func (s *AuditHandlerSuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+	s.requestParams = map[string]string{}
+
+	s.usecase = mocks.NewAuditUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewAuditHandler(s.usecase, s.timer)
+
+	s.expectedActor = internal.Actor{UserID: 1, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *AuditHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *AuditHandlerSuite) newRequest(target string) *http.Request {
+	r := httptest.NewRequest("GET", target, nil)
+	r.RemoteAddr = "192.0.2.1:54321"
+	r.Header.Set("User-Agent", "curl/7.85.0")
+
+	return r.WithContext(helper.ContextWithAccessTokenClaims(r.Context(), &helper.AccessTokenClaims{UserID: 1}))
+}
+
+func (s *AuditHandlerSuite) expectedResponseBody(status, message string) string {
+	return `
+		{
+			"message": "` + message + `",
+			"meta": {
+				"http_status": ` + status + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_NoClaims_ReturnUnauthorized() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, httptest.NewRequest("GET", "http://test.com/v1/admin/audit-events", nil), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Unauthorized, "Missing or invalid access token."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_InvalidUserID_ReturnBadRequest() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, s.newRequest("http://test.com/v1/admin/audit-events?user_id=-1"), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidParameter, "User id must be a positive number."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_InvalidLimit_ReturnBadRequest() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, s.newRequest("http://test.com/v1/admin/audit-events?limit=abc"), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidParameter, "Limit must be a positive number."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_InvalidFrom_ReturnBadRequest() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, s.newRequest("http://test.com/v1/admin/audit-events?from=yesterday"), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidParameter, "From must be an RFC 3339 timestamp."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_InvalidTimeRange_ReturnBadRequest() {
+	r := s.newRequest("http://test.com/v1/admin/audit-events")
+	s.usecase.On("ListAuditEvents", r.Context(), internal.ListAuditEventsUsecaseInput{Actor: s.expectedActor}).
+		Return(internal.ListAuditEventsUsecaseOutput{}, internal.ErrInvalidTimeRange)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidTimeRange, "From must be before to."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_UsecaseUnknownError_ReturnInternalServerError() {
+	r := s.newRequest("http://test.com/v1/admin/audit-events")
+	s.usecase.On("ListAuditEvents", r.Context(), internal.ListAuditEventsUsecaseInput{Actor: s.expectedActor}).
+		Return(internal.ListAuditEventsUsecaseOutput{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *AuditHandlerSuite) TestListAuditEvents_Success_ReturnEvents() {
+	r := s.newRequest("http://test.com/v1/admin/audit-events?user_id=7&from=2022-10-29T00:00:00Z&to=2022-10-30T00:00:00Z&cursor=MTI&limit=10")
+	userID := int64(7)
+	s.usecase.On("ListAuditEvents", r.Context(), internal.ListAuditEventsUsecaseInput{
+		Actor:  s.expectedActor,
+		UserID: userID,
+		From:   time.Date(2022, 10, 29, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC),
+		Cursor: "MTI",
+		Limit:  10,
+	}).Return(internal.ListAuditEventsUsecaseOutput{
+		Events: []entity.AuditEvent{{
+			ID:        11,
+			Type:      "auth.login",
+			UserID:    &userID,
+			IPAddress: "192.0.2.1",
+			UserAgent: "curl/7.85.0",
+			Outcome:   entity.AuditOutcomeSuccess,
+			CreatedAt: time.Date(2022, 10, 29, 12, 0, 0, 0, time.UTC),
+		}},
+		NextCursor: "MTE",
+	}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListAuditEvents(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"events": [
+				{
+					"id": 11,
+					"type": "auth.login",
+					"actor_user_id": null,
+					"user_id": 7,
+					"ip_address": "192.0.2.1",
+					"user_agent": "curl/7.85.0",
+					"outcome": "success",
+					"reason": "",
+					"created_at": "2022-10-29T12:00:00Z"
+				}
+			],
+			"next_cursor": "MTE",
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/audit/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditUsecase is an autogenerated mock type for the AuditUsecase type
+type AuditUsecase struct {
+	mock.Mock
+}
+
+// ListAuditEvents provides a mock function with given fields: _a0, _a1
+func (_m *AuditUsecase) ListAuditEvents(_a0 context.Context, _a1 internal.ListAuditEventsUsecaseInput) (internal.ListAuditEventsUsecaseOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.ListAuditEventsUsecaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, internal.ListAuditEventsUsecaseInput) internal.ListAuditEventsUsecaseOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.ListAuditEventsUsecaseOutput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.ListAuditEventsUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAuditUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuditUsecase creates a new instance of AuditUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuditUsecase(t mockConstructorTestingTNewAuditUsecase) *AuditUsecase {
+	mock := &AuditUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
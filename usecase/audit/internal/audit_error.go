@@ -0,0 +1,8 @@
+package internal
+
+import "errors"
+
+var (
+	ErrInvalidCursor    = errors.New("audit event cursor is not valid")
+	ErrInvalidTimeRange = errors.New("audit event time range is not valid")
+)
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	auditEventDataSection     = "audit_events"
+	getAuditEventsByUserQuery = "SELECT event_type, ip_address, user_agent, outcome, created_at FROM audit_event WHERE user_id = ? ORDER BY id"
+)
+
+type AuditEventExport struct {
+	Type      string    `json:"type"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Outcome   string    `json:"outcome"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditEventDataExporter contributes the audit events about a user to the
+// personal data export. Admin identities and internal reasons are left out.
+type AuditEventDataExporter struct {
+	sql *sql.DB
+}
+
+func NewAuditEventDataExporter(sql *sql.DB) *AuditEventDataExporter {
+	return &AuditEventDataExporter{sql: sql}
+}
+
+func (e *AuditEventDataExporter) PersonalDataSection() string {
+	return auditEventDataSection
+}
+
+func (e *AuditEventDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	rows, err := e.sql.QueryContext(ctx, getAuditEventsByUserQuery, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []AuditEventExport{}
+	for rows.Next() {
+		export := AuditEventExport{}
+		var ipAddress, userAgent sql.NullString
+		if err := rows.Scan(&export.Type, &ipAddress, &userAgent, &export.Outcome, &export.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		export.IPAddress = ipAddress.String
+		export.UserAgent = userAgent.String
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
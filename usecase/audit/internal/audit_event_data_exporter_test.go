@@ -0,0 +1,84 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+)
+
+type AuditEventDataExporterSuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context  context.Context
+	user     entity.User
+	now      time.Time
+	exporter *internal.AuditEventDataExporter
+}
+
+func TestAuditEventDataExporterSuite(t *testing.T) {
+	suite.Run(t, &AuditEventDataExporterSuite{})
+}
+
+func (s *AuditEventDataExporterSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT event_type, ip_address, user_agent, outcome, created_at FROM audit_event WHERE user_id = ? ORDER BY id"
+	s.errMock = errors.New("mocked error")
+
+	s.exporter = internal.NewAuditEventDataExporter(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *AuditEventDataExporterSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *AuditEventDataExporterSuite) TestPersonalDataSection_ReturnAuditEvents() {
+	s.Assert().Equal("audit_events", s.exporter.PersonalDataSection())
+}
+
+func (s *AuditEventDataExporterSuite) TestExportPersonalData_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnError(s.errMock)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(data)
+}
+
+func (s *AuditEventDataExporterSuite) TestExportPersonalData_Success_ReturnEvents() {
+	rows := sqlmock.NewRows([]string{"event_type", "ip_address", "user_agent", "outcome", "created_at"}).
+		AddRow("auth.register", "192.0.2.1", "curl/7.85.0", "success", s.now).
+		AddRow("admin.user.disable", nil, nil, "success", s.now)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.AuditEventExport{
+		{Type: "auth.register", IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0", Outcome: "success", CreatedAt: s.now},
+		{Type: "admin.user.disable", Outcome: "success", CreatedAt: s.now},
+	}, data)
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	AuditEventListAuditEvents = "admin.audit.list"
+)
+
+// Actor is the admin querying the audit log, recorded in the audit log too.
+type Actor struct {
+	UserID    int64
+	IPAddress string
+	UserAgent string
+}
+
+// ListAuditEventsUsecaseInput filters events by user and by a time range
+// including From and excluding To. Zero values disable a filter.
+type ListAuditEventsUsecaseInput struct {
+	Actor  Actor
+	UserID int64
+	From   time.Time
+	To     time.Time
+	Cursor string
+	Limit  int
+}
+
+type ListAuditEventsUsecaseOutput struct {
+	Events     []entity.AuditEvent
+	NextCursor string
+}
+
+type AuditEventFilter struct {
+	UserID   int64
+	From     time.Time
+	To       time.Time
+	BeforeID int64
+	Limit    int
+}
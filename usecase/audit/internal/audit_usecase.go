@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	defaultListAuditEventsLimit = 50
+	maxListAuditEventsLimit     = 200
+)
+
+type AuditUsecase struct {
+	gateway AuditGateway
+}
+
+//go:generate mockery --name=AuditGateway --output=./mocks
+type AuditGateway interface {
+	ListAuditEvents(ctx context.Context, filter AuditEventFilter) ([]entity.AuditEvent, error)
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
+	NowInUTC() time.Time
+}
+
+func NewAuditUsecase(gateway AuditGateway) *AuditUsecase {
+	return &AuditUsecase{gateway: gateway}
+}
+
+// ListAuditEvents returns one page of events, newest first, and the cursor of
+// the next page. Reading the audit log is audited as well.
+func (u *AuditUsecase) ListAuditEvents(ctx context.Context, in ListAuditEventsUsecaseInput) (ListAuditEventsUsecaseOutput, error) {
+	out, err := u.listAuditEvents(ctx, in)
+
+	event := entity.AuditEvent{
+		Type:        AuditEventListAuditEvents,
+		ActorUserID: &in.Actor.UserID,
+		IPAddress:   in.Actor.IPAddress,
+		UserAgent:   in.Actor.UserAgent,
+		Outcome:     entity.AuditOutcomeSuccess,
+		Reason:      fmt.Sprintf("user_id=%d", in.UserID),
+		CreatedAt:   u.gateway.NowInUTC(),
+	}
+	if in.UserID != 0 {
+		event.UserID = &in.UserID
+	}
+	if err != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason += " " + err.Error()
+	}
+
+	auditErr := u.gateway.RecordAuditEvent(ctx, event)
+	if err != nil {
+		return ListAuditEventsUsecaseOutput{}, err
+	}
+
+	if auditErr != nil {
+		return ListAuditEventsUsecaseOutput{}, auditErr
+	}
+
+	return out, nil
+}
+
+func (u *AuditUsecase) listAuditEvents(ctx context.Context, in ListAuditEventsUsecaseInput) (ListAuditEventsUsecaseOutput, error) {
+	if !in.From.IsZero() && !in.To.IsZero() && !in.From.Before(in.To) {
+		return ListAuditEventsUsecaseOutput{}, ErrInvalidTimeRange
+	}
+
+	beforeID, err := decodeCursor(in.Cursor)
+	if err != nil {
+		return ListAuditEventsUsecaseOutput{}, err
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultListAuditEventsLimit
+	}
+	if limit > maxListAuditEventsLimit {
+		limit = maxListAuditEventsLimit
+	}
+
+	// One extra row tells whether there is a next page.
+	events, err := u.gateway.ListAuditEvents(ctx, AuditEventFilter{
+		UserID:   in.UserID,
+		From:     in.From,
+		To:       in.To,
+		BeforeID: beforeID,
+		Limit:    limit + 1,
+	})
+	if err != nil {
+		return ListAuditEventsUsecaseOutput{}, err
+	}
+
+	out := ListAuditEventsUsecaseOutput{Events: events}
+	if len(events) > limit {
+		out.Events = events[:limit]
+		out.NextCursor = encodeCursor(out.Events[limit-1].ID)
+	}
+
+	return out, nil
+}
+
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
@@ -0,0 +1,122 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+	"littlerollingsushi.com/example/usecase/audit/internal/mocks"
+)
+
+type AuditUsecaseSuite struct {
+	suite.Suite
+
+	gateway *mocks.AuditGateway
+	usecase *internal.AuditUsecase
+
+	context context.Context
+	actor   internal.Actor
+	now     time.Time
+	errMock error
+}
+
+func TestAuditUsecaseSuite(t *testing.T) {
+	suite.Run(t, &AuditUsecaseSuite{})
+}
+
+func (s *AuditUsecaseSuite) SetupTest() {
+	s.gateway = mocks.NewAuditGateway(s.T())
+	s.usecase = internal.NewAuditUsecase(s.gateway)
+
+	s.context = context.Background()
+	s.actor = internal.Actor{UserID: 1, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.errMock = errors.New("mock error")
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+}
+
+func (s *AuditUsecaseSuite) expectAuditEvent(userID *int64, outcome, reason string) {
+	s.gateway.On("RecordAuditEvent", s.context, entity.AuditEvent{
+		Type:        internal.AuditEventListAuditEvents,
+		ActorUserID: &s.actor.UserID,
+		UserID:      userID,
+		IPAddress:   s.actor.IPAddress,
+		UserAgent:   s.actor.UserAgent,
+		Outcome:     outcome,
+		Reason:      reason,
+		CreatedAt:   s.now,
+	}).Return(nil).Once()
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_FromNotBeforeTo_ReturnInvalidTimeRangeErr() {
+	s.expectAuditEvent(nil, entity.AuditOutcomeFailure, "user_id=0 "+internal.ErrInvalidTimeRange.Error())
+
+	_, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{Actor: s.actor, From: s.now, To: s.now})
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidTimeRange)
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_InvalidCursor_ReturnInvalidCursorErr() {
+	s.expectAuditEvent(nil, entity.AuditOutcomeFailure, "user_id=0 "+internal.ErrInvalidCursor.Error())
+
+	_, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{Actor: s.actor, Cursor: "MA"})
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidCursor)
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_GatewayError_ReturnError() {
+	s.gateway.On("ListAuditEvents", s.context, mock.Anything).Return(nil, s.errMock)
+	s.expectAuditEvent(nil, entity.AuditOutcomeFailure, "user_id=0 "+s.errMock.Error())
+
+	_, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{Actor: s.actor})
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_MoreRowsThanLimit_ReturnNextCursor() {
+	userID := int64(7)
+	from, to := s.now.Add(-time.Hour), s.now
+	s.gateway.On("ListAuditEvents", s.context, internal.AuditEventFilter{UserID: userID, From: from, To: to, BeforeID: 12, Limit: 3}).
+		Return([]entity.AuditEvent{{ID: 11}, {ID: 10}, {ID: 9}}, nil)
+	s.expectAuditEvent(&userID, entity.AuditOutcomeSuccess, "user_id=7")
+
+	out, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{
+		Actor:  s.actor,
+		UserID: userID,
+		From:   from,
+		To:     to,
+		Cursor: "MTI",
+		Limit:  2,
+	})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.AuditEvent{{ID: 11}, {ID: 10}}, out.Events)
+	a.Equal("MTA", out.NextCursor)
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_LimitAboveMax_UseMaxLimit() {
+	s.gateway.On("ListAuditEvents", s.context, internal.AuditEventFilter{Limit: 201}).Return([]entity.AuditEvent{}, nil)
+	s.expectAuditEvent(nil, entity.AuditOutcomeSuccess, "user_id=0")
+
+	out, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{Actor: s.actor, Limit: 1000})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Empty(out.NextCursor)
+}
+
+func (s *AuditUsecaseSuite) TestListAuditEvents_AuditError_ReturnError() {
+	s.gateway.On("ListAuditEvents", s.context, mock.Anything).Return([]entity.AuditEvent{}, nil)
+	s.gateway.On("RecordAuditEvent", s.context, mock.Anything).Return(s.errMock)
+
+	_, err := s.usecase.ListAuditEvents(s.context, internal.ListAuditEventsUsecaseInput{Actor: s.actor})
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	maxAuditTextLength    = 255
+	insertAuditEventQuery = "INSERT INTO audit_event (event_type, actor_user_id, user_id, ip_address, user_agent, outcome, reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+)
+
+type InsertAuditEventGateway struct {
+	sql *sql.DB
+}
+
+func NewInsertAuditEventGateway(sql *sql.DB) *InsertAuditEventGateway {
+	return &InsertAuditEventGateway{sql: sql}
+}
+
+func (g *InsertAuditEventGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	_, err := g.sql.ExecContext(
+		ctx,
+		insertAuditEventQuery,
+		event.Type,
+		event.ActorUserID,
+		event.UserID,
+		event.IPAddress,
+		truncate(event.UserAgent),
+		event.Outcome,
+		truncate(event.Reason),
+		event.CreatedAt,
+	)
+	return err
+}
+
+// truncate cuts s to the column length, counted in characters like MySQL does.
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxAuditTextLength {
+		return s
+	}
+
+	return string(runes[:maxAuditTextLength])
+}
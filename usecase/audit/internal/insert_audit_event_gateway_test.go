@@ -0,0 +1,95 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+)
+
+type InsertAuditEventGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	event   entity.AuditEvent
+	gateway *internal.InsertAuditEventGateway
+}
+
+func TestInsertAuditEventGatewaySuite(t *testing.T) {
+	suite.Run(t, &InsertAuditEventGatewaySuite{})
+}
+
+func (s *InsertAuditEventGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "INSERT INTO audit_event (event_type, actor_user_id, user_id, ip_address, user_agent, outcome, reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	s.errMock = errors.New("mocked error")
+
+	actorUserID, userID := int64(1), int64(7)
+	s.gateway = internal.NewInsertAuditEventGateway(s.db)
+	s.context = context.Background()
+	s.event = entity.AuditEvent{
+		Type:        "admin.user.disable",
+		ActorUserID: &actorUserID,
+		UserID:      &userID,
+		IPAddress:   "192.0.2.1",
+		UserAgent:   "curl/7.85.0",
+		Outcome:     entity.AuditOutcomeSuccess,
+		CreatedAt:   time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC),
+	}
+}
+
+func (s *InsertAuditEventGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *InsertAuditEventGatewaySuite) TestRecordAuditEvent_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	err := s.gateway.RecordAuditEvent(s.context, s.event)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *InsertAuditEventGatewaySuite) TestRecordAuditEvent_LongReason_TruncateReason() {
+	s.event.Reason = strings.Repeat("é", 300)
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(s.event.Type, s.event.ActorUserID, s.event.UserID, s.event.IPAddress, s.event.UserAgent, s.event.Outcome, strings.Repeat("é", 255), s.event.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.gateway.RecordAuditEvent(s.context, s.event)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *InsertAuditEventGatewaySuite) TestRecordAuditEvent_InsertSuccess_ReturnNil() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(s.event.Type, s.event.ActorUserID, s.event.UserID, s.event.IPAddress, s.event.UserAgent, s.event.Outcome, s.event.Reason, s.event.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.gateway.RecordAuditEvent(s.context, s.event)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
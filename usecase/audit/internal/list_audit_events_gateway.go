@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	listAuditEventsQuery = "SELECT id, event_type, actor_user_id, user_id, ip_address, user_agent, outcome, reason, created_at FROM audit_event"
+)
+
+type ListAuditEventsGateway struct {
+	sql *sql.DB
+}
+
+func NewListAuditEventsGateway(sql *sql.DB) *ListAuditEventsGateway {
+	return &ListAuditEventsGateway{sql: sql}
+}
+
+// ListAuditEvents returns the events matching filter, newest first.
+func (g *ListAuditEventsGateway) ListAuditEvents(ctx context.Context, filter AuditEventFilter) ([]entity.AuditEvent, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.UserID != 0 {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+	if filter.BeforeID != 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.BeforeID)
+	}
+
+	query := listAuditEventsQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := g.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []entity.AuditEvent{}
+	for rows.Next() {
+		event := entity.AuditEvent{}
+		var ipAddress, userAgent, reason sql.NullString
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.ActorUserID,
+			&event.UserID,
+			&ipAddress,
+			&userAgent,
+			&event.Outcome,
+			&reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		event.IPAddress = ipAddress.String
+		event.UserAgent = userAgent.String
+		event.Reason = reason.String
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,107 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/audit/internal"
+)
+
+type ListAuditEventsGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	columns       []string
+	errMock       error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.ListAuditEventsGateway
+}
+
+func TestListAuditEventsGatewaySuite(t *testing.T) {
+	suite.Run(t, &ListAuditEventsGatewaySuite{})
+}
+
+func (s *ListAuditEventsGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, event_type, actor_user_id, user_id, ip_address, user_agent, outcome, reason, created_at FROM audit_event"
+	s.columns = []string{"id", "event_type", "actor_user_id", "user_id", "ip_address", "user_agent", "outcome", "reason", "created_at"}
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewListAuditEventsGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *ListAuditEventsGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ListAuditEventsGatewaySuite) TestListAuditEvents_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	events, err := s.gateway.ListAuditEvents(s.context, internal.AuditEventFilter{Limit: 51})
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(events)
+}
+
+func (s *ListAuditEventsGatewaySuite) TestListAuditEvents_NoFilter_QueryWithoutConditions() {
+	s.mockDb.ExpectQuery("^" + regexp.QuoteMeta(s.expectedQuery+" ORDER BY id DESC LIMIT ?") + "$").
+		WithArgs(51).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+
+	events, err := s.gateway.ListAuditEvents(s.context, internal.AuditEventFilter{Limit: 51})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.AuditEvent{}, events)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ListAuditEventsGatewaySuite) TestListAuditEvents_AllFilters_ReturnEvents() {
+	from, to := s.now.Add(-time.Hour), s.now
+	userID := int64(7)
+	query := s.expectedQuery + " WHERE user_id = ? AND created_at >= ? AND created_at < ? AND id < ? ORDER BY id DESC LIMIT ?"
+	rows := sqlmock.NewRows(s.columns).
+		AddRow(9, "auth.login", nil, 7, "192.0.2.1", "curl/7.85.0", "failure", "login password is not valid", s.now).
+		AddRow(8, "auth.register", nil, 7, nil, nil, "success", nil, s.now)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(userID, from, to, int64(10), 51).
+		WillReturnRows(rows)
+
+	events, err := s.gateway.ListAuditEvents(s.context, internal.AuditEventFilter{UserID: userID, From: from, To: to, BeforeID: 10, Limit: 51})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.AuditEvent{
+		{
+			ID:        9,
+			Type:      "auth.login",
+			UserID:    &userID,
+			IPAddress: "192.0.2.1",
+			UserAgent: "curl/7.85.0",
+			Outcome:   entity.AuditOutcomeFailure,
+			Reason:    "login password is not valid",
+			CreatedAt: s.now,
+		},
+		{ID: 8, Type: "auth.register", UserID: &userID, Outcome: entity.AuditOutcomeSuccess, CreatedAt: s.now},
+	}, events)
+}
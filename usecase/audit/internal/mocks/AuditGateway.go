@@ -0,0 +1,85 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+	internal "littlerollingsushi.com/example/usecase/audit/internal"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AuditGateway is an autogenerated mock type for the AuditGateway type
+type AuditGateway struct {
+	mock.Mock
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, filter
+func (_m *AuditGateway) ListAuditEvents(ctx context.Context, filter internal.AuditEventFilter) ([]entity.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []entity.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, internal.AuditEventFilter) []entity.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *AuditGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, event
+func (_m *AuditGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAuditGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuditGateway creates a new instance of AuditGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuditGateway(t mockConstructorTestingTNewAuditGateway) *AuditGateway {
+	mock := &AuditGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,54 @@
+package constructor
+
+import (
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/usecase/challenge/handler"
+	"littlerollingsushi.com/example/usecase/challenge/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperConstructor "littlerollingsushi.com/example/usecase/helper/constructor"
+)
+
+var (
+	challengeUsecase     *internal.ChallengeUsecase
+	challengeUsecaseOnce sync.Once
+)
+
+func ConstructChallengeHandler(cfg *config.Config, redisClient *redis.Client) *handler.ChallengeHandler {
+	return handler.NewChallengeHandler(ConstructChallengeUsecase(cfg, redisClient), &helper.TimerImplementation{})
+}
+
+// ConstructChallengeUsecase returns the same usecase to every caller, so the
+// registration handler verifies challenges against the stores they were
+// counted in.
+func ConstructChallengeUsecase(cfg *config.Config, redisClient *redis.Client) *internal.ChallengeUsecase {
+	challengeUsecaseOnce.Do(func() {
+		challengeUsecase = internal.NewChallengeUsecase(
+			internal.ChallengeUsecaseConfig{
+				Enabled:        cfg.Challenge.Enabled,
+				Secret:         []byte(cfg.Challenge.Secret),
+				Expiration:     cfg.Challenge.Expiration,
+				Difficulty:     cfg.Challenge.Difficulty,
+				MaxDifficulty:  cfg.Challenge.MaxDifficulty,
+				SpikeThreshold: cfg.Challenge.SpikeThreshold,
+				SpikeWindow:    cfg.Challenge.SpikeWindow,
+			},
+			struct {
+				*helper.TokenGenerator
+				helper.CounterStore
+				helper.TTLSet
+				helper.Timer
+			}{
+				TokenGenerator: &helper.TokenGenerator{},
+				CounterStore:   helperConstructor.ConstructCounterStore(cfg, redisClient, "challenge"),
+				TTLSet:         helperConstructor.ConstructTTLSet(cfg, redisClient, "challenge"),
+				Timer:          &helper.TimerImplementation{},
+			},
+		)
+	})
+
+	return challengeUsecase
+}
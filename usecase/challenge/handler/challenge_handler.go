@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/challenge/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const timeFormat = "2006-01-02T15:04:05.999Z"
+
+type ChallengeHandler struct {
+	usecase ChallengeUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=ChallengeUsecase --output=./mocks
+type ChallengeUsecase interface {
+	IssueChallenge(context.Context) (internal.Challenge, error)
+}
+
+func NewChallengeHandler(usecase ChallengeUsecase, timer helper.Timer) *ChallengeHandler {
+	return &ChallengeHandler{usecase: usecase, timer: timer}
+}
+
+func (h *ChallengeHandler) IssueChallenge(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	challenge, err := h.usecase.IssueChallenge(r.Context())
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{
+		"challenge":  challenge.Token,
+		"difficulty": challenge.Difficulty,
+		"algorithm":  internal.ChallengeAlgorithm,
+		"expires_at": challenge.ExpiresAt.Format(timeFormat),
+	})
+}
+
+func (h *ChallengeHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	problem.WriteInternalError(w, r, h.timer, err)
+}
+
+func (h *ChallengeHandler) writeDataResponse(w http.ResponseWriter, status int, data map[string]interface{}) {
+	data["meta"] = map[string]interface{}{
+		"http_status": status,
+		"server_time": h.timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
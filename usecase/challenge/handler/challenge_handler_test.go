@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/challenge/handler"
+	"littlerollingsushi.com/example/usecase/challenge/handler/mocks"
+	"littlerollingsushi.com/example/usecase/challenge/internal"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type ChallengeHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.ChallengeUsecase
+	timer   *helperMocks.Timer
+	handler *handler.ChallengeHandler
+
+	expectedTimestamp time.Time
+	errMock           error
+}
+
+func TestChallengeHandlerSuite(t *testing.T) {
+	suite.Run(t, &ChallengeHandlerSuite{})
+}
+
+func (s *ChallengeHandlerSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/challenge", nil)
+	s.requestParams = map[string]string{}
+	s.responseWriter = httptest.NewRecorder()
+
+	s.usecase = mocks.NewChallengeUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewChallengeHandler(s.usecase, s.timer)
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *ChallengeHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *ChallengeHandlerSuite) TestIssueChallenge_UsecaseError_ReturnInternalServerError() {
+	s.usecase.On("IssueChallenge", s.request.Context()).Return(internal.Challenge{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.IssueChallenge(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *ChallengeHandlerSuite) TestIssueChallenge_UsecaseSuccess_ReturnChallenge() {
+	s.usecase.On("IssueChallenge", s.request.Context()).Return(internal.Challenge{
+		Token:      "v1.seed.20.1667088299.signature",
+		Difficulty: 20,
+		ExpiresAt:  time.Date(2022, 10, 30, 0, 4, 59, 0, time.UTC),
+	}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.IssueChallenge(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"challenge": "v1.seed.20.1667088299.signature",
+			"difficulty": 20,
+			"algorithm": "sha256",
+			"expires_at": "2022-10-30T00:04:59Z",
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
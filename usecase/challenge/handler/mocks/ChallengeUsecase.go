@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/challenge/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChallengeUsecase is an autogenerated mock type for the ChallengeUsecase type
+type ChallengeUsecase struct {
+	mock.Mock
+}
+
+// IssueChallenge provides a mock function with given fields: _a0
+func (_m *ChallengeUsecase) IssueChallenge(_a0 context.Context) (internal.Challenge, error) {
+	ret := _m.Called(_a0)
+
+	var r0 internal.Challenge
+	if rf, ok := ret.Get(0).(func(context.Context) internal.Challenge); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(internal.Challenge)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewChallengeUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChallengeUsecase creates a new instance of ChallengeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChallengeUsecase(t mockConstructorTestingTNewChallengeUsecase) *ChallengeUsecase {
+	mock := &ChallengeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,7 @@
+package internal
+
+import "errors"
+
+var (
+	ErrInvalidChallenge = errors.New("challenge is not valid")
+)
@@ -0,0 +1,15 @@
+package internal
+
+import "time"
+
+const (
+	ChallengeAlgorithm = "sha256"
+)
+
+// Challenge is solved by finding a nonce for which the SHA-256 hash of
+// Token + ":" + nonce starts with Difficulty zero bits.
+type Challenge struct {
+	Token      string
+	Difficulty int
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	challengeVersion = "v1"
+	registrationsKey = "registrations"
+	maxNonceLength   = 64
+)
+
+type ChallengeUsecase struct {
+	config  ChallengeUsecaseConfig
+	gateway ChallengeGateway
+}
+
+type ChallengeUsecaseConfig struct {
+	// Enabled makes VerifyChallenge accept anything when false, challenges are
+	// still issued so clients can solve them before the scheme is enforced.
+	Enabled       bool
+	Secret        []byte
+	Expiration    time.Duration
+	Difficulty    int
+	MaxDifficulty int
+
+	// Every time the registrations within SpikeWindow double past
+	// SpikeThreshold, the difficulty is raised by one bit.
+	SpikeThreshold int64
+	SpikeWindow    time.Duration
+}
+
+//go:generate mockery --name=ChallengeGateway --output=./mocks
+type ChallengeGateway interface {
+	GenerateToken() (string, error)
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	Count(ctx context.Context, key string) (int64, error)
+	Add(ctx context.Context, member string, ttl time.Duration) (bool, error)
+	NowInUTC() time.Time
+}
+
+func NewChallengeUsecase(config ChallengeUsecaseConfig, gateway ChallengeGateway) *ChallengeUsecase {
+	return &ChallengeUsecase{config: config, gateway: gateway}
+}
+
+// IssueChallenge returns a challenge signed with the configured secret, so
+// nothing has to be stored until it is solved.
+func (u *ChallengeUsecase) IssueChallenge(ctx context.Context) (Challenge, error) {
+	difficulty, err := u.difficulty(ctx)
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	seed, err := u.gateway.GenerateToken()
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	expiresAt := u.gateway.NowInUTC().Add(u.config.Expiration)
+	payload := strings.Join([]string{
+		challengeVersion,
+		seed,
+		strconv.Itoa(difficulty),
+		strconv.FormatInt(expiresAt.Unix(), 10),
+	}, ".")
+
+	return Challenge{
+		Token:      payload + "." + u.sign(payload),
+		Difficulty: difficulty,
+		ExpiresAt:  time.Unix(expiresAt.Unix(), 0).UTC(),
+	}, nil
+}
+
+// VerifyChallenge accepts each solved challenge once. Accepted challenges
+// count as registrations for raising the difficulty.
+func (u *ChallengeUsecase) VerifyChallenge(ctx context.Context, token, nonce string) (bool, error) {
+	if !u.config.Enabled {
+		return true, nil
+	}
+
+	difficulty, expiresAt, err := u.parse(token)
+	if err != nil {
+		return false, nil
+	}
+
+	now := u.gateway.NowInUTC()
+	if !now.Before(expiresAt) {
+		return false, nil
+	}
+
+	if nonce == "" || len(nonce) > maxNonceLength || leadingZeroBits(token, nonce) < difficulty {
+		return false, nil
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	added, err := u.gateway.Add(ctx, hex.EncodeToString(sum[:]), expiresAt.Sub(now))
+	if err != nil || !added {
+		return false, err
+	}
+
+	if _, err := u.gateway.Increment(ctx, registrationsKey, u.config.SpikeWindow); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (u *ChallengeUsecase) difficulty(ctx context.Context) (int, error) {
+	difficulty := u.config.Difficulty
+	if u.config.SpikeThreshold <= 0 {
+		return difficulty, nil
+	}
+
+	registrations, err := u.gateway.Count(ctx, registrationsKey)
+	if err != nil {
+		return 0, err
+	}
+
+	for threshold := u.config.SpikeThreshold; registrations >= threshold && difficulty < u.config.MaxDifficulty; threshold *= 2 {
+		difficulty++
+	}
+
+	return difficulty, nil
+}
+
+func (u *ChallengeUsecase) parse(token string) (int, time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || parts[0] != challengeVersion {
+		return 0, time.Time{}, ErrInvalidChallenge
+	}
+
+	payload := strings.Join(parts[:4], ".")
+	if !hmac.Equal([]byte(u.sign(payload)), []byte(parts[4])) {
+		return 0, time.Time{}, ErrInvalidChallenge
+	}
+
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidChallenge
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidChallenge
+	}
+
+	return difficulty, time.Unix(expiresAt, 0).UTC(), nil
+}
+
+func (u *ChallengeUsecase) sign(payload string) string {
+	mac := hmac.New(sha256.New, u.config.Secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func leadingZeroBits(token, nonce string) int {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s", token, nonce)))
+
+	zeros := 0
+	for _, b := range sum {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+		zeros += 8
+	}
+
+	return zeros
+}
@@ -0,0 +1,227 @@
+package internal_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/challenge/internal"
+	"littlerollingsushi.com/example/usecase/challenge/internal/mocks"
+)
+
+type ChallengeUsecaseSuite struct {
+	suite.Suite
+
+	config    internal.ChallengeUsecaseConfig
+	gateway   *mocks.ChallengeGateway
+	usecase   *internal.ChallengeUsecase
+	countCall *mock.Call
+	addCall   *mock.Call
+
+	context context.Context
+	now     time.Time
+	errMock error
+}
+
+func TestChallengeUsecaseSuite(t *testing.T) {
+	suite.Run(t, &ChallengeUsecaseSuite{})
+}
+
+func (s *ChallengeUsecaseSuite) SetupTest() {
+	s.config = internal.ChallengeUsecaseConfig{
+		Enabled:        true,
+		Secret:         []byte("secret"),
+		Expiration:     5 * time.Minute,
+		Difficulty:     4,
+		MaxDifficulty:  6,
+		SpikeThreshold: 30,
+		SpikeWindow:    time.Minute,
+	}
+	s.gateway = mocks.NewChallengeGateway(s.T())
+	s.usecase = internal.NewChallengeUsecase(s.config, s.gateway)
+
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.errMock = errors.New("mock error")
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+	s.gateway.On("GenerateToken").Return("seed", nil).Maybe()
+	s.countCall = s.gateway.On("Count", s.context, "registrations").Return(int64(0), nil).Maybe()
+	s.addCall = s.gateway.On("Add", s.context, mock.Anything, mock.Anything).Return(true, nil).Maybe()
+	s.gateway.On("Increment", s.context, "registrations", s.config.SpikeWindow).Return(int64(1), nil).Maybe()
+}
+
+func (s *ChallengeUsecaseSuite) issue() internal.Challenge {
+	challenge, err := s.usecase.IssueChallenge(s.context)
+	s.Require().NoError(err)
+	return challenge
+}
+
+func (s *ChallengeUsecaseSuite) solve(challenge internal.Challenge) string {
+	for nonce := 0; ; nonce++ {
+		sum := sha256.Sum256([]byte(challenge.Token + ":" + strconv.Itoa(nonce)))
+		if leadingZeroBits(sum[:]) >= challenge.Difficulty {
+			return strconv.Itoa(nonce)
+		}
+	}
+}
+
+func (s *ChallengeUsecaseSuite) wrongNonce(challenge internal.Challenge) string {
+	for nonce := 0; ; nonce++ {
+		sum := sha256.Sum256([]byte(challenge.Token + ":" + strconv.Itoa(nonce)))
+		if leadingZeroBits(sum[:]) < challenge.Difficulty {
+			return strconv.Itoa(nonce)
+		}
+	}
+}
+
+func leadingZeroBits(sum []byte) int {
+	zeros := 0
+	for _, b := range sum {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+		zeros += 8
+	}
+	return zeros
+}
+
+func (s *ChallengeUsecaseSuite) TestIssueChallenge_NoRegistrations_ReturnBaseDifficulty() {
+	challenge := s.issue()
+
+	a := s.Assert()
+	a.Equal(4, challenge.Difficulty)
+	a.Equal(s.now.Add(5*time.Minute), challenge.ExpiresAt)
+	a.True(strings.HasPrefix(challenge.Token, "v1.seed.4.1667088299."))
+}
+
+func (s *ChallengeUsecaseSuite) TestIssueChallenge_RegistrationSpike_RaiseDifficulty() {
+	s.countCall.Return(int64(60), nil)
+
+	s.Assert().Equal(6, s.issue().Difficulty)
+}
+
+func (s *ChallengeUsecaseSuite) TestIssueChallenge_LargeRegistrationSpike_CapAtMaxDifficulty() {
+	s.countCall.Return(int64(1000000), nil)
+
+	s.Assert().Equal(6, s.issue().Difficulty)
+}
+
+func (s *ChallengeUsecaseSuite) TestIssueChallenge_CountError_ReturnOriginalError() {
+	s.countCall.Return(int64(0), s.errMock)
+
+	_, err := s.usecase.IssueChallenge(s.context)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_Disabled_ReturnTrue() {
+	s.config.Enabled = false
+	s.usecase = internal.NewChallengeUsecase(s.config, s.gateway)
+
+	valid, err := s.usecase.VerifyChallenge(s.context, "", "")
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(valid)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_Solved_ReturnTrue() {
+	challenge := s.issue()
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	sum := sha256.Sum256([]byte(challenge.Token))
+	a := s.Assert()
+	a.Nil(err)
+	a.True(valid)
+	s.gateway.AssertCalled(s.T(), "Add", s.context, hex.EncodeToString(sum[:]), 5*time.Minute)
+	s.gateway.AssertCalled(s.T(), "Increment", s.context, "registrations", time.Minute)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_WrongNonce_ReturnFalse() {
+	challenge := s.issue()
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.wrongNonce(challenge))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+	s.gateway.AssertNotCalled(s.T(), "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_TamperedDifficulty_ReturnFalse() {
+	challenge := s.issue()
+	challenge.Token = strings.Replace(challenge.Token, ".4.", ".0.", 1)
+	challenge.Difficulty = 0
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_OtherSecret_ReturnFalse() {
+	challenge := s.issue()
+	s.config.Secret = []byte("other")
+	s.usecase = internal.NewChallengeUsecase(s.config, s.gateway)
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_Malformed_ReturnFalse() {
+	valid, err := s.usecase.VerifyChallenge(s.context, "v1.seed.4", "1")
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_Expired_ReturnFalse() {
+	challenge := s.issue()
+	gateway := mocks.NewChallengeGateway(s.T())
+	gateway.On("NowInUTC").Return(s.now.Add(5 * time.Minute))
+	usecase := internal.NewChallengeUsecase(s.config, gateway)
+
+	valid, err := usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_AlreadyUsed_ReturnFalse() {
+	challenge := s.issue()
+	s.addCall.Return(false, nil)
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(valid)
+	s.gateway.AssertNotCalled(s.T(), "Increment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ChallengeUsecaseSuite) TestVerifyChallenge_AddError_ReturnOriginalError() {
+	challenge := s.issue()
+	s.addCall.Return(false, s.errMock)
+
+	valid, err := s.usecase.VerifyChallenge(s.context, challenge.Token, s.solve(challenge))
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.False(valid)
+}
@@ -0,0 +1,21 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/usecase/dataexport/handler"
+	"littlerollingsushi.com/example/usecase/dataexport/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// ConstructDataExportHandler builds the export handler. The user row is always
+// exported; every other usecase package contributes its data through the
+// given exporters.
+func ConstructDataExportHandler(db *sql.DB, exporters ...helper.PersonalDataExporter) *handler.DataExportHandler {
+	usecase := internal.NewDataExportUsecase(
+		&helper.TimerImplementation{},
+		append([]helper.PersonalDataExporter{internal.NewUserDataExporter(db)}, exporters...)...,
+	)
+	timer := &helper.TimerImplementation{}
+	return handler.NewDataExportHandler(usecase, timer)
+}
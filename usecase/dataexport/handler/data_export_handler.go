@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/dataexport/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const (
+	formatJSON         = "json"
+	formatZip          = "zip"
+	exportJSONFileName = "personal-data.json"
+	exportZipFileName  = "personal-data.zip"
+)
+
+type DataExportHandler struct {
+	usecase DataExportUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=DataExportUsecase --output=./mocks
+type DataExportUsecase interface {
+	ExportPersonalData(context.Context, internal.ExportPersonalDataUsecaseInput) (internal.ExportPersonalDataUsecaseOutput, error)
+}
+
+func NewDataExportHandler(usecase DataExportUsecase, timer helper.Timer) *DataExportHandler {
+	return &DataExportHandler{usecase: usecase, timer: timer}
+}
+
+// ExportPersonalData must be registered behind the authentication middleware,
+// the account is taken from the user id of the access token. The export is returned
+// as JSON, or as a zip archive holding the JSON document with format=zip.
+func (h *DataExportHandler) ExportPersonalData(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok {
+		problem.Write(w, h.timer, problem.InvalidCredentials, "Invalid credentials.")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatJSON
+	}
+	if format != formatJSON && format != formatZip {
+		problem.Write(w, h.timer, problem.InvalidParameter, "Unsupported export format. Use json or zip.")
+		return
+	}
+
+	out, err := h.usecase.ExportPersonalData(r.Context(), internal.ExportPersonalDataUsecaseInput{UserID: claims.UserID})
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	if format == formatZip {
+		h.writeZipResponse(w, r, out)
+		return
+	}
+
+	h.writeJSONResponse(w, out)
+}
+
+func (h *DataExportHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, internal.ErrUserNotFound):
+		problem.Write(w, h.timer, problem.InvalidCredentials, "Invalid credentials.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *DataExportHandler) buildDocument(out internal.ExportPersonalDataUsecaseOutput) map[string]interface{} {
+	return map[string]interface{}{
+		"exported_at": out.ExportedAt.Format("2006-01-02T15:04:05.999Z"),
+		"data":        out.Sections,
+	}
+}
+
+func (h *DataExportHandler) writeJSONResponse(w http.ResponseWriter, out internal.ExportPersonalDataUsecaseOutput) {
+	data := h.buildDocument(out)
+	data["meta"] = map[string]interface{}{
+		"http_status": http.StatusOK,
+		"server_time": h.timer.NowInUTC().Format("2006-01-02T15:04:05.999Z"),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportJSONFileName+`"`)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeZipResponse builds the archive in memory first so an encoding failure
+// can still be reported with a proper status code.
+func (h *DataExportHandler) writeZipResponse(w http.ResponseWriter, r *http.Request, out internal.ExportPersonalDataUsecaseOutput) {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	file, err := archive.Create(exportJSONFileName)
+	if err == nil {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(h.buildDocument(out))
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportZipFileName+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,177 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/dataexport/handler"
+	"littlerollingsushi.com/example/usecase/dataexport/handler/mocks"
+	"littlerollingsushi.com/example/usecase/dataexport/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type DataExportHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.DataExportUsecase
+	timer   *helperMocks.Timer
+	handler *handler.DataExportHandler
+
+	expectedUsecaseInput        internal.ExportPersonalDataUsecaseInput
+	expectedUsecaseOutput       internal.ExportPersonalDataUsecaseOutput
+	expectedTimestamp           time.Time
+	expectedSuccessResponseBody string
+	expectedArchivedDocument    string
+	errMock                     error
+}
+
+func TestDataExportHandlerSuite(t *testing.T) {
+	suite.Run(t, &DataExportHandlerSuite{})
+}
+
+func (s *DataExportHandlerSuite) SetupTest() {
+	s.request = s.newRequest("http://test.com/v1/me/export")
+	s.responseWriter = httptest.NewRecorder()
+	s.requestParams = map[string]string{}
+
+	s.usecase = mocks.NewDataExportUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewDataExportHandler(s.usecase, s.timer)
+
+	s.expectedUsecaseInput = internal.ExportPersonalDataUsecaseInput{UserID: 7}
+	s.expectedUsecaseOutput = internal.ExportPersonalDataUsecaseOutput{
+		ExportedAt: time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC),
+		Sections: map[string]interface{}{
+			"user": map[string]interface{}{"email": "john.doe@email.com"},
+		},
+	}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.expectedArchivedDocument = `
+		{
+			"exported_at": "2022-10-29T23:59:59Z",
+			"data": {
+				"user": {"email": "john.doe@email.com"}
+			}
+		}
+	`
+	s.expectedSuccessResponseBody = `
+		{
+			"exported_at": "2022-10-29T23:59:59Z",
+			"data": {
+				"user": {"email": "john.doe@email.com"}
+			},
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+	s.errMock = errors.New("mock error")
+}
+
+func (s *DataExportHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *DataExportHandlerSuite) newRequest(target string) *http.Request {
+	r := httptest.NewRequest("GET", target, nil)
+	return r.WithContext(helper.ContextWithAccessTokenClaims(r.Context(), &helper.AccessTokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "john.doe@email.com"}, UserID: 7}))
+}
+
+func (s *DataExportHandlerSuite) TestExportPersonalData_UnsupportedFormat_ReturnBadRequest() {
+	s.request = s.newRequest("http://test.com/v1/me/export?format=xml")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ExportPersonalData(s.responseWriter, s.request, s.requestParams)
+
+	s.Assert().Equal(http.StatusBadRequest, s.responseWriter.Result().StatusCode)
+}
+
+func (s *DataExportHandlerSuite) TestExportPersonalData_UsecaseUnknownError_ReturnInternalServerError() {
+	s.usecase.On("ExportPersonalData", s.request.Context(), s.expectedUsecaseInput).Return(internal.ExportPersonalDataUsecaseOutput{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ExportPersonalData(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *DataExportHandlerSuite) TestExportPersonalData_UserNotFound_ReturnUnauthorized() {
+	err := fmt.Errorf("export user: %w", internal.ErrUserNotFound)
+	s.usecase.On("ExportPersonalData", s.request.Context(), s.expectedUsecaseInput).Return(internal.ExportPersonalDataUsecaseOutput{}, err)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ExportPersonalData(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidCredentials, "Invalid credentials."), string(body))
+}
+
+func (s *DataExportHandlerSuite) TestExportPersonalData_JSONFormat_ReturnDocument() {
+	s.usecase.On("ExportPersonalData", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ExportPersonalData(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal("application/json", resp.Header.Get("Content-Type"))
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *DataExportHandlerSuite) TestExportPersonalData_ZipFormat_ReturnArchivedDocument() {
+	s.request = s.newRequest("http://test.com/v1/me/export?format=zip")
+	s.usecase.On("ExportPersonalData", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+
+	s.handler.ExportPersonalData(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal("application/zip", resp.Header.Get("Content-Type"))
+	a.Nil(err)
+	a.Len(archive.File, 1)
+	file, _ := archive.File[0].Open()
+	document, _ := io.ReadAll(file)
+	a.Equal("personal-data.json", archive.File[0].Name)
+	a.JSONEq(s.expectedArchivedDocument, string(document))
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/dataexport/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataExportUsecase is an autogenerated mock type for the DataExportUsecase type
+type DataExportUsecase struct {
+	mock.Mock
+}
+
+// ExportPersonalData provides a mock function with given fields: _a0, _a1
+func (_m *DataExportUsecase) ExportPersonalData(_a0 context.Context, _a1 internal.ExportPersonalDataUsecaseInput) (internal.ExportPersonalDataUsecaseOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.ExportPersonalDataUsecaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, internal.ExportPersonalDataUsecaseInput) internal.ExportPersonalDataUsecaseOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.ExportPersonalDataUsecaseOutput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.ExportPersonalDataUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewDataExportUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewDataExportUsecase creates a new instance of DataExportUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewDataExportUsecase(t mockConstructorTestingTNewDataExportUsecase) *DataExportUsecase {
+	mock := &DataExportUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,8 @@
+package internal
+
+import "errors"
+
+var (
+	ErrUserNotFound     = errors.New("user with given id is not found")
+	ErrDuplicateSection = errors.New("personal data section is exported more than once")
+)
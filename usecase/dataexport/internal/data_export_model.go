@@ -0,0 +1,22 @@
+package internal
+
+import "time"
+
+type ExportPersonalDataUsecaseInput struct {
+	UserID int64
+}
+
+type ExportPersonalDataUsecaseOutput struct {
+	ExportedAt time.Time
+	Sections   map[string]interface{}
+}
+
+type UserExport struct {
+	ID        int64      `json:"id"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type DataExportUsecase struct {
+	gateway   DataExportGateway
+	exporters []helper.PersonalDataExporter
+}
+
+//go:generate mockery --name=DataExportGateway --output=./mocks
+type DataExportGateway interface {
+	NowInUTC() time.Time
+}
+
+func NewDataExportUsecase(gateway DataExportGateway, exporters ...helper.PersonalDataExporter) *DataExportUsecase {
+	return &DataExportUsecase{gateway: gateway, exporters: exporters}
+}
+
+func (u *DataExportUsecase) ExportPersonalData(ctx context.Context, in ExportPersonalDataUsecaseInput) (ExportPersonalDataUsecaseOutput, error) {
+	// The user row is exported first, by the user exporter, which fails with
+	// ErrUserNotFound if the user is gone.
+	user := entity.User{ID: in.UserID}
+	sections := make(map[string]interface{}, len(u.exporters))
+	for _, exporter := range u.exporters {
+		name := exporter.PersonalDataSection()
+		if _, ok := sections[name]; ok {
+			return ExportPersonalDataUsecaseOutput{}, fmt.Errorf("%w: %s", ErrDuplicateSection, name)
+		}
+
+		data, err := exporter.ExportPersonalData(ctx, user)
+		if err != nil {
+			return ExportPersonalDataUsecaseOutput{}, fmt.Errorf("export %s: %w", name, err)
+		}
+
+		sections[name] = data
+	}
+
+	return ExportPersonalDataUsecaseOutput{
+		ExportedAt: u.gateway.NowInUTC(),
+		Sections:   sections,
+	}, nil
+}
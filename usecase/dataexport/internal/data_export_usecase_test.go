@@ -0,0 +1,87 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/dataexport/internal"
+	"littlerollingsushi.com/example/usecase/dataexport/internal/mocks"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type DataExportUsecaseSuite struct {
+	suite.Suite
+
+	gateway       *mocks.DataExportGateway
+	userExporter  *helperMocks.PersonalDataExporter
+	otherExporter *helperMocks.PersonalDataExporter
+	usecase       *internal.DataExportUsecase
+
+	context context.Context
+	input   internal.ExportPersonalDataUsecaseInput
+	user    entity.User
+	now     time.Time
+	errMock error
+}
+
+func TestDataExportUsecaseSuite(t *testing.T) {
+	suite.Run(t, &DataExportUsecaseSuite{})
+}
+
+func (s *DataExportUsecaseSuite) SetupTest() {
+	s.gateway = mocks.NewDataExportGateway(s.T())
+	s.userExporter = helperMocks.NewPersonalDataExporter(s.T())
+	s.otherExporter = helperMocks.NewPersonalDataExporter(s.T())
+	s.usecase = internal.NewDataExportUsecase(s.gateway, s.userExporter, s.otherExporter)
+
+	s.context = context.Background()
+	s.input = internal.ExportPersonalDataUsecaseInput{UserID: 7}
+	s.user = entity.User{ID: 7}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *DataExportUsecaseSuite) TestExportPersonalData_ExporterError_ReturnOriginalError() {
+	s.userExporter.On("PersonalDataSection").Return("user")
+	s.userExporter.On("ExportPersonalData", s.context, s.user).Return(nil, s.errMock)
+
+	output, err := s.usecase.ExportPersonalData(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *DataExportUsecaseSuite) TestExportPersonalData_DuplicateSection_ReturnError() {
+	s.userExporter.On("PersonalDataSection").Return("user")
+	s.userExporter.On("ExportPersonalData", s.context, s.user).Return("user data", nil)
+	s.otherExporter.On("PersonalDataSection").Return("user")
+
+	output, err := s.usecase.ExportPersonalData(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrDuplicateSection)
+}
+
+func (s *DataExportUsecaseSuite) TestExportPersonalData_Success_ReturnEverySection() {
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.userExporter.On("PersonalDataSection").Return("user")
+	s.userExporter.On("ExportPersonalData", s.context, s.user).Return("user data", nil)
+	s.otherExporter.On("PersonalDataSection").Return("other")
+	s.otherExporter.On("ExportPersonalData", s.context, s.user).Return([]string{"other data"}, nil)
+
+	output, err := s.usecase.ExportPersonalData(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.now, output.ExportedAt)
+	a.Equal(map[string]interface{}{
+		"user":  "user data",
+		"other": []string{"other data"},
+	}, output.Sections)
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	userDataSection    = "user"
+	getUserExportQuery = "SELECT id, first_name, last_name, email, created_at, updated_at, deleted_at FROM user WHERE id = ?"
+)
+
+// UserDataExporter exports the user row itself, without the crypted password.
+type UserDataExporter struct {
+	sql *sql.DB
+}
+
+func NewUserDataExporter(sql *sql.DB) *UserDataExporter {
+	return &UserDataExporter{sql: sql}
+}
+
+func (e *UserDataExporter) PersonalDataSection() string {
+	return userDataSection
+}
+
+func (e *UserDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	export := UserExport{}
+	err := e.sql.QueryRowContext(ctx, getUserExportQuery, user.ID).
+		Scan(&export.ID, &export.FirstName, &export.LastName, &export.Email, &export.CreatedAt, &export.UpdatedAt, &export.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return export, nil
+}
@@ -0,0 +1,98 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/dataexport/internal"
+)
+
+type UserDataExporterSuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context  context.Context
+	user     entity.User
+	export   internal.UserExport
+	exporter *internal.UserDataExporter
+}
+
+func TestUserDataExporterSuite(t *testing.T) {
+	suite.Run(t, &UserDataExporterSuite{})
+}
+
+func (s *UserDataExporterSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, first_name, last_name, email, created_at, updated_at, deleted_at FROM user WHERE id = ?"
+	s.errMock = errors.New("mocked error")
+
+	updatedAt := time.Date(2022, 10, 30, 23, 59, 59, 0, time.UTC)
+	s.exporter = internal.NewUserDataExporter(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7}
+	s.export = internal.UserExport{
+		ID:        7,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john.doe@email.com",
+		CreatedAt: time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC),
+		UpdatedAt: &updatedAt,
+	}
+}
+
+func (s *UserDataExporterSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *UserDataExporterSuite) TestPersonalDataSection_ReturnUser() {
+	s.Assert().Equal("user", s.exporter.PersonalDataSection())
+}
+
+func (s *UserDataExporterSuite) TestExportPersonalData_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(export)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *UserDataExporterSuite) TestExportPersonalData_NoRows_ReturnUserNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(sql.ErrNoRows)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(export)
+	a.ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *UserDataExporterSuite) TestExportPersonalData_UserFound_ReturnExport() {
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "created_at", "updated_at", "deleted_at"})
+	rows.AddRow(s.export.ID, s.export.FirstName, s.export.LastName, s.export.Email, s.export.CreatedAt, *s.export.UpdatedAt, nil)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.export, export)
+}
@@ -0,0 +1,45 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	"littlerollingsushi.com/example/usecase/emailchange/handler"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+func ConstructEmailChangeHandler(cfg *config.Config, db *sql.DB) *handler.EmailChangeHandler {
+	usecase := internal.NewEmailChangeUsecase(
+		internal.EmailChangeUsecaseConfig{
+			ConfirmationURL: cfg.EmailChange.ConfirmationURL,
+			TokenExpiration: cfg.EmailChange.TokenExpiration,
+		},
+		struct {
+			*internal.GetUserByIDGateway
+			*internal.InsertEmailChangeRequestGateway
+			*internal.GetEmailChangeRequestGateway
+			*internal.ChangeUserEmailGateway
+			*helper.PasswordEncrypter
+			*helper.TokenGenerator
+			*helper.SMTPMailer
+			helper.Timer
+		}{
+			GetUserByIDGateway:              internal.NewGetUserByIDGateway(db),
+			InsertEmailChangeRequestGateway: internal.NewInsertEmailChangeRequestGateway(db),
+			GetEmailChangeRequestGateway:    internal.NewGetEmailChangeRequestGateway(db),
+			ChangeUserEmailGateway:          internal.NewChangeUserEmailGateway(db),
+			PasswordEncrypter:               &helper.PasswordEncrypter{Observer: metricsConstructor.ConstructMetrics()},
+			TokenGenerator:                  &helper.TokenGenerator{},
+			SMTPMailer:                      helper.NewSMTPMailer(cfg.SMTP),
+			Timer:                           &helper.TimerImplementation{},
+		},
+	)
+	timer := &helper.TimerImplementation{}
+	return handler.NewEmailChangeHandler(usecase, timer)
+}
+
+func ConstructPersonalDataExporter(db *sql.DB) helper.PersonalDataExporter {
+	return internal.NewEmailChangeDataExporter(db)
+}
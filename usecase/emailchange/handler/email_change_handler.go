@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// confirmationPage asks the owner of the new address to submit the token, as
+// mail scanners and link prefetchers open the emailed link on their own.
+var confirmationPage = template.Must(template.New("confirmation").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Confirm your new email address</title>
+</head>
+<body>
+<h1>Confirm your new email address</h1>
+<form method="post">
+<input type="hidden" name="token" value="{{.}}">
+<button type="submit">Confirm email change</button>
+</form>
+</body>
+</html>
+`))
+
+type EmailChangeHandler struct {
+	usecase EmailChangeUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=EmailChangeUsecase --output=./mocks
+type EmailChangeUsecase interface {
+	RequestEmailChange(context.Context, internal.RequestEmailChangeUsecaseInput) error
+	ConfirmEmailChange(context.Context, internal.ConfirmEmailChangeUsecaseInput) error
+}
+
+type requestEmailChangeRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type confirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+func NewEmailChangeHandler(usecase EmailChangeUsecase, timer helper.Timer) *EmailChangeHandler {
+	return &EmailChangeHandler{usecase: usecase, timer: timer}
+}
+
+// RequestEmailChange must be registered behind the authentication middleware,
+// the user is taken from the access token. The token subject is not used as it
+// names the email the token was issued for, which may since be another user's.
+func (h *EmailChangeHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok {
+		problem.Write(w, h.timer, problem.InvalidCredentials, "Invalid credentials.")
+		return
+	}
+
+	req := requestEmailChangeRequest{}
+	if err := helper.DecodeRequestBody(w, r, helper.MaxRequestBodyBytes, &req); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
+	in := internal.RequestEmailChangeUsecaseInput{
+		UserID:   claims.UserID,
+		NewEmail: req.Email,
+		Password: req.Password,
+	}
+
+	if err := h.usecase.RequestEmailChange(r.Context(), in); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusAccepted, "Confirmation sent. Check the inbox of the new email.")
+}
+
+// ShowEmailChangeConfirmation serves the page the confirmation link opens. It
+// changes nothing, the change is applied once the page posts the token to
+// ConfirmEmailChange.
+func (h *EmailChangeHandler) ShowEmailChangeConfirmation(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		problem.WriteError(w, r, h.timer, internal.ErrEmptyToken)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	confirmationPage.Execute(w, token)
+}
+
+func (h *EmailChangeHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	req := confirmEmailChangeRequest{}
+	if err := helper.DecodeRequestBody(w, r, helper.MaxRequestBodyBytes, &req); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
+	in := internal.ConfirmEmailChangeUsecaseInput{
+		Token: req.Token,
+	}
+
+	if err := h.usecase.ConfirmEmailChange(r.Context(), in); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "Email changed. Continue to login with the new email.")
+}
+
+func (h *EmailChangeHandler) writeMessageResponse(w http.ResponseWriter, status int, message string) {
+	data := map[string]interface{}{
+		"message": message,
+		"meta": map[string]interface{}{
+			"http_status": status,
+			"server_time": h.timer.NowInUTC().Format("2006-01-02T15:04:05.999Z"),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
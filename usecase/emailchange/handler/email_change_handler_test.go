@@ -0,0 +1,266 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/emailchange/handler"
+	"littlerollingsushi.com/example/usecase/emailchange/handler/mocks"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type EmailChangeHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	confirmRequest *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.EmailChangeUsecase
+	timer   *helperMocks.Timer
+	handler *handler.EmailChangeHandler
+
+	expectedRequestInput internal.RequestEmailChangeUsecaseInput
+	expectedConfirmInput internal.ConfirmEmailChangeUsecaseInput
+	expectedTimestamp    time.Time
+	errMock              error
+}
+
+func TestEmailChangeHandlerSuite(t *testing.T) {
+	suite.Run(t, &EmailChangeHandlerSuite{})
+}
+
+func (s *EmailChangeHandlerSuite) SetupTest() {
+	form := url.Values{}
+	form.Add("email", "john.new@email.com")
+	form.Add("password", "verysecure")
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/me/email", strings.NewReader(form.Encode()))
+	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request = s.request.WithContext(helper.ContextWithAccessTokenClaims(
+		s.request.Context(),
+		&helper.AccessTokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "john.doe@email.com"}, UserID: 7},
+	))
+	s.confirmRequest = httptest.NewRequest("POST", "http://test.com/v1/email/confirm", strings.NewReader("token=random-token"))
+	s.confirmRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	s.responseWriter = httptest.NewRecorder()
+
+	s.requestParams = map[string]string{}
+
+	s.usecase = mocks.NewEmailChangeUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewEmailChangeHandler(s.usecase, s.timer)
+
+	s.expectedRequestInput = internal.RequestEmailChangeUsecaseInput{
+		UserID:   7,
+		NewEmail: "john.new@email.com",
+		Password: "verysecure",
+	}
+	s.expectedConfirmInput = internal.ConfirmEmailChangeUsecaseInput{Token: "random-token"}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *EmailChangeHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *EmailChangeHandlerSuite) expectedResponseBody(status, message string) string {
+	return `
+		{
+			"message": "` + message + `",
+			"meta": {
+				"http_status": ` + status + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_NoClaims_ReturnUnauthorized() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/me/email", nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidCredentials, "Invalid credentials."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_UsecaseUnknownError_ReturnInternalServerError() {
+	s.usecase.On("RequestEmailChange", s.request.Context(), s.expectedRequestInput).Return(s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_InvalidPassword_ReturnUnauthorized() {
+	s.usecase.On("RequestEmailChange", s.request.Context(), s.expectedRequestInput).Return(internal.ErrInvalidPassword)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "invalid_credentials", Status: 401, Title: "Authentication failed"}, "Invalid credentials."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_EmailAlreadyUsed_ReturnUnprocessableEntity() {
+	s.usecase.On("RequestEmailChange", s.request.Context(), s.expectedRequestInput).Return(internal.ErrEmailAlreadyUsed)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "email_already_used", Status: 422, Title: "Unprocessable request"}, "Email already used. Choose different email."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_UsecaseSuccess_ReturnAccepted() {
+	s.usecase.On("RequestEmailChange", s.request.Context(), s.expectedRequestInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusAccepted, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("202", "Confirmation sent. Check the inbox of the new email."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_JSONBody_ReturnAccepted() {
+	claims, _ := helper.AccessTokenClaimsFromContext(s.request.Context())
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/me/email", strings.NewReader(`{"email":"john.new@email.com","password":"verysecure"}`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.request = s.request.WithContext(helper.ContextWithAccessTokenClaims(s.request.Context(), claims))
+	s.usecase.On("RequestEmailChange", s.request.Context(), s.expectedRequestInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusAccepted, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("202", "Confirmation sent. Check the inbox of the new email."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestRequestEmailChange_UnsupportedContentType_ReturnUnsupportedMediaType() {
+	s.request.Header.Set("Content-Type", "text/plain")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RequestEmailChange(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.UnsupportedMediaType, "Unsupported content type. Send application/json or a form."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestShowEmailChangeConfirmation_EmptyToken_ReturnUnprocessableEntity() {
+	r := httptest.NewRequest("GET", "http://test.com/v1/email/confirm", nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ShowEmailChangeConfirmation(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "required_field_missing", Status: 422, Title: "Unprocessable request"}, "Token is required."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestShowEmailChangeConfirmation_Token_ReturnFormWithoutConfirming() {
+	r := httptest.NewRequest("GET", "http://test.com/v1/email/confirm?token=random%22token", nil)
+
+	s.handler.ShowEmailChangeConfirmation(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal("text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	a.Contains(string(body), `<form method="post">`)
+	a.Contains(string(body), `<input type="hidden" name="token" value="random&#34;token">`)
+}
+
+func (s *EmailChangeHandlerSuite) TestConfirmEmailChange_ExpiredRequest_ReturnUnprocessableEntity() {
+	s.usecase.On("ConfirmEmailChange", s.confirmRequest.Context(), s.expectedConfirmInput).Return(internal.ErrEmailChangeRequestExpired)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ConfirmEmailChange(s.responseWriter, s.confirmRequest, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "invalid_confirmation_link", Status: 422, Title: "Unprocessable request"}, "Confirmation link is invalid or expired."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestConfirmEmailChange_JSONBody_ReturnOK() {
+	s.confirmRequest = httptest.NewRequest("POST", "http://test.com/v1/email/confirm", strings.NewReader(`{"token":"random-token"}`))
+	s.confirmRequest.Header.Set("Content-Type", "application/json")
+	s.usecase.On("ConfirmEmailChange", s.confirmRequest.Context(), s.expectedConfirmInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ConfirmEmailChange(s.responseWriter, s.confirmRequest, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("200", "Email changed. Continue to login with the new email."), string(body))
+}
+
+func (s *EmailChangeHandlerSuite) TestConfirmEmailChange_UsecaseSuccess_ReturnOK() {
+	s.usecase.On("ConfirmEmailChange", s.confirmRequest.Context(), s.expectedConfirmInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ConfirmEmailChange(s.responseWriter, s.confirmRequest, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("200", "Email changed. Continue to login with the new email."), string(body))
+}
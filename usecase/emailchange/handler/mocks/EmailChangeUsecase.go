@@ -0,0 +1,59 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/emailchange/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailChangeUsecase is an autogenerated mock type for the EmailChangeUsecase type
+type EmailChangeUsecase struct {
+	mock.Mock
+}
+
+// ConfirmEmailChange provides a mock function with given fields: _a0, _a1
+func (_m *EmailChangeUsecase) ConfirmEmailChange(_a0 context.Context, _a1 internal.ConfirmEmailChangeUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.ConfirmEmailChangeUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestEmailChange provides a mock function with given fields: _a0, _a1
+func (_m *EmailChangeUsecase) RequestEmailChange(_a0 context.Context, _a1 internal.RequestEmailChangeUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.RequestEmailChangeUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewEmailChangeUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmailChangeUsecase creates a new instance of EmailChangeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmailChangeUsecase(t mockConstructorTestingTNewEmailChangeUsecase) *EmailChangeUsecase {
+	mock := &EmailChangeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	updateUserEmailQuery           = "UPDATE user SET email = ?, updated_at = ? WHERE id = ?"
+	confirmEmailChangeRequestQuery = "UPDATE email_change_request SET confirmed_at = ? WHERE id = ?"
+	revokeUserSessionsQuery        = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+)
+
+type ChangeUserEmailGateway struct {
+	sql *sql.DB
+}
+
+func NewChangeUserEmailGateway(sql *sql.DB) *ChangeUserEmailGateway {
+	return &ChangeUserEmailGateway{sql: sql}
+}
+
+// ChangeUserEmail applies the requested email to the user, marks the request
+// as confirmed and revokes every session of the user in a single transaction,
+// so tokens issued for the old email stop working.
+func (g *ChangeUserEmailGateway) ChangeUserEmail(ctx context.Context, req EmailChangeRequest, now time.Time) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, updateUserEmailQuery, req.NewEmail, now, req.UserID); err != nil {
+		if me, ok := err.(*mysql.MySQLError); ok {
+			if me.Number == errNoDuplicateRecord {
+				return ErrEmailAlreadyUsed
+			}
+		}
+
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, confirmEmailChangeRequestQuery, now, req.ID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, revokeUserSessionsQuery, now, req.UserID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
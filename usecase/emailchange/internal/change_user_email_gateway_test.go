@@ -0,0 +1,137 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+)
+
+type ChangeUserEmailGatewaySuite struct {
+	suite.Suite
+
+	db                      *sql.DB
+	mockDb                  sqlmock.Sqlmock
+	expectedUpdateUserQuery string
+	expectedConfirmQuery    string
+	expectedRevokeQuery     string
+	errMock                 error
+	errDuplicateRecord      error
+
+	context context.Context
+	request internal.EmailChangeRequest
+	now     time.Time
+	gateway *internal.ChangeUserEmailGateway
+}
+
+func TestChangeUserEmailGatewaySuite(t *testing.T) {
+	suite.Run(t, &ChangeUserEmailGatewaySuite{})
+}
+
+func (s *ChangeUserEmailGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedUpdateUserQuery = "UPDATE user SET email = ?, updated_at = ? WHERE id = ?"
+	s.expectedConfirmQuery = "UPDATE email_change_request SET confirmed_at = ? WHERE id = ?"
+	s.expectedRevokeQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+	s.errMock = errors.New("mocked error")
+	s.errDuplicateRecord = &mysql.MySQLError{Number: 1062, Message: "mock message"}
+
+	s.gateway = internal.NewChangeUserEmailGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.request = internal.EmailChangeRequest{ID: 3, UserID: 7, NewEmail: "john.new@email.com"}
+}
+
+func (s *ChangeUserEmailGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_BeginError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin().WillReturnError(s.errMock)
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_DuplicateEmail_ReturnEmailAlreadyUsedErr() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdateUserQuery)).WillReturnError(s.errDuplicateRecord)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, internal.ErrEmailAlreadyUsed)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_UpdateUserUnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdateUserQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_ConfirmRequestError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdateUserQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedConfirmQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_RevokeSessionsError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdateUserQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedConfirmQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ChangeUserEmailGatewaySuite) TestChangeUserEmail_Success_RevokeSessions() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdateUserQuery)).
+		WithArgs(s.request.NewEmail, s.now, s.request.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedConfirmQuery)).
+		WithArgs(s.now, s.request.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.now, s.request.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.ChangeUserEmail(s.context, s.request, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
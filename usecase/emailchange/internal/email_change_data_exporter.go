@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	emailChangeDataSection            = "email_change_requests"
+	getEmailChangeRequestsByUserQuery = "SELECT new_email, expires_at, confirmed_at, created_at FROM email_change_request WHERE user_id = ? ORDER BY created_at"
+)
+
+type EmailChangeRequestExport struct {
+	NewEmail    string     `json:"new_email"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// EmailChangeDataExporter contributes the email change requests of a user to
+// the personal data export. Token hashes are left out on purpose.
+type EmailChangeDataExporter struct {
+	sql *sql.DB
+}
+
+func NewEmailChangeDataExporter(sql *sql.DB) *EmailChangeDataExporter {
+	return &EmailChangeDataExporter{sql: sql}
+}
+
+func (e *EmailChangeDataExporter) PersonalDataSection() string {
+	return emailChangeDataSection
+}
+
+func (e *EmailChangeDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	rows, err := e.sql.QueryContext(ctx, getEmailChangeRequestsByUserQuery, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []EmailChangeRequestExport{}
+	for rows.Next() {
+		export := EmailChangeRequestExport{}
+		if err := rows.Scan(&export.NewEmail, &export.ExpiresAt, &export.ConfirmedAt, &export.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+)
+
+type EmailChangeDataExporterSuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context  context.Context
+	user     entity.User
+	exports  []internal.EmailChangeRequestExport
+	exporter *internal.EmailChangeDataExporter
+}
+
+func TestEmailChangeDataExporterSuite(t *testing.T) {
+	suite.Run(t, &EmailChangeDataExporterSuite{})
+}
+
+func (s *EmailChangeDataExporterSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT new_email, expires_at, confirmed_at, created_at FROM email_change_request WHERE user_id = ? ORDER BY created_at"
+	s.errMock = errors.New("mocked error")
+
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	confirmedAt := now.Add(time.Minute)
+	s.exporter = internal.NewEmailChangeDataExporter(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7}
+	s.exports = []internal.EmailChangeRequestExport{
+		{NewEmail: "john.new@email.com", ExpiresAt: now.Add(time.Hour), ConfirmedAt: &confirmedAt, CreatedAt: now},
+		{NewEmail: "john.newer@email.com", ExpiresAt: now.Add(2 * time.Hour), CreatedAt: now.Add(time.Hour)},
+	}
+}
+
+func (s *EmailChangeDataExporterSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *EmailChangeDataExporterSuite) TestPersonalDataSection_ReturnEmailChangeRequests() {
+	s.Assert().Equal("email_change_requests", s.exporter.PersonalDataSection())
+}
+
+func (s *EmailChangeDataExporterSuite) TestExportPersonalData_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(export)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *EmailChangeDataExporterSuite) TestExportPersonalData_NoRequests_ReturnEmptyList() {
+	rows := sqlmock.NewRows([]string{"new_email", "expires_at", "confirmed_at", "created_at"})
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.EmailChangeRequestExport{}, export)
+}
+
+func (s *EmailChangeDataExporterSuite) TestExportPersonalData_RequestsFound_ReturnExports() {
+	rows := sqlmock.NewRows([]string{"new_email", "expires_at", "confirmed_at", "created_at"})
+	rows.AddRow(s.exports[0].NewEmail, s.exports[0].ExpiresAt, *s.exports[0].ConfirmedAt, s.exports[0].CreatedAt)
+	rows.AddRow(s.exports[1].NewEmail, s.exports[1].ExpiresAt, nil, s.exports[1].CreatedAt)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	export, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.exports, export)
+}
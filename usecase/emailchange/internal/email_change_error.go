@@ -0,0 +1,66 @@
+package internal
+
+import "littlerollingsushi.com/example/usecase/helper"
+
+const (
+	errNoDuplicateRecord = 1062
+)
+
+// An unknown user and a wrong password share code and message, like a failed
+// login does.
+var (
+	ErrEmptyNewEmail = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "required_field_missing",
+		Message: "Email is required.",
+		Reason:  "email change new email can not be empty",
+	}
+	ErrEmptyPassword = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "required_field_missing",
+		Message: "Password is required.",
+		Reason:  "email change password can not be empty",
+	}
+	ErrEmptyToken = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "required_field_missing",
+		Message: "Token is required.",
+		Reason:  "email change confirmation token can not be empty",
+	}
+	ErrInvalidPassword = &helper.DomainError{
+		Kind:    helper.ErrorKindUnauthenticated,
+		Code:    "invalid_credentials",
+		Message: "Invalid credentials.",
+		Reason:  "email change password is not valid",
+	}
+	ErrSameEmail = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "same_email",
+		Message: "New email is the same as the current email.",
+		Reason:  "new email is the same as the current email",
+	}
+	ErrEmailAlreadyUsed = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "email_already_used",
+		Message: "Email already used. Choose different email.",
+		Reason:  "email is already used by another user",
+	}
+	ErrUserNotFound = &helper.DomainError{
+		Kind:    helper.ErrorKindUnauthenticated,
+		Code:    "invalid_credentials",
+		Message: "Invalid credentials.",
+		Reason:  "user with given id is not found",
+	}
+	ErrEmailChangeRequestNotFound = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "invalid_confirmation_link",
+		Message: "Confirmation link is invalid or expired.",
+		Reason:  "email change request with given token is not found",
+	}
+	ErrEmailChangeRequestExpired = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "invalid_confirmation_link",
+		Message: "Confirmation link is invalid or expired.",
+		Reason:  "email change request is expired",
+	}
+)
@@ -0,0 +1,23 @@
+package internal
+
+import "time"
+
+type RequestEmailChangeUsecaseInput struct {
+	UserID   int64
+	NewEmail string
+	Password string
+}
+
+type ConfirmEmailChangeUsecaseInput struct {
+	Token string
+}
+
+type EmailChangeRequest struct {
+	ID        int64
+	UserID    int64
+	OldEmail  string
+	NewEmail  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type EmailChangeUsecase struct {
+	config  EmailChangeUsecaseConfig
+	gateway EmailChangeGateway
+}
+
+type EmailChangeUsecaseConfig struct {
+	ConfirmationURL string
+	TokenExpiration time.Duration
+}
+
+//go:generate mockery --name=EmailChangeGateway --output=./mocks
+type EmailChangeGateway interface {
+	GetUserByID(ctx context.Context, userID int64) (entity.User, error)
+	IsEmailUsed(ctx context.Context, email string) (bool, error)
+	InsertEmailChangeRequest(ctx context.Context, req EmailChangeRequest) error
+	GetEmailChangeRequestByTokenHash(ctx context.Context, tokenHash string) (EmailChangeRequest, error)
+	ChangeUserEmail(ctx context.Context, req EmailChangeRequest, now time.Time) error
+	IsHashAndPasswordEqual(hash, password string) bool
+	GenerateToken() (string, error)
+	HashToken(token string) string
+	SendMail(ctx context.Context, mail helper.Mail) error
+	NowInUTC() time.Time
+}
+
+func NewEmailChangeUsecase(config EmailChangeUsecaseConfig, gateway EmailChangeGateway) *EmailChangeUsecase {
+	return &EmailChangeUsecase{config: config, gateway: gateway}
+}
+
+func (u *EmailChangeUsecase) RequestEmailChange(ctx context.Context, in RequestEmailChangeUsecaseInput) error {
+	if in.NewEmail == "" {
+		return ErrEmptyNewEmail
+	}
+
+	if in.Password == "" {
+		return ErrEmptyPassword
+	}
+
+	user, err := u.gateway.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !u.gateway.IsHashAndPasswordEqual(user.CryptedPassword, in.Password) {
+		return ErrInvalidPassword
+	}
+
+	if strings.EqualFold(user.Email, in.NewEmail) {
+		return ErrSameEmail
+	}
+
+	used, err := u.gateway.IsEmailUsed(ctx, in.NewEmail)
+	if err != nil {
+		return err
+	}
+
+	if used {
+		return ErrEmailAlreadyUsed
+	}
+
+	token, err := u.gateway.GenerateToken()
+	if err != nil {
+		return err
+	}
+
+	now := u.gateway.NowInUTC()
+	req := EmailChangeRequest{
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  in.NewEmail,
+		TokenHash: u.gateway.HashToken(token),
+		ExpiresAt: now.Add(u.config.TokenExpiration),
+		CreatedAt: now,
+	}
+	if err := u.gateway.InsertEmailChangeRequest(ctx, req); err != nil {
+		return err
+	}
+
+	if err := u.gateway.SendMail(ctx, u.buildConfirmationMail(req, token)); err != nil {
+		return err
+	}
+
+	return u.gateway.SendMail(ctx, u.buildNoticeMail(req))
+}
+
+func (u *EmailChangeUsecase) ConfirmEmailChange(ctx context.Context, in ConfirmEmailChangeUsecaseInput) error {
+	if in.Token == "" {
+		return ErrEmptyToken
+	}
+
+	req, err := u.gateway.GetEmailChangeRequestByTokenHash(ctx, u.gateway.HashToken(in.Token))
+	if err != nil {
+		return err
+	}
+
+	now := u.gateway.NowInUTC()
+	if !now.Before(req.ExpiresAt) {
+		return ErrEmailChangeRequestExpired
+	}
+
+	return u.gateway.ChangeUserEmail(ctx, req, now)
+}
+
+func (u *EmailChangeUsecase) buildConfirmationMail(req EmailChangeRequest, token string) helper.Mail {
+	link := u.config.ConfirmationURL + "?token=" + url.QueryEscape(token)
+
+	return helper.Mail{
+		To:      req.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Someone requested to use this address for the account %s.\n\n"+
+				"Open the link below to confirm the change. It expires at %s.\n\n%s\n\n"+
+				"If you did not request this, you can ignore this email.\n",
+			req.OldEmail,
+			req.ExpiresAt.Format(time.RFC1123),
+			link,
+		),
+	}
+}
+
+func (u *EmailChangeUsecase) buildNoticeMail(req EmailChangeRequest) helper.Mail {
+	return helper.Mail{
+		To:      req.OldEmail,
+		Subject: "Your email address is about to change",
+		Body: fmt.Sprintf(
+			"We received a request to change the email address of your account to %s.\n\n"+
+				"The change is applied only after it is confirmed from the new address. "+
+				"If you did not request this, change your password immediately.\n",
+			req.NewEmail,
+		),
+	}
+}
@@ -0,0 +1,217 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+	"littlerollingsushi.com/example/usecase/emailchange/internal/mocks"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type EmailChangeUsecaseSuite struct {
+	suite.Suite
+
+	config  internal.EmailChangeUsecaseConfig
+	gateway *mocks.EmailChangeGateway
+	usecase *internal.EmailChangeUsecase
+
+	context       context.Context
+	requestInput  internal.RequestEmailChangeUsecaseInput
+	confirmInput  internal.ConfirmEmailChangeUsecaseInput
+	user          entity.User
+	changeRequest internal.EmailChangeRequest
+	now           time.Time
+	errMock       error
+}
+
+func TestEmailChangeUsecaseSuite(t *testing.T) {
+	suite.Run(t, &EmailChangeUsecaseSuite{})
+}
+
+func (s *EmailChangeUsecaseSuite) SetupTest() {
+	s.config = internal.EmailChangeUsecaseConfig{
+		ConfirmationURL: "http://test.com/v1/me/email/confirm",
+		TokenExpiration: time.Hour,
+	}
+	s.gateway = mocks.NewEmailChangeGateway(s.T())
+	s.usecase = internal.NewEmailChangeUsecase(s.config, s.gateway)
+
+	s.context = context.Background()
+	s.requestInput = internal.RequestEmailChangeUsecaseInput{
+		UserID:   7,
+		NewEmail: "john.new@email.com",
+		Password: "verysecure",
+	}
+	s.confirmInput = internal.ConfirmEmailChangeUsecaseInput{Token: "random-token"}
+	s.user = entity.User{
+		ID:              7,
+		FirstName:       "John",
+		LastName:        "Doe",
+		Email:           "john.doe@email.com",
+		CryptedPassword: "verysecureencrypted",
+	}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.changeRequest = internal.EmailChangeRequest{
+		UserID:    s.user.ID,
+		OldEmail:  s.user.Email,
+		NewEmail:  s.requestInput.NewEmail,
+		TokenHash: "random-token-hash",
+		ExpiresAt: s.now.Add(time.Hour),
+		CreatedAt: s.now,
+	}
+	s.errMock = errors.New("mock error")
+}
+
+func (s *EmailChangeUsecaseSuite) mockValidRequest() {
+	s.gateway.On("GetUserByID", s.context, s.requestInput.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.requestInput.Password).Return(true)
+	s.gateway.On("IsEmailUsed", s.context, s.requestInput.NewEmail).Return(false, nil)
+	s.gateway.On("GenerateToken").Return("random-token", nil)
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("NowInUTC").Return(s.now)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_EmptyNewEmail_ReturnError() {
+	s.requestInput.NewEmail = ""
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmptyNewEmail)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_EmptyPassword_ReturnError() {
+	s.requestInput.Password = ""
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmptyPassword)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_GetUserByIDError_ReturnOriginalError() {
+	s.gateway.On("GetUserByID", s.context, s.requestInput.UserID).Return(entity.User{}, s.errMock)
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_InvalidPassword_ReturnError() {
+	s.gateway.On("GetUserByID", s.context, s.requestInput.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.requestInput.Password).Return(false)
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidPassword)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_SameEmail_ReturnError() {
+	s.requestInput.NewEmail = "John.Doe@email.com"
+	s.gateway.On("GetUserByID", s.context, s.requestInput.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.requestInput.Password).Return(true)
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, internal.ErrSameEmail)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_NewEmailUsed_ReturnEmailAlreadyUsedErr() {
+	s.gateway.On("GetUserByID", s.context, s.requestInput.UserID).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.requestInput.Password).Return(true)
+	s.gateway.On("IsEmailUsed", s.context, s.requestInput.NewEmail).Return(true, nil)
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmailAlreadyUsed)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_InsertRequestError_ReturnOriginalError() {
+	s.mockValidRequest()
+	s.gateway.On("InsertEmailChangeRequest", s.context, s.changeRequest).Return(s.errMock)
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_SendMailError_ReturnOriginalError() {
+	s.mockValidRequest()
+	s.gateway.On("InsertEmailChangeRequest", s.context, s.changeRequest).Return(nil)
+	s.gateway.On("SendMail", s.context, mock.Anything).Return(s.errMock).Once()
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *EmailChangeUsecaseSuite) TestRequestEmailChange_ValidRequest_SendConfirmationAndNotice() {
+	s.mockValidRequest()
+	s.gateway.On("InsertEmailChangeRequest", s.context, s.changeRequest).Return(nil)
+	s.gateway.On("SendMail", s.context, mock.MatchedBy(func(m helper.Mail) bool {
+		return m.To == s.requestInput.NewEmail
+	})).Return(nil).Once()
+	s.gateway.On("SendMail", s.context, mock.MatchedBy(func(m helper.Mail) bool {
+		return m.To == s.user.Email
+	})).Return(nil).Once()
+
+	err := s.usecase.RequestEmailChange(s.context, s.requestInput)
+
+	a := s.Assert()
+	a.Nil(err)
+	confirmation := s.gateway.Calls[len(s.gateway.Calls)-2].Arguments.Get(1).(helper.Mail)
+	a.Contains(confirmation.Body, "http://test.com/v1/me/email/confirm?token=random-token")
+}
+
+func (s *EmailChangeUsecaseSuite) TestConfirmEmailChange_EmptyToken_ReturnError() {
+	s.confirmInput.Token = ""
+
+	err := s.usecase.ConfirmEmailChange(s.context, s.confirmInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmptyToken)
+}
+
+func (s *EmailChangeUsecaseSuite) TestConfirmEmailChange_RequestNotFound_ReturnError() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetEmailChangeRequestByTokenHash", s.context, "random-token-hash").Return(internal.EmailChangeRequest{}, internal.ErrEmailChangeRequestNotFound)
+
+	err := s.usecase.ConfirmEmailChange(s.context, s.confirmInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmailChangeRequestNotFound)
+}
+
+func (s *EmailChangeUsecaseSuite) TestConfirmEmailChange_RequestExpired_ReturnError() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetEmailChangeRequestByTokenHash", s.context, "random-token-hash").Return(s.changeRequest, nil)
+	s.gateway.On("NowInUTC").Return(s.changeRequest.ExpiresAt)
+
+	err := s.usecase.ConfirmEmailChange(s.context, s.confirmInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmailChangeRequestExpired)
+}
+
+func (s *EmailChangeUsecaseSuite) TestConfirmEmailChange_EmailTakenMeanwhile_ReturnEmailAlreadyUsedErr() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetEmailChangeRequestByTokenHash", s.context, "random-token-hash").Return(s.changeRequest, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("ChangeUserEmail", s.context, s.changeRequest, s.now).Return(internal.ErrEmailAlreadyUsed)
+
+	err := s.usecase.ConfirmEmailChange(s.context, s.confirmInput)
+
+	s.Assert().ErrorIs(err, internal.ErrEmailAlreadyUsed)
+}
+
+func (s *EmailChangeUsecaseSuite) TestConfirmEmailChange_ValidToken_ReturnNil() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetEmailChangeRequestByTokenHash", s.context, "random-token-hash").Return(s.changeRequest, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("ChangeUserEmail", s.context, s.changeRequest, s.now).Return(nil)
+
+	err := s.usecase.ConfirmEmailChange(s.context, s.confirmInput)
+
+	s.Assert().Nil(err)
+}
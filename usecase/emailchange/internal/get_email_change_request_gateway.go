@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	getEmailChangeRequestByTokenHashQuery = "SELECT r.id, r.user_id, u.email, r.new_email, r.token_hash, r.expires_at, r.created_at " +
+		"FROM email_change_request r JOIN user u ON u.id = r.user_id WHERE r.token_hash = ? AND r.confirmed_at IS NULL"
+)
+
+type GetEmailChangeRequestGateway struct {
+	sql *sql.DB
+}
+
+func NewGetEmailChangeRequestGateway(sql *sql.DB) *GetEmailChangeRequestGateway {
+	return &GetEmailChangeRequestGateway{sql: sql}
+}
+
+func (g *GetEmailChangeRequestGateway) GetEmailChangeRequestByTokenHash(ctx context.Context, tokenHash string) (EmailChangeRequest, error) {
+	req := EmailChangeRequest{}
+	err := g.sql.QueryRowContext(ctx, getEmailChangeRequestByTokenHashQuery, tokenHash).
+		Scan(&req.ID, &req.UserID, &req.OldEmail, &req.NewEmail, &req.TokenHash, &req.ExpiresAt, &req.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return req, ErrEmailChangeRequestNotFound
+		}
+
+		return req, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,95 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+)
+
+type GetEmailChangeRequestGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context   context.Context
+	tokenHash string
+	request   internal.EmailChangeRequest
+	gateway   *internal.GetEmailChangeRequestGateway
+}
+
+func TestGetEmailChangeRequestGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetEmailChangeRequestGatewaySuite{})
+}
+
+func (s *GetEmailChangeRequestGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT r.id, r.user_id, u.email, r.new_email, r.token_hash, r.expires_at, r.created_at " +
+		"FROM email_change_request r JOIN user u ON u.id = r.user_id WHERE r.token_hash = ? AND r.confirmed_at IS NULL"
+	s.errMock = errors.New("mocked error")
+
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.gateway = internal.NewGetEmailChangeRequestGateway(s.db)
+	s.context = context.Background()
+	s.tokenHash = "random-token-hash"
+	s.request = internal.EmailChangeRequest{
+		ID:        3,
+		UserID:    7,
+		OldEmail:  "john.doe@email.com",
+		NewEmail:  "john.new@email.com",
+		TokenHash: s.tokenHash,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+}
+
+func (s *GetEmailChangeRequestGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetEmailChangeRequestGatewaySuite) TestGetEmailChangeRequestByTokenHash_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	req, err := s.gateway.GetEmailChangeRequestByTokenHash(s.context, s.tokenHash)
+
+	a := s.Assert()
+	a.Empty(req)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *GetEmailChangeRequestGatewaySuite) TestGetEmailChangeRequestByTokenHash_NoRows_ReturnNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(sql.ErrNoRows)
+
+	req, err := s.gateway.GetEmailChangeRequestByTokenHash(s.context, s.tokenHash)
+
+	a := s.Assert()
+	a.Empty(req)
+	a.ErrorIs(err, internal.ErrEmailChangeRequestNotFound)
+}
+
+func (s *GetEmailChangeRequestGatewaySuite) TestGetEmailChangeRequestByTokenHash_Found_ReturnRequest() {
+	rows := sqlmock.NewRows([]string{"id", "user_id", "email", "new_email", "token_hash", "expires_at", "created_at"})
+	rows.AddRow(s.request.ID, s.request.UserID, s.request.OldEmail, s.request.NewEmail, s.request.TokenHash, s.request.ExpiresAt, s.request.CreatedAt)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.tokenHash).WillReturnRows(rows)
+
+	req, err := s.gateway.GetEmailChangeRequestByTokenHash(s.context, s.tokenHash)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.request, req)
+}
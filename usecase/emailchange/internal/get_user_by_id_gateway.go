@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	getUserByIDQuery = "SELECT id, email, crypted_password FROM user WHERE id = ?"
+	isEmailUsedQuery = "SELECT EXISTS (SELECT 1 FROM user WHERE email = ?)"
+)
+
+type GetUserByIDGateway struct {
+	sql *sql.DB
+}
+
+func NewGetUserByIDGateway(sql *sql.DB) *GetUserByIDGateway {
+	return &GetUserByIDGateway{sql: sql}
+}
+
+// GetUserByID reads only the columns the email change needs.
+func (g *GetUserByIDGateway) GetUserByID(ctx context.Context, userID int64) (entity.User, error) {
+	user := entity.User{}
+	err := g.sql.QueryRowContext(ctx, getUserByIDQuery, userID).Scan(&user.ID, &user.Email, &user.CryptedPassword)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, ErrUserNotFound
+		}
+
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (g *GetUserByIDGateway) IsEmailUsed(ctx context.Context, email string) (bool, error) {
+	used := false
+	err := g.sql.QueryRowContext(ctx, isEmailUsedQuery, email).Scan(&used)
+	return used, err
+}
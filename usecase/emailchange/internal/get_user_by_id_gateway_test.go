@@ -0,0 +1,94 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+)
+
+type GetUserByIDGatewaySuite struct {
+	suite.Suite
+
+	db      *sql.DB
+	mockDb  sqlmock.Sqlmock
+	errMock error
+
+	context context.Context
+	user    entity.User
+	gateway *internal.GetUserByIDGateway
+}
+
+func TestGetUserByIDGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetUserByIDGatewaySuite{})
+}
+
+func (s *GetUserByIDGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewGetUserByIDGateway(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7, Email: "john.doe@email.com", CryptedPassword: "verysecureencrypted"}
+}
+
+func (s *GetUserByIDGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_NoRows_ReturnUserNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta("SELECT id, email, crypted_password FROM user WHERE id = ?")).
+		WithArgs(s.user.ID).
+		WillReturnError(sql.ErrNoRows)
+
+	user, err := s.gateway.GetUserByID(s.context, s.user.ID)
+
+	a := s.Assert()
+	a.Empty(user)
+	a.ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_UserFound_ReturnUser() {
+	rows := sqlmock.NewRows([]string{"id", "email", "crypted_password"}).AddRow(s.user.ID, s.user.Email, s.user.CryptedPassword)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta("SELECT id, email, crypted_password FROM user WHERE id = ?")).
+		WithArgs(s.user.ID).
+		WillReturnRows(rows)
+
+	user, err := s.gateway.GetUserByID(s.context, s.user.ID)
+
+	a := s.Assert()
+	a.Equal(s.user, user)
+	a.Nil(err)
+}
+
+func (s *GetUserByIDGatewaySuite) TestIsEmailUsed_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM user WHERE email = ?)")).WillReturnError(s.errMock)
+
+	_, err := s.gateway.IsEmailUsed(s.context, "john.new@email.com")
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *GetUserByIDGatewaySuite) TestIsEmailUsed_EmailExists_ReturnTrue() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM user WHERE email = ?)")).
+		WithArgs("john.new@email.com").
+		WillReturnRows(sqlmock.NewRows([]string{"used"}).AddRow(true))
+
+	used, err := s.gateway.IsEmailUsed(s.context, "john.new@email.com")
+
+	a := s.Assert()
+	a.True(used)
+	a.Nil(err)
+}
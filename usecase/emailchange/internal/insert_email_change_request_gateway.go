@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	deletePendingEmailChangeRequestsQuery = "DELETE FROM email_change_request WHERE user_id = ? AND confirmed_at IS NULL"
+	insertEmailChangeRequestQuery         = "INSERT INTO email_change_request (user_id, new_email, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)"
+)
+
+type InsertEmailChangeRequestGateway struct {
+	sql *sql.DB
+}
+
+func NewInsertEmailChangeRequestGateway(sql *sql.DB) *InsertEmailChangeRequestGateway {
+	return &InsertEmailChangeRequestGateway{sql: sql}
+}
+
+// InsertEmailChangeRequest replaces the pending requests of the user with req
+// in a single transaction, so only the link of the latest request works.
+func (g *InsertEmailChangeRequestGateway) InsertEmailChangeRequest(ctx context.Context, req EmailChangeRequest) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deletePendingEmailChangeRequestsQuery, req.UserID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, insertEmailChangeRequestQuery, req.UserID, req.NewEmail, req.TokenHash, req.ExpiresAt, req.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,102 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/emailchange/internal"
+)
+
+type InsertEmailChangeRequestGatewaySuite struct {
+	suite.Suite
+
+	db                  *sql.DB
+	mockDb              sqlmock.Sqlmock
+	expectedDeleteQuery string
+	expectedInsertQuery string
+	errMock             error
+
+	context context.Context
+	input   internal.EmailChangeRequest
+	gateway *internal.InsertEmailChangeRequestGateway
+}
+
+func TestInsertEmailChangeRequestGatewaySuite(t *testing.T) {
+	suite.Run(t, &InsertEmailChangeRequestGatewaySuite{})
+}
+
+func (s *InsertEmailChangeRequestGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedDeleteQuery = "DELETE FROM email_change_request WHERE user_id = ? AND confirmed_at IS NULL"
+	s.expectedInsertQuery = "INSERT INTO email_change_request (user_id, new_email, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)"
+	s.errMock = errors.New("mocked error")
+
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.gateway = internal.NewInsertEmailChangeRequestGateway(s.db)
+	s.context = context.Background()
+	s.input = internal.EmailChangeRequest{
+		UserID:    7,
+		NewEmail:  "john.new@email.com",
+		TokenHash: "random-token-hash",
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+}
+
+func (s *InsertEmailChangeRequestGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *InsertEmailChangeRequestGatewaySuite) TestInsertEmailChangeRequest_DeleteError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.InsertEmailChangeRequest(s.context, s.input)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *InsertEmailChangeRequestGatewaySuite) TestInsertEmailChangeRequest_InsertError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.InsertEmailChangeRequest(s.context, s.input)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *InsertEmailChangeRequestGatewaySuite) TestInsertEmailChangeRequest_InsertSuccess_ReplacePendingRequests() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).
+		WithArgs(s.input.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertQuery)).
+		WithArgs(s.input.UserID, s.input.NewEmail, s.input.TokenHash, s.input.ExpiresAt, s.input.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.InsertEmailChangeRequest(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
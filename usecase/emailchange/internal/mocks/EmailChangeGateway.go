@@ -0,0 +1,204 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+	helper "littlerollingsushi.com/example/usecase/helper"
+
+	internal "littlerollingsushi.com/example/usecase/emailchange/internal"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// EmailChangeGateway is an autogenerated mock type for the EmailChangeGateway type
+type EmailChangeGateway struct {
+	mock.Mock
+}
+
+// ChangeUserEmail provides a mock function with given fields: ctx, req, now
+func (_m *EmailChangeGateway) ChangeUserEmail(ctx context.Context, req internal.EmailChangeRequest, now time.Time) error {
+	ret := _m.Called(ctx, req, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.EmailChangeRequest, time.Time) error); ok {
+		r0 = rf(ctx, req, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateToken provides a mock function with given fields:
+func (_m *EmailChangeGateway) GenerateToken() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailChangeRequestByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *EmailChangeGateway) GetEmailChangeRequestByTokenHash(ctx context.Context, tokenHash string) (internal.EmailChangeRequest, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 internal.EmailChangeRequest
+	if rf, ok := ret.Get(0).(func(context.Context, string) internal.EmailChangeRequest); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(internal.EmailChangeRequest)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userID
+func (_m *EmailChangeGateway) GetUserByID(ctx context.Context, userID int64) (entity.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashToken provides a mock function with given fields: token
+func (_m *EmailChangeGateway) HashToken(token string) string {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// InsertEmailChangeRequest provides a mock function with given fields: ctx, req
+func (_m *EmailChangeGateway) InsertEmailChangeRequest(ctx context.Context, req internal.EmailChangeRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.EmailChangeRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsEmailUsed provides a mock function with given fields: ctx, email
+func (_m *EmailChangeGateway) IsEmailUsed(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsHashAndPasswordEqual provides a mock function with given fields: hash, password
+func (_m *EmailChangeGateway) IsHashAndPasswordEqual(hash string, password string) bool {
+	ret := _m.Called(hash, password)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(hash, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *EmailChangeGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// SendMail provides a mock function with given fields: ctx, mail
+func (_m *EmailChangeGateway) SendMail(ctx context.Context, mail helper.Mail) error {
+	ret := _m.Called(ctx, mail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, helper.Mail) error); ok {
+		r0 = rf(ctx, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewEmailChangeGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmailChangeGateway creates a new instance of EmailChangeGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmailChangeGateway(t mockConstructorTestingTNewEmailChangeGateway) *EmailChangeGateway {
+	mock := &EmailChangeGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
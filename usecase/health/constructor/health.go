@@ -0,0 +1,27 @@
+package constructor
+
+import (
+	"crypto/rsa"
+	"database/sql"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/usecase/health/handler"
+	"littlerollingsushi.com/example/usecase/health/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+// ConstructHealthUsecase registers the readiness checks of the API: the
+// database behind db and the RSA key pair access tokens are signed and
+// verified with, the very keys the login and the authentication use. main
+// keeps the usecase to begin its shutdown.
+func ConstructHealthUsecase(cfg *config.Config, db *sql.DB, privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) *internal.HealthUsecase {
+	usecase := internal.NewHealthUsecase(&helper.TimerImplementation{})
+	usecase.Register("database", internal.NewPingDatabaseGateway(db), cfg.Health.CheckTimeout)
+	usecase.Register("signing_key", internal.NewSigningKeyChecker(privateKey, publicKey), cfg.Health.CheckTimeout)
+
+	return usecase
+}
+
+func ConstructHealthHandler(usecase *internal.HealthUsecase) *handler.HealthHandler {
+	return handler.NewHealthHandler(usecase, &helper.TimerImplementation{})
+}
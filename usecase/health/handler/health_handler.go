@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/health/internal"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const timeFormat = "2006-01-02T15:04:05.999Z"
+
+type HealthHandler struct {
+	usecase HealthUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=HealthUsecase --output=./mocks
+type HealthUsecase interface {
+	CheckReadiness(context.Context) internal.Readiness
+}
+
+func NewHealthHandler(usecase HealthUsecase, timer helper.Timer) *HealthHandler {
+	return &HealthHandler{usecase: usecase, timer: timer}
+}
+
+// Live answers as long as the process serves requests at all. It checks no
+// dependency, an orchestrator restarting the API would not fix them.
+func (h *HealthHandler) Live(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{"status": internal.StatusOK})
+}
+
+// Ready answers 503 Service Unavailable when a check fails or shutdown has
+// begun, with the status of every check. The endpoint is public, so why a
+// check fails is only logged.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	readiness := h.usecase.CheckReadiness(r.Context())
+
+	checks := map[string]interface{}{}
+	for _, result := range readiness.Checks {
+		checks[result.Name] = map[string]interface{}{"status": result.Status}
+		if result.Error != "" {
+			logging.FromContext(r.Context()).Warn(
+				"readiness check failing",
+				"check", result.Name,
+				"error", result.Error,
+				"duration_ms", result.Duration.Milliseconds(),
+			)
+		}
+	}
+
+	status := http.StatusOK
+	if !readiness.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	h.writeDataResponse(w, status, map[string]interface{}{
+		"status": readiness.Status,
+		"checks": checks,
+	})
+}
+
+func (h *HealthHandler) writeDataResponse(w http.ResponseWriter, status int, data map[string]interface{}) {
+	data["meta"] = map[string]interface{}{
+		"http_status": status,
+		"server_time": h.timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/health/handler"
+	"littlerollingsushi.com/example/usecase/health/handler/mocks"
+	"littlerollingsushi.com/example/usecase/health/internal"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type HealthHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.HealthUsecase
+	timer   *helperMocks.Timer
+	handler *handler.HealthHandler
+
+	expectedTimestamp time.Time
+}
+
+func TestHealthHandlerSuite(t *testing.T) {
+	suite.Run(t, &HealthHandlerSuite{})
+}
+
+func (s *HealthHandlerSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/readyz", nil)
+	s.requestParams = map[string]string{}
+	s.responseWriter = httptest.NewRecorder()
+
+	s.usecase = mocks.NewHealthUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewHealthHandler(s.usecase, s.timer)
+
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+}
+
+func (s *HealthHandlerSuite) TestLive_ReturnOK() {
+	s.handler.Live(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"status": "ok",
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *HealthHandlerSuite) TestReady_Ready_ReturnOKWithChecks() {
+	s.usecase.On("CheckReadiness", s.request.Context()).Return(internal.Readiness{
+		Ready:  true,
+		Status: internal.StatusOK,
+		Checks: []internal.CheckResult{
+			{Name: "database", Status: internal.StatusOK, Duration: 3 * time.Millisecond},
+			{Name: "signing_key", Status: internal.StatusOK},
+		},
+	})
+
+	s.handler.Ready(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"status": "ok",
+			"checks": {
+				"database": {"status": "ok"},
+				"signing_key": {"status": "ok"}
+			},
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *HealthHandlerSuite) TestReady_CheckFailing_ReturnServiceUnavailable() {
+	s.usecase.On("CheckReadiness", s.request.Context()).Return(internal.Readiness{
+		Status: internal.StatusUnavailable,
+		Checks: []internal.CheckResult{
+			{Name: "database", Status: internal.StatusFailing, Error: "timed out after 2s", Duration: 2 * time.Second},
+		},
+	})
+
+	s.handler.Ready(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"status": "unavailable",
+			"checks": {
+				"database": {"status": "failing"}
+			},
+			"meta": {
+				"http_status": 503,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *HealthHandlerSuite) TestReady_ShuttingDown_ReturnServiceUnavailable() {
+	s.usecase.On("CheckReadiness", s.request.Context()).Return(internal.Readiness{Status: internal.StatusShuttingDown, Checks: []internal.CheckResult{}})
+
+	s.handler.Ready(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	a.Contains(string(body), `"status":"shutting_down"`)
+}
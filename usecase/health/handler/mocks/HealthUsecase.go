@@ -0,0 +1,45 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/health/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthUsecase is an autogenerated mock type for the HealthUsecase type
+type HealthUsecase struct {
+	mock.Mock
+}
+
+// CheckReadiness provides a mock function with given fields: _a0
+func (_m *HealthUsecase) CheckReadiness(_a0 context.Context) internal.Readiness {
+	ret := _m.Called(_a0)
+
+	var r0 internal.Readiness
+	if rf, ok := ret.Get(0).(func(context.Context) internal.Readiness); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(internal.Readiness)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewHealthUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHealthUsecase creates a new instance of HealthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHealthUsecase(t mockConstructorTestingTNewHealthUsecase) *HealthUsecase {
+	mock := &HealthUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,23 @@
+package internal
+
+import "time"
+
+const (
+	StatusOK           = "ok"
+	StatusFailing      = "failing"
+	StatusUnavailable  = "unavailable"
+	StatusShuttingDown = "shutting_down"
+)
+
+type CheckResult struct {
+	Name     string
+	Status   string
+	Error    string
+	Duration time.Duration
+}
+
+type Readiness struct {
+	Ready  bool
+	Status string
+	Checks []CheckResult
+}
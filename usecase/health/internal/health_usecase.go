@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+//go:generate mockery --name=Checker --output=./mocks
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type check struct {
+	name    string
+	checker Checker
+	timeout time.Duration
+}
+
+// HealthUsecase answers whether the API can serve traffic. It is ready while
+// every registered check passes and shutdown has not begun.
+type HealthUsecase struct {
+	timer        helper.Timer
+	mutex        sync.RWMutex
+	checks       []check
+	shuttingDown atomic.Bool
+}
+
+func NewHealthUsecase(timer helper.Timer) *HealthUsecase {
+	return &HealthUsecase{timer: timer}
+}
+
+// Register adds a check run on every readiness probe. A check taking longer
+// than timeout fails.
+func (u *HealthUsecase) Register(name string, checker Checker, timeout time.Duration) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.checks = append(u.checks, check{name: name, checker: checker, timeout: timeout})
+}
+
+// BeginShutdown makes every later readiness probe fail, so load balancers stop
+// sending requests while the server drains the ones in flight.
+func (u *HealthUsecase) BeginShutdown() {
+	u.shuttingDown.Store(true)
+}
+
+// CheckReadiness runs the checks concurrently, so a probe takes as long as the
+// slowest check at most. Results keep the order the checks were registered in.
+func (u *HealthUsecase) CheckReadiness(ctx context.Context) Readiness {
+	if u.shuttingDown.Load() {
+		return Readiness{Status: StatusShuttingDown, Checks: []CheckResult{}}
+	}
+
+	u.mutex.RLock()
+	checks := append([]check(nil), u.checks...)
+	u.mutex.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	wg := sync.WaitGroup{}
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = u.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	readiness := Readiness{Ready: true, Status: StatusOK, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusOK {
+			readiness.Ready = false
+			readiness.Status = StatusUnavailable
+		}
+	}
+
+	return readiness
+}
+
+func (u *HealthUsecase) run(ctx context.Context, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := u.timer.NowInUTC()
+	err := c.checker.Check(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Name: c.name, Status: StatusOK, Duration: u.timer.NowInUTC().Sub(start)}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+		if ctx.Err() == context.DeadlineExceeded {
+			result.Error = fmt.Sprintf("timed out after %s", c.timeout)
+		}
+	}
+
+	return result
+}
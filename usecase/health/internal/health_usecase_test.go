@@ -0,0 +1,94 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/health/internal"
+	"littlerollingsushi.com/example/usecase/health/internal/mocks"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type HealthUsecaseSuite struct {
+	suite.Suite
+
+	context  context.Context
+	timer    *helperMocks.Timer
+	database *mocks.Checker
+	key      *mocks.Checker
+	usecase  *internal.HealthUsecase
+
+	now time.Time
+}
+
+func TestHealthUsecaseSuite(t *testing.T) {
+	suite.Run(t, &HealthUsecaseSuite{})
+}
+
+func (s *HealthUsecaseSuite) SetupTest() {
+	s.context = context.Background()
+	s.timer = helperMocks.NewTimer(s.T())
+	s.database = mocks.NewChecker(s.T())
+	s.key = mocks.NewChecker(s.T())
+	s.usecase = internal.NewHealthUsecase(s.timer)
+	s.usecase.Register("database", s.database, 50*time.Millisecond)
+	s.usecase.Register("signing_key", s.key, 50*time.Millisecond)
+
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.timer.On("NowInUTC").Return(s.now).Maybe()
+}
+
+func (s *HealthUsecaseSuite) TestCheckReadiness_AllPass_Ready() {
+	s.database.On("Check", mock.Anything).Return(nil)
+	s.key.On("Check", mock.Anything).Return(nil)
+
+	readiness := s.usecase.CheckReadiness(s.context)
+
+	s.Assert().Equal(internal.Readiness{
+		Ready:  true,
+		Status: internal.StatusOK,
+		Checks: []internal.CheckResult{
+			{Name: "database", Status: internal.StatusOK},
+			{Name: "signing_key", Status: internal.StatusOK},
+		},
+	}, readiness)
+}
+
+func (s *HealthUsecaseSuite) TestCheckReadiness_CheckFails_NotReady() {
+	s.database.On("Check", mock.Anything).Return(errors.New("connection refused"))
+	s.key.On("Check", mock.Anything).Return(nil)
+
+	readiness := s.usecase.CheckReadiness(s.context)
+
+	a := s.Assert()
+	a.False(readiness.Ready)
+	a.Equal(internal.StatusUnavailable, readiness.Status)
+	a.Equal(internal.CheckResult{Name: "database", Status: internal.StatusFailing, Error: "connection refused"}, readiness.Checks[0])
+	a.Equal(internal.StatusOK, readiness.Checks[1].Status)
+}
+
+func (s *HealthUsecaseSuite) TestCheckReadiness_CheckTimesOut_NotReady() {
+	s.database.On("Check", mock.Anything).Return(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.key.On("Check", mock.Anything).Return(nil)
+
+	readiness := s.usecase.CheckReadiness(s.context)
+
+	a := s.Assert()
+	a.False(readiness.Ready)
+	a.Equal("timed out after 50ms", readiness.Checks[0].Error)
+}
+
+func (s *HealthUsecaseSuite) TestCheckReadiness_ShutdownBegun_NotReadyWithoutChecks() {
+	s.usecase.BeginShutdown()
+
+	readiness := s.usecase.CheckReadiness(s.context)
+
+	s.Assert().Equal(internal.Readiness{Status: internal.StatusShuttingDown, Checks: []internal.CheckResult{}}, readiness)
+}
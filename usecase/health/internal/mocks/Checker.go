@@ -0,0 +1,43 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Checker is an autogenerated mock type for the Checker type
+type Checker struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *Checker) Check(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChecker creates a new instance of Checker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChecker(t mockConstructorTestingTNewChecker) *Checker {
+	mock := &Checker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
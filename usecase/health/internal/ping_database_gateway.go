@@ -0,0 +1,19 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+type PingDatabaseGateway struct {
+	sql *sql.DB
+}
+
+func NewPingDatabaseGateway(sql *sql.DB) *PingDatabaseGateway {
+	return &PingDatabaseGateway{sql: sql}
+}
+
+// Check pings the database, opening a connection when the pool has none idle.
+func (g *PingDatabaseGateway) Check(ctx context.Context) error {
+	return g.sql.PingContext(ctx)
+}
@@ -0,0 +1,52 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/health/internal"
+)
+
+type PingDatabaseGatewaySuite struct {
+	suite.Suite
+
+	db      *sql.DB
+	mockDb  sqlmock.Sqlmock
+	gateway *internal.PingDatabaseGateway
+}
+
+func TestPingDatabaseGatewaySuite(t *testing.T) {
+	suite.Run(t, &PingDatabaseGatewaySuite{})
+}
+
+func (s *PingDatabaseGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.gateway = internal.NewPingDatabaseGateway(db)
+}
+
+func (s *PingDatabaseGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *PingDatabaseGatewaySuite) TestCheck_PingSucceeds_ReturnNil() {
+	s.mockDb.ExpectPing()
+
+	s.Assert().NoError(s.gateway.Check(context.Background()))
+	s.Assert().NoError(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *PingDatabaseGatewaySuite) TestCheck_PingFails_ReturnError() {
+	s.mockDb.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	s.Assert().EqualError(s.gateway.Check(context.Background()), "connection refused")
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"sync"
+)
+
+var ErrSigningKeyMismatch = errors.New("access tokens signed with the private key do not verify with the public key")
+
+// SigningKeyChecker checks that access tokens can be signed, and that tokens
+// signed with the private key of the login verify with the public key of the
+// authentication, which a mismatched key pair would break on every request.
+// The keys never change once loaded, so only the first check signs and later
+// ones return its result.
+type SigningKeyChecker struct {
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey
+
+	once sync.Once
+	err  error
+}
+
+func NewSigningKeyChecker(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) *SigningKeyChecker {
+	return &SigningKeyChecker{privateKey: privateKey, publicKey: publicKey}
+}
+
+func (c *SigningKeyChecker) Check(_ context.Context) error {
+	c.once.Do(func() {
+		c.err = c.check()
+	})
+
+	return c.err
+}
+
+func (c *SigningKeyChecker) check() error {
+	digest := sha256.Sum256([]byte("readiness probe"))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return err
+	}
+
+	if err := rsa.VerifyPKCS1v15(c.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return ErrSigningKeyMismatch
+	}
+
+	return nil
+}
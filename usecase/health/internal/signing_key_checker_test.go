@@ -0,0 +1,51 @@
+package internal_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/health/internal"
+)
+
+type SigningKeyCheckerSuite struct {
+	suite.Suite
+
+	privateKey *rsa.PrivateKey
+}
+
+func TestSigningKeyCheckerSuite(t *testing.T) {
+	suite.Run(t, &SigningKeyCheckerSuite{})
+}
+
+func (s *SigningKeyCheckerSuite) SetupTest() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.privateKey = privateKey
+}
+
+func (s *SigningKeyCheckerSuite) TestCheck_MatchingKeyPair_ReturnNil() {
+	checker := internal.NewSigningKeyChecker(s.privateKey, &s.privateKey.PublicKey)
+
+	s.Assert().NoError(checker.Check(context.Background()))
+}
+
+func (s *SigningKeyCheckerSuite) TestCheck_MismatchedKeyPair_ReturnErrSigningKeyMismatch() {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	checker := internal.NewSigningKeyChecker(s.privateKey, &other.PublicKey)
+
+	s.Assert().ErrorIs(checker.Check(context.Background()), internal.ErrSigningKeyMismatch)
+}
+
+func (s *SigningKeyCheckerSuite) TestCheck_CalledTwice_ReturnFirstResult() {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	checker := internal.NewSigningKeyChecker(s.privateKey, &other.PublicKey)
+
+	a := s.Assert()
+	a.ErrorIs(checker.Check(context.Background()), internal.ErrSigningKeyMismatch)
+	a.ErrorIs(checker.Check(context.Background()), internal.ErrSigningKeyMismatch)
+}
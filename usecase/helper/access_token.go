@@ -0,0 +1,90 @@
+package helper
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	AccessTokenIssuer   = "littlerollingsushi.com"
+	AccessTokenAudience = "littlerollingsushi.com"
+)
+
+var (
+	ErrMissingAccessToken = errors.New("access token is missing")
+	ErrInvalidAccessToken = errors.New("access token is not valid")
+)
+
+type accessTokenClaimsContextKey struct{}
+
+type AccessTokenClaims struct {
+	jwt.RegisteredClaims
+	UserID      int64    `json:"uid,omitempty"`
+	SessionID   int64    `json:"sid,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// HasPermission reports whether the claims grant permission, either directly,
+// through a "resource:*" wildcard or through the "*" super permission.
+func (c *AccessTokenClaims) HasPermission(permission string) bool {
+	resource, _, _ := strings.Cut(permission, ":")
+	for _, granted := range c.Permissions {
+		if granted == permission || granted == "*" || granted == resource+":*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+type AccessTokenVerifier struct {
+	publicKey *rsa.PublicKey
+	timer     Timer
+}
+
+func NewAccessTokenVerifier(publicKey *rsa.PublicKey, timer Timer) *AccessTokenVerifier {
+	return &AccessTokenVerifier{publicKey: publicKey, timer: timer}
+}
+
+// VerifyAuthorizationHeader extracts the bearer token from an Authorization
+// header value and verifies it.
+func (v *AccessTokenVerifier) VerifyAuthorizationHeader(header string) (*AccessTokenClaims, error) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrMissingAccessToken
+	}
+
+	return v.Verify(token)
+}
+
+func (v *AccessTokenVerifier) Verify(token string) (*AccessTokenClaims, error) {
+	claims := &AccessTokenClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithoutClaimsValidation())
+	if _, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.publicKey, nil
+	}); err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+
+	now := v.timer.NowInUTC()
+	if !claims.VerifyExpiresAt(now, true) || !claims.VerifyNotBefore(now, true) ||
+		!claims.VerifyIssuer(AccessTokenIssuer, true) || !claims.VerifyAudience(AccessTokenAudience, true) {
+		return nil, ErrInvalidAccessToken
+	}
+
+	return claims, nil
+}
+
+func ContextWithAccessTokenClaims(ctx context.Context, claims *AccessTokenClaims) context.Context {
+	return context.WithValue(ctx, accessTokenClaimsContextKey{}, claims)
+}
+
+func AccessTokenClaimsFromContext(ctx context.Context) (*AccessTokenClaims, bool) {
+	claims, ok := ctx.Value(accessTokenClaimsContextKey{}).(*AccessTokenClaims)
+	return claims, ok
+}
@@ -0,0 +1,93 @@
+package helper_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AccessTokenVerifierSuite struct {
+	suite.Suite
+
+	priv     *rsa.PrivateKey
+	timer    *mocks.Timer
+	verifier *helper.AccessTokenVerifier
+
+	now    time.Time
+	claims helper.AccessTokenClaims
+}
+
+func TestAccessTokenVerifierSuite(t *testing.T) {
+	suite.Run(t, &AccessTokenVerifierSuite{})
+}
+
+func (s *AccessTokenVerifierSuite) SetupTest() {
+	s.priv, _ = rsa.GenerateKey(rand.Reader, 2048)
+	s.timer = mocks.NewTimer(s.T())
+	s.verifier = helper.NewAccessTokenVerifier(&s.priv.PublicKey, s.timer)
+
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.claims = helper.AccessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    helper.AccessTokenIssuer,
+			Audience:  jwt.ClaimStrings{helper.AccessTokenAudience},
+			Subject:   "john.doe@email.com",
+			IssuedAt:  jwt.NewNumericDate(s.now),
+			NotBefore: jwt.NewNumericDate(s.now),
+			ExpiresAt: jwt.NewNumericDate(s.now.Add(time.Hour)),
+		},
+		Roles:       []string{"support"},
+		Permissions: []string{"users:read"},
+	}
+}
+
+func (s *AccessTokenVerifierSuite) sign(claims helper.AccessTokenClaims, key *rsa.PrivateKey) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, &claims).SignedString(key)
+	return token
+}
+
+func (s *AccessTokenVerifierSuite) TestVerifyAuthorizationHeader_NotBearer_ReturnMissingErr() {
+	claims, err := s.verifier.VerifyAuthorizationHeader("Basic am9objpkb2U=")
+
+	a := s.Assert()
+	a.Nil(claims)
+	a.ErrorIs(err, helper.ErrMissingAccessToken)
+}
+
+func (s *AccessTokenVerifierSuite) TestVerify_SignedByOtherKey_ReturnInvalidErr() {
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	claims, err := s.verifier.Verify(s.sign(s.claims, other))
+
+	a := s.Assert()
+	a.Nil(claims)
+	a.ErrorIs(err, helper.ErrInvalidAccessToken)
+}
+
+func (s *AccessTokenVerifierSuite) TestVerify_Expired_ReturnInvalidErr() {
+	s.timer.On("NowInUTC").Return(s.now.Add(time.Hour))
+
+	claims, err := s.verifier.Verify(s.sign(s.claims, s.priv))
+
+	a := s.Assert()
+	a.Nil(claims)
+	a.ErrorIs(err, helper.ErrInvalidAccessToken)
+}
+
+func (s *AccessTokenVerifierSuite) TestVerifyAuthorizationHeader_ValidToken_ReturnClaims() {
+	s.timer.On("NowInUTC").Return(s.now)
+
+	claims, err := s.verifier.VerifyAuthorizationHeader("Bearer " + s.sign(s.claims, s.priv))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.claims.Subject, claims.Subject)
+	a.Equal(s.claims.Roles, claims.Roles)
+	a.Equal(s.claims.Permissions, claims.Permissions)
+}
@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"context"
+	"log/slog"
+)
+
+//go:generate mockery --name=MailSender --output=./mocks
+type MailSender interface {
+	SendMail(ctx context.Context, mail Mail) error
+}
+
+// AsyncMailer queues mails and sends them from Run, so callers never wait for
+// the SMTP server.
+type AsyncMailer struct {
+	mailer MailSender
+	queue  chan Mail
+	logger *slog.Logger
+}
+
+func NewAsyncMailer(mailer MailSender, queueSize int, logger *slog.Logger) *AsyncMailer {
+	return &AsyncMailer{mailer: mailer, queue: make(chan Mail, queueSize), logger: logger}
+}
+
+// SendMail never blocks. When the queue is full the mail is dropped and
+// logged, a notification is not worth slowing a request down.
+func (m *AsyncMailer) SendMail(_ context.Context, mail Mail) error {
+	select {
+	case m.queue <- mail:
+	default:
+		m.logger.Warn("mail queue is full, dropping mail", "subject", mail.Subject)
+	}
+
+	return nil
+}
+
+// Run sends queued mails until ctx is done, then sends what is left in the
+// queue before returning.
+func (m *AsyncMailer) Run(ctx context.Context) {
+	for {
+		select {
+		case mail := <-m.queue:
+			m.send(mail)
+		case <-ctx.Done():
+			for {
+				select {
+				case mail := <-m.queue:
+					m.send(mail)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (m *AsyncMailer) send(mail Mail) {
+	if err := m.mailer.SendMail(context.Background(), mail); err != nil {
+		m.logger.Error("sending mail failed", "subject", mail.Subject, "error", err)
+	}
+}
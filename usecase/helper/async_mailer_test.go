@@ -0,0 +1,77 @@
+package helper_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type AsyncMailerSuite struct {
+	suite.Suite
+
+	mailer      *mocks.MailSender
+	asyncMailer *helper.AsyncMailer
+
+	context context.Context
+	mail    helper.Mail
+}
+
+func TestAsyncMailerSuite(t *testing.T) {
+	suite.Run(t, &AsyncMailerSuite{})
+}
+
+func (s *AsyncMailerSuite) SetupTest() {
+	s.mailer = mocks.NewMailSender(s.T())
+	s.asyncMailer = helper.NewAsyncMailer(s.mailer, 1, logging.New(io.Discard, slog.LevelInfo))
+
+	s.context = context.Background()
+	s.mail = helper.Mail{To: "john.doe@email.com", Subject: "New sign-in to your account", Body: "body"}
+}
+
+func (s *AsyncMailerSuite) TestSendMail_QueueFull_DropMailWithoutBlocking() {
+	a := s.Assert()
+	a.Nil(s.asyncMailer.SendMail(s.context, s.mail))
+	a.Nil(s.asyncMailer.SendMail(s.context, s.mail))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.mailer.On("SendMail", mock.Anything, s.mail).Return(nil).Once()
+
+	s.asyncMailer.Run(ctx)
+}
+
+func (s *AsyncMailerSuite) TestRun_QueuedMail_SendMail() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mailer.On("SendMail", mock.Anything, s.mail).Run(func(mock.Arguments) { cancel() }).Return(errors.New("mock error"))
+
+	done := make(chan struct{})
+	go func() {
+		s.asyncMailer.Run(ctx)
+		close(done)
+	}()
+	s.asyncMailer.SendMail(s.context, s.mail)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("mailer did not stop after context cancellation")
+	}
+}
+
+func (s *AsyncMailerSuite) TestRun_ContextCanceled_DrainQueue() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.asyncMailer.SendMail(s.context, s.mail)
+	s.mailer.On("SendMail", mock.Anything, s.mail).Return(nil).Once()
+
+	s.asyncMailer.Run(ctx)
+}
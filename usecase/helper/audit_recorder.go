@@ -0,0 +1,13 @@
+package helper
+
+import (
+	"context"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+// AuditRecorder appends events to the audit log. Usecases embed it in their
+// gateway, the implementation is built by the audit constructor.
+type AuditRecorder interface {
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
+}
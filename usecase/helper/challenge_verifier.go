@@ -0,0 +1,10 @@
+package helper
+
+import "context"
+
+// ChallengeVerifier checks a solved proof-of-work challenge. It returns false
+// for a missing, tampered, expired, reused or wrongly solved challenge, and an
+// error only when the check itself failed.
+type ChallengeVerifier interface {
+	VerifyChallenge(ctx context.Context, token, nonce string) (bool, error)
+}
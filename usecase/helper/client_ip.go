@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type clientIPContextKey struct{}
+
+func ContextWithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, clientIP)
+}
+
+// ClientIP returns the IP address of the client that sent the request, as the
+// ClientIP middleware resolved it behind the trusted proxies, or the address
+// of the peer when the middleware did not run.
+func ClientIP(r *http.Request) string {
+	if clientIP, ok := r.Context().Value(clientIPContextKey{}).(string); ok {
+		return clientIP
+	}
+
+	return peerIP(r)
+}
+
+func peerIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// TrustedProxies are the networks of the proxies in front of the API, whose
+// X-Forwarded-For entries can be believed.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses CIDRs or single IP addresses.
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	proxies := TrustedProxies{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", value)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
+
+// ClientIP returns the address of the client behind the trusted proxies. The
+// X-Forwarded-For chain is walked from the peer backwards and stops at the
+// first hop that is not a trusted proxy, as anything before it can be forged
+// by the client.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	clientIP := peerIP(r)
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0 && p.trusts(clientIP); i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		clientIP = hop
+	}
+
+	return clientIP
+}
+
+func (p TrustedProxies) trusts(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package helper_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type TrustedProxiesSuite struct {
+	suite.Suite
+
+	request *http.Request
+	proxies helper.TrustedProxies
+}
+
+func TestTrustedProxiesSuite(t *testing.T) {
+	suite.Run(t, &TrustedProxiesSuite{})
+}
+
+func (s *TrustedProxiesSuite) SetupTest() {
+	s.request = httptest.NewRequest("GET", "http://test.com/v1/me", nil)
+	s.request.RemoteAddr = "10.0.0.2:51234"
+
+	proxies, err := helper.ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	s.Require().NoError(err)
+	s.proxies = proxies
+}
+
+func (s *TrustedProxiesSuite) TestParseTrustedProxies_Invalid_ReturnError() {
+	_, err := helper.ParseTrustedProxies([]string{"10.0.0.0/33"})
+	s.Assert().Error(err)
+
+	_, err = helper.ParseTrustedProxies([]string{"proxy.local"})
+	s.Assert().Error(err)
+}
+
+func (s *TrustedProxiesSuite) TestClientIP_NoForwardedFor_ReturnPeer() {
+	s.Assert().Equal("10.0.0.2", s.proxies.ClientIP(s.request))
+}
+
+func (s *TrustedProxiesSuite) TestClientIP_TrustedPeer_ReturnForwardedClient() {
+	s.request.Header.Set("X-Forwarded-For", "203.0.113.7, 192.168.1.1")
+
+	s.Assert().Equal("203.0.113.7", s.proxies.ClientIP(s.request))
+}
+
+func (s *TrustedProxiesSuite) TestClientIP_ForgedHops_StopAtFirstUntrustedHop() {
+	s.request.Header.Set("X-Forwarded-For", "1.2.3.4, 203.0.113.7")
+
+	s.Assert().Equal("203.0.113.7", s.proxies.ClientIP(s.request))
+}
+
+func (s *TrustedProxiesSuite) TestClientIP_UntrustedPeer_IgnoreForwardedFor() {
+	s.request.RemoteAddr = "198.51.100.9:51234"
+	s.request.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	s.Assert().Equal("198.51.100.9", s.proxies.ClientIP(s.request))
+}
+
+func (s *TrustedProxiesSuite) TestClientIP_NoTrustedProxies_ReturnPeer() {
+	s.request.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	s.Assert().Equal("10.0.0.2", helper.TrustedProxies{}.ClientIP(s.request))
+}
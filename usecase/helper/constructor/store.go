@@ -0,0 +1,48 @@
+package constructor
+
+import (
+	"github.com/redis/go-redis/v9"
+
+	"littlerollingsushi.com/example/config"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const (
+	StoreBackendMemory = "memory"
+	StoreBackendRedis  = "redis"
+
+	// RedisKeyPrefix namespaces every key the application stores in Redis.
+	RedisKeyPrefix = "example:"
+)
+
+// ConstructRedisClient returns the client every Redis backed store shares, so
+// they use one connection pool, or nil with the in-memory backend. The caller
+// closes it on shutdown.
+func ConstructRedisClient(cfg *config.Config) *redis.Client {
+	if cfg.Store.Backend != StoreBackendRedis {
+		return nil
+	}
+
+	return helper.NewRedisClient(cfg.Redis)
+}
+
+// ConstructCounterStore returns the CounterStore of the configured backend,
+// with keys namespaced by name. The in-memory backend only works with a
+// single instance.
+func ConstructCounterStore(cfg *config.Config, redisClient *redis.Client, name string) helper.CounterStore {
+	if cfg.Store.Backend == StoreBackendRedis {
+		return helper.NewRedisStore(redisClient, RedisKeyPrefix+name+":")
+	}
+
+	return helper.NewMemoryStore(&helper.TimerImplementation{})
+}
+
+// ConstructTTLSet returns the TTLSet of the configured backend, with members
+// namespaced by name.
+func ConstructTTLSet(cfg *config.Config, redisClient *redis.Client, name string) helper.TTLSet {
+	if cfg.Store.Backend == StoreBackendRedis {
+		return helper.NewRedisStore(redisClient, RedisKeyPrefix+name+":")
+	}
+
+	return helper.NewMemoryStore(&helper.TimerImplementation{})
+}
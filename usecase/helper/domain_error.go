@@ -0,0 +1,29 @@
+package helper
+
+// ErrorKind groups domain errors by how they are reported to clients.
+type ErrorKind int
+
+const (
+	ErrorKindInternal ErrorKind = iota
+	ErrorKindInvalid
+	ErrorKindUnauthenticated
+	ErrorKindForbidden
+	ErrorKindConflict
+	ErrorKindUnprocessable
+)
+
+// DomainError is an expected failure of a usecase. Code identifies it to
+// clients and Message is safe to show them; Reason says what actually went
+// wrong and is only meant for logs and the audit trail. Usecases declare them
+// as sentinels, so callers can match them with errors.Is, or with errors.As
+// to get at the kind and code.
+type DomainError struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Reason  string
+}
+
+func (e *DomainError) Error() string {
+	return e.Reason
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type MailerConfig struct {
+	Host     string `env:"HOST" default:"127.0.0.1"`
+	Port     int    `env:"PORT" default:"1025"`
+	Username string `env:"USERNAME"`
+	Password string `env:"PASSWORD" secret:"true"`
+	From     string `env:"FROM" default:"no-reply@littlerollingsushi.com"`
+}
+
+type SMTPMailer struct {
+	config MailerConfig
+}
+
+func NewSMTPMailer(config MailerConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) SendMail(_ context.Context, mail Mail) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	return smtp.SendMail(addr, auth, m.config.From, []string{mail.To}, m.buildMessage(mail))
+}
+
+func (m *SMTPMailer) buildMessage(mail Mail) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mail.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(mail.Body)
+	return []byte(b.String())
+}
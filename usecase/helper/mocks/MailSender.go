@@ -0,0 +1,44 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	helper "littlerollingsushi.com/example/usecase/helper"
+)
+
+// MailSender is an autogenerated mock type for the MailSender type
+type MailSender struct {
+	mock.Mock
+}
+
+// SendMail provides a mock function with given fields: ctx, mail
+func (_m *MailSender) SendMail(ctx context.Context, mail helper.Mail) error {
+	ret := _m.Called(ctx, mail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, helper.Mail) error); ok {
+		r0 = rf(ctx, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMailSender interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMailSender creates a new instance of MailSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMailSender(t mockConstructorTestingTNewMailSender) *MailSender {
+	mock := &MailSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,34 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordHashObserver is an autogenerated mock type for the PasswordHashObserver type
+type PasswordHashObserver struct {
+	mock.Mock
+}
+
+// ObservePasswordHash provides a mock function with given fields: operation, duration
+func (_m *PasswordHashObserver) ObservePasswordHash(operation string, duration time.Duration) {
+	_m.Called(operation, duration)
+}
+
+type mockConstructorTestingTNewPasswordHashObserver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordHashObserver creates a new instance of PasswordHashObserver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordHashObserver(t mockConstructorTestingTNewPasswordHashObserver) *PasswordHashObserver {
+	mock := &PasswordHashObserver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalDataExporter is an autogenerated mock type for the PersonalDataExporter type
+type PersonalDataExporter struct {
+	mock.Mock
+}
+
+// ExportPersonalData provides a mock function with given fields: ctx, user
+func (_m *PersonalDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) interface{}); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalDataSection provides a mock function with given fields:
+func (_m *PersonalDataExporter) PersonalDataSection() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPersonalDataExporter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPersonalDataExporter creates a new instance of PersonalDataExporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPersonalDataExporter(t mockConstructorTestingTNewPersonalDataExporter) *PersonalDataExporter {
+	mock := &PersonalDataExporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TTLSet is an autogenerated mock type for the TTLSet type
+type TTLSet struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: ctx, member, ttl
+func (_m *TTLSet) Add(ctx context.Context, member string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, member, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, member, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, member, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Contains provides a mock function with given fields: ctx, member
+func (_m *TTLSet) Contains(ctx context.Context, member string) (bool, error) {
+	ret := _m.Called(ctx, member)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTTLSet interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTTLSet creates a new instance of TTLSet. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTTLSet(t mockConstructorTestingTNewTTLSet) *TTLSet {
+	mock := &TTLSet{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,15 +1,39 @@
 package helper
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"time"
 
-type PasswordEncrypter struct{}
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate mockery --name=PasswordHashObserver --output=./mocks
+type PasswordHashObserver interface {
+	ObservePasswordHash(operation string, duration time.Duration)
+}
+
+// PasswordEncrypter hashes passwords with bcrypt. When Observer is set, it is
+// told how long every hash and comparison took, as their cost grows with the
+// bcrypt cost and shows in request latency.
+type PasswordEncrypter struct {
+	Observer PasswordHashObserver
+}
+
+func (e *PasswordEncrypter) EncryptPassword(password string, saltLength int) (cryptedPassword string, err error) {
+	defer e.observe("hash", time.Now())
 
-func (*PasswordEncrypter) EncryptPassword(password string, saltLength int) (cryptedPassword string, err error) {
 	crypted, err := bcrypt.GenerateFromPassword([]byte(password), saltLength)
 	return string(crypted), err
 }
 
-func (*PasswordEncrypter) IsHashAndPasswordEqual(hash, password string) bool {
+func (e *PasswordEncrypter) IsHashAndPasswordEqual(hash, password string) bool {
+	defer e.observe("compare", time.Now())
+
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+func (e *PasswordEncrypter) observe(operation string, start time.Time) {
+	if e.Observer != nil {
+		e.Observer.ObservePasswordHash(operation, time.Since(start))
+	}
+}
@@ -0,0 +1,49 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type PasswordEncrypterSuite struct {
+	suite.Suite
+
+	observer  *mocks.PasswordHashObserver
+	encrypter *helper.PasswordEncrypter
+}
+
+func TestPasswordEncrypterSuite(t *testing.T) {
+	suite.Run(t, &PasswordEncrypterSuite{})
+}
+
+func (s *PasswordEncrypterSuite) SetupTest() {
+	s.observer = mocks.NewPasswordHashObserver(s.T())
+	s.encrypter = &helper.PasswordEncrypter{Observer: s.observer}
+}
+
+func (s *PasswordEncrypterSuite) TestEncryptPassword_ObserveHashAndCompare() {
+	s.observer.On("ObservePasswordHash", "hash", mock.AnythingOfType("time.Duration")).Once()
+	s.observer.On("ObservePasswordHash", "compare", mock.AnythingOfType("time.Duration")).Twice()
+
+	hash, err := s.encrypter.EncryptPassword("very secure password", bcrypt.MinCost)
+
+	a := s.Assert()
+	a.NoError(err)
+	a.True(s.encrypter.IsHashAndPasswordEqual(hash, "very secure password"))
+	a.False(s.encrypter.IsHashAndPasswordEqual(hash, "wrong password"))
+}
+
+func (s *PasswordEncrypterSuite) TestEncryptPassword_NoObserver_Hash() {
+	encrypter := &helper.PasswordEncrypter{}
+
+	hash, err := encrypter.EncryptPassword("very secure password", bcrypt.MinCost)
+
+	a := s.Assert()
+	a.NoError(err)
+	a.True(encrypter.IsHashAndPasswordEqual(hash, "very secure password"))
+}
@@ -0,0 +1,17 @@
+package helper
+
+import (
+	"context"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+// PersonalDataExporter is implemented by every usecase package storing data
+// about a user, so the data can be handed out in the personal data export.
+// The returned value must be JSON encodable.
+//
+//go:generate mockery --name=PersonalDataExporter --output=./mocks
+type PersonalDataExporter interface {
+	PersonalDataSection() string
+	ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error)
+}
@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrementScript starts the expiry with the first increment only, so the
+// window is not extended by every later event.
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+type RedisConfig struct {
+	Addr     string `env:"ADDR" default:"127.0.0.1:6379"`
+	Password string `env:"PASSWORD" secret:"true"`
+	DB       int    `env:"DB"`
+}
+
+func NewRedisClient(config RedisConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+}
+
+// RedisStore implements CounterStore and TTLSet on a Redis server shared by
+// every instance. Keys are namespaced with prefix.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrementScript.Run(ctx, s.client, []string{s.prefix + key}, ttl.Milliseconds()).Int64()
+}
+
+func (s *RedisStore) Count(ctx context.Context, key string) (int64, error) {
+	count, err := s.client.Get(ctx, s.prefix+key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return count, err
+}
+
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+func (s *RedisStore) Add(ctx context.Context, member string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+member, "1", ttl).Result()
+}
+
+func (s *RedisStore) Contains(ctx context.Context, member string) (bool, error) {
+	n, err := s.client.Exists(ctx, s.prefix+member).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,116 @@
+package helper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type RedisStoreSuite struct {
+	suite.Suite
+
+	server *miniredis.Miniredis
+	client *redis.Client
+	store  *helper.RedisStore
+
+	context context.Context
+}
+
+func TestRedisStoreSuite(t *testing.T) {
+	suite.Run(t, &RedisStoreSuite{})
+}
+
+func (s *RedisStoreSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.client = redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	s.store = helper.NewRedisStore(s.client, "example:test:")
+	s.context = context.Background()
+}
+
+func (s *RedisStoreSuite) TearDownTest() {
+	s.client.Close()
+}
+
+func (s *RedisStoreSuite) TestIncrement_WithinWindow_ReturnIncreasingCount() {
+	a := s.Assert()
+	count, err := s.store.Increment(s.context, "key", time.Minute)
+	a.Nil(err)
+	a.Equal(int64(1), count)
+
+	s.server.FastForward(30 * time.Second)
+	count, err = s.store.Increment(s.context, "key", time.Minute)
+	a.Nil(err)
+	a.Equal(int64(2), count)
+	a.Equal(30*time.Second, s.server.TTL("example:test:key"))
+}
+
+func (s *RedisStoreSuite) TestIncrement_WindowExpired_StartNewWindow() {
+	s.store.Increment(s.context, "key", time.Minute)
+	s.server.FastForward(time.Minute)
+
+	count, err := s.store.Increment(s.context, "key", time.Minute)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(1), count)
+}
+
+func (s *RedisStoreSuite) TestIncrement_ServerDown_ReturnError() {
+	s.server.Close()
+
+	_, err := s.store.Increment(s.context, "key", time.Minute)
+
+	s.Assert().NotNil(err)
+}
+
+func (s *RedisStoreSuite) TestReset_DeleteCounter() {
+	s.store.Increment(s.context, "key", time.Minute)
+
+	a := s.Assert()
+	a.Nil(s.store.Reset(s.context, "key"))
+	a.False(s.server.Exists("example:test:key"))
+}
+
+func (s *RedisStoreSuite) TestCount_ReturnCountOfCurrentWindow() {
+	a := s.Assert()
+	count, err := s.store.Count(s.context, "key")
+	a.Nil(err)
+	a.Zero(count)
+
+	s.store.Increment(s.context, "key", time.Minute)
+	s.store.Increment(s.context, "key", time.Minute)
+	count, err = s.store.Count(s.context, "key")
+	a.Nil(err)
+	a.Equal(int64(2), count)
+}
+
+func (s *RedisStoreSuite) TestAdd_ExistingMember_ReturnFalse() {
+	a := s.Assert()
+	added, err := s.store.Add(s.context, "member", time.Minute)
+	a.Nil(err)
+	a.True(added)
+
+	added, err = s.store.Add(s.context, "member", time.Minute)
+	a.Nil(err)
+	a.False(added)
+}
+
+func (s *RedisStoreSuite) TestContains_UntilTTLElapsed_ReturnTrue() {
+	a := s.Assert()
+	_, err := s.store.Add(s.context, "member", time.Minute)
+	a.Nil(err)
+
+	contains, err := s.store.Contains(s.context, "member")
+	a.Nil(err)
+	a.True(contains)
+
+	s.server.FastForward(time.Minute)
+	contains, err = s.store.Contains(s.context, "member")
+	a.Nil(err)
+	a.False(contains)
+}
@@ -0,0 +1,269 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxRequestBodyBytes is the default limit for request bodies decoded with
+// DecodeRequestBody, generous for the small forms the API accepts.
+const MaxRequestBodyBytes = 64 << 10
+
+const (
+	contentTypeJSON          = "application/json"
+	contentTypeFormURLEncode = "application/x-www-form-urlencoded"
+	contentTypeMultipartForm = "multipart/form-data"
+)
+
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported request content type")
+	ErrRequestBodyTooLarge  = errors.New("request body is too large")
+)
+
+// MalformedRequestBodyError is returned for bodies that cannot be decoded.
+// Details describe what is wrong and are safe to show to the client.
+type MalformedRequestBodyError struct {
+	Details []string
+}
+
+func (e *MalformedRequestBodyError) Error() string {
+	return "malformed request body: " + strings.Join(e.Details, "; ")
+}
+
+func malformed(format string, args ...interface{}) error {
+	return &MalformedRequestBodyError{Details: []string{fmt.Sprintf(format, args...)}}
+}
+
+// DecodeRequestBody decodes a JSON, URL-encoded or multipart form body of at
+// most maxBytes into dst, a pointer to a struct. Form fields are matched by the
+// json tag of the struct field, so one struct serves both encodings; only
+// string and bool fields can be filled from a form. Fields dst does not declare
+// are rejected in either encoding.
+func DecodeRequestBody(w http.ResponseWriter, r *http.Request, maxBytes int64, dst interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ErrUnsupportedMediaType
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	switch mediaType {
+	case contentTypeJSON:
+		return decodeJSONBody(r, dst)
+	case contentTypeFormURLEncode, contentTypeMultipartForm:
+		return decodeFormBody(r, mediaType, maxBytes, dst)
+	default:
+		return ErrUnsupportedMediaType
+	}
+}
+
+// PeekRequestBodyField returns the string value of a top-level field of a JSON
+// or form body without consuming it, so middleware can look at a field before
+// the handler decodes the body. It returns an empty string when the field is
+// missing or the body cannot be read.
+func PeekRequestBodyField(r *http.Request, maxBytes int64, field string) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBytes))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil {
+		return ""
+	}
+
+	switch mediaType {
+	case contentTypeJSON:
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+
+		var value string
+		if err := json.Unmarshal(fields[field], &value); err != nil {
+			return ""
+		}
+
+		return value
+	case contentTypeFormURLEncode, contentTypeMultipartForm:
+		clone := r.Clone(r.Context())
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		if err := parseForm(clone, mediaType, maxBytes); err != nil {
+			return ""
+		}
+
+		return clone.PostFormValue(field)
+	default:
+		return ""
+	}
+}
+
+func decodeJSONBody(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return jsonBodyError(err)
+	}
+
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrRequestBodyTooLarge
+		}
+		return malformed("body must contain a single JSON object")
+	}
+
+	return nil
+}
+
+func jsonBodyError(err error) error {
+	var (
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		maxBytesErr  *http.MaxBytesError
+		invalidField = "json: unknown field "
+	)
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return ErrRequestBodyTooLarge
+	case errors.As(err, &syntaxErr):
+		return malformed("body contains malformed JSON at position %d", syntaxErr.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return malformed("body contains malformed JSON")
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return malformed("body must be a JSON object")
+		}
+		return malformed("field %q must be a %s", typeErr.Field, typeErr.Type)
+	case strings.HasPrefix(err.Error(), invalidField):
+		return malformed("unknown field %s", strings.TrimPrefix(err.Error(), invalidField))
+	case errors.Is(err, io.EOF):
+		return malformed("body must not be empty")
+	default:
+		return err
+	}
+}
+
+func decodeFormBody(r *http.Request, mediaType string, maxBytes int64, dst interface{}) error {
+	if err := parseForm(r, mediaType, maxBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrRequestBodyTooLarge
+		}
+		return malformed("body contains a malformed form")
+	}
+
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode form body: destination must be a pointer to a struct, got %T", dst)
+	}
+	value = value.Elem()
+
+	fields := map[string]int{}
+	for i := 0; i < value.NumField(); i++ {
+		if name := formFieldName(value.Type().Field(i)); name != "" {
+			fields[name] = i
+		}
+	}
+
+	details := []string{}
+	for name, values := range r.PostForm {
+		i, ok := fields[name]
+		if !ok {
+			details = append(details, fmt.Sprintf("unknown field %q", name))
+			continue
+		}
+
+		if err := setFormField(value.Field(i), values[0]); err != nil {
+			details = append(details, fmt.Sprintf("field %q must be a %s", name, value.Field(i).Kind()))
+		}
+	}
+	if r.MultipartForm != nil {
+		for name := range r.MultipartForm.File {
+			details = append(details, fmt.Sprintf("unknown field %q", name))
+		}
+	}
+
+	if len(details) > 0 {
+		sort.Strings(details)
+		return &MalformedRequestBodyError{Details: details}
+	}
+
+	return nil
+}
+
+// parseForm parses URL-encoded and multipart bodies into r.PostForm whatever
+// the method. ParseForm ignores the body of DELETE requests, and unlike
+// ParseMultipartForm this does not swallow errors reading an URL-encoded body.
+func parseForm(r *http.Request, mediaType string, maxBytes int64) error {
+	if mediaType == contentTypeMultipartForm {
+		return r.ParseMultipartForm(maxBytes)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+	r.PostForm = values
+
+	return nil
+}
+
+func formFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+func setFormField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		if value == "" {
+			field.SetBool(false)
+			return nil
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported form field kind %s", field.Kind())
+	}
+
+	return nil
+}
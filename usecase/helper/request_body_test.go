@@ -0,0 +1,197 @@
+package helper_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+type RequestBodySuite struct {
+	suite.Suite
+
+	responseWriter *httptest.ResponseRecorder
+}
+
+type requestBody struct {
+	Email    string `json:"email"`
+	Remember bool   `json:"remember"`
+}
+
+func TestRequestBodySuite(t *testing.T) {
+	suite.Run(t, &RequestBodySuite{})
+}
+
+func (s *RequestBodySuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+}
+
+func (s *RequestBodySuite) newRequest(contentType, body string) *http.Request {
+	r := httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func (s *RequestBodySuite) decode(r *http.Request) (requestBody, error) {
+	dst := requestBody{}
+	err := helper.DecodeRequestBody(s.responseWriter, r, 64, &dst)
+	return dst, err
+}
+
+func (s *RequestBodySuite) assertMalformed(err error, details ...string) {
+	a := s.Assert()
+	malformedErr, ok := err.(*helper.MalformedRequestBodyError)
+	if a.True(ok, "expected a malformed request body error, got %v", err) {
+		a.Equal(details, malformedErr.Details)
+	}
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSON_DecodeFields() {
+	dst, err := s.decode(s.newRequest("application/json; charset=utf-8", `{"email":"john.doe@email.com","remember":true}`))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(requestBody{Email: "john.doe@email.com", Remember: true}, dst)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_URLEncodedForm_DecodeFields() {
+	dst, err := s.decode(s.newRequest("application/x-www-form-urlencoded", "email=john.doe%40email.com&remember=true"))
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(requestBody{Email: "john.doe@email.com", Remember: true}, dst)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_URLEncodedFormOfDelete_DecodeFields() {
+	r := httptest.NewRequest("DELETE", "http://test.com/v1/me", strings.NewReader("email=john.doe%40email.com"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	dst, err := s.decode(r)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(requestBody{Email: "john.doe@email.com"}, dst)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_MultipartForm_DecodeFields() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("email", "john.doe@email.com")
+	writer.Close()
+
+	dst := requestBody{}
+	err := helper.DecodeRequestBody(s.responseWriter, s.newRequest(writer.FormDataContentType(), body.String()), 1024, &dst)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(requestBody{Email: "john.doe@email.com"}, dst)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_UnsupportedContentType_ReturnErrUnsupportedMediaType() {
+	_, err := s.decode(s.newRequest("text/plain", "john.doe@email.com"))
+
+	s.Assert().ErrorIs(err, helper.ErrUnsupportedMediaType)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_MissingContentType_ReturnErrUnsupportedMediaType() {
+	_, err := s.decode(s.newRequest("", `{"email":"john.doe@email.com"}`))
+
+	s.Assert().ErrorIs(err, helper.ErrUnsupportedMediaType)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSONTooLarge_ReturnErrRequestBodyTooLarge() {
+	_, err := s.decode(s.newRequest("application/json", `{"email":"`+strings.Repeat("a", 64)+`"}`))
+
+	s.Assert().ErrorIs(err, helper.ErrRequestBodyTooLarge)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_FormTooLarge_ReturnErrRequestBodyTooLarge() {
+	_, err := s.decode(s.newRequest("application/x-www-form-urlencoded", "email="+strings.Repeat("a", 64)))
+
+	s.Assert().ErrorIs(err, helper.ErrRequestBodyTooLarge)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_MalformedJSON_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `{"email":}`))
+
+	s.assertMalformed(err, "body contains malformed JSON at position 10")
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_TruncatedJSON_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `{"email":"john`))
+
+	s.assertMalformed(err, "body contains malformed JSON")
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_EmptyJSON_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", ""))
+
+	s.assertMalformed(err, "body must not be empty")
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSONWrongType_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `{"email":42}`))
+
+	s.assertMalformed(err, `field "email" must be a string`)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSONArray_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `["john.doe@email.com"]`))
+
+	s.assertMalformed(err, "body must be a JSON object")
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSONUnknownField_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `{"username":"john"}`))
+
+	s.assertMalformed(err, `unknown field "username"`)
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_JSONTrailingData_ReturnDetails() {
+	_, err := s.decode(s.newRequest("application/json", `{"email":"a"}{"email":"b"}`))
+
+	s.assertMalformed(err, "body must contain a single JSON object")
+}
+
+func (s *RequestBodySuite) TestDecodeRequestBody_FormUnknownAndInvalidFields_ReturnAllDetails() {
+	_, err := s.decode(s.newRequest("application/x-www-form-urlencoded", "username=john&remember=maybe"))
+
+	s.assertMalformed(err, `field "remember" must be a bool`, `unknown field "username"`)
+}
+
+func (s *RequestBodySuite) TestPeekRequestBodyField_JSON_ReturnValueAndKeepBody() {
+	r := s.newRequest("application/json", `{"email":"john.doe@email.com"}`)
+
+	value := helper.PeekRequestBodyField(r, 64, "email")
+
+	body, _ := io.ReadAll(r.Body)
+	a := s.Assert()
+	a.Equal("john.doe@email.com", value)
+	a.Equal(`{"email":"john.doe@email.com"}`, string(body))
+}
+
+func (s *RequestBodySuite) TestPeekRequestBodyField_Form_ReturnValueAndKeepBody() {
+	r := s.newRequest("application/x-www-form-urlencoded", "email=john.doe%40email.com")
+
+	value := helper.PeekRequestBodyField(r, 64, "email")
+
+	dst, err := s.decode(r)
+	a := s.Assert()
+	a.Equal("john.doe@email.com", value)
+	a.Nil(err)
+	a.Equal("john.doe@email.com", dst.Email)
+}
+
+func (s *RequestBodySuite) TestPeekRequestBodyField_NonStringField_ReturnEmpty() {
+	r := s.newRequest("application/json", `{"email":42}`)
+
+	s.Assert().Empty(helper.PeekRequestBodyField(r, 64, "email"))
+}
@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+)
+
+var (
+	ErrInvalidPEM = errors.New("file does not contain a valid PEM block")
+)
+
+func LoadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	return block, nil
+}
@@ -0,0 +1,134 @@
+package helper
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CounterStore counts events per key in windows that start with the first
+// event and expire after ttl, e.g. failed logins counted for a lockout.
+//
+//go:generate mockery --name=CounterStore --output=./mocks
+type CounterStore interface {
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	Count(ctx context.Context, key string) (int64, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// TTLSet holds members that expire on their own, e.g. revoked token ids kept
+// until the token would have expired anyway. Add reports whether the member
+// was not in the set yet.
+//
+//go:generate mockery --name=TTLSet --output=./mocks
+type TTLSet interface {
+	Add(ctx context.Context, member string, ttl time.Duration) (bool, error)
+	Contains(ctx context.Context, member string) (bool, error)
+}
+
+type memoryCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryStore implements CounterStore and TTLSet in process memory. It is only
+// suitable for a single instance.
+type MemoryStore struct {
+	timer     Timer
+	mu        sync.Mutex
+	counters  map[string]memoryCounter
+	members   map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore(timer Timer) *MemoryStore {
+	return &MemoryStore{
+		timer:    timer,
+		counters: map[string]memoryCounter{},
+		members:  map[string]time.Time{},
+	}
+}
+
+func (s *MemoryStore) Increment(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	now := s.timer.NowInUTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = memoryCounter{expiresAt: now.Add(ttl)}
+	}
+
+	counter.count++
+	s.counters[key] = counter
+	return counter.count, nil
+}
+
+func (s *MemoryStore) Count(_ context.Context, key string) (int64, error) {
+	now := s.timer.NowInUTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		return 0, nil
+	}
+
+	return counter.count, nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+func (s *MemoryStore) Add(_ context.Context, member string, ttl time.Duration) (bool, error) {
+	now := s.timer.NowInUTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	if expiresAt, ok := s.members[member]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	s.members[member] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *MemoryStore) Contains(_ context.Context, member string) (bool, error) {
+	now := s.timer.NowInUTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.members[member]
+	return ok && now.Before(expiresAt), nil
+}
+
+// sweep drops expired entries at most once a minute so memory stays bounded.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+
+	for key, counter := range s.counters {
+		if !now.Before(counter.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+	for member, expiresAt := range s.members {
+		if !now.Before(expiresAt) {
+			delete(s.members, member)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,114 @@
+package helper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/helper/mocks"
+)
+
+type MemoryStoreSuite struct {
+	suite.Suite
+
+	context context.Context
+	timer   *mocks.Timer
+	store   *helper.MemoryStore
+	now     time.Time
+}
+
+func TestMemoryStoreSuite(t *testing.T) {
+	suite.Run(t, &MemoryStoreSuite{})
+}
+
+func (s *MemoryStoreSuite) SetupTest() {
+	s.context = context.Background()
+	s.timer = mocks.NewTimer(s.T())
+	s.store = helper.NewMemoryStore(s.timer)
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *MemoryStoreSuite) incrementAt(now time.Time) int64 {
+	s.timer.On("NowInUTC").Return(now).Once()
+	count, err := s.store.Increment(s.context, "key", time.Minute)
+	s.Require().Nil(err)
+	return count
+}
+
+func (s *MemoryStoreSuite) TestIncrement_WithinWindow_ReturnIncreasingCount() {
+	a := s.Assert()
+	a.Equal(int64(1), s.incrementAt(s.now))
+	a.Equal(int64(2), s.incrementAt(s.now.Add(59*time.Second)))
+}
+
+func (s *MemoryStoreSuite) TestIncrement_WindowExpired_StartNewWindow() {
+	s.incrementAt(s.now)
+	s.incrementAt(s.now.Add(30 * time.Second))
+
+	s.Assert().Equal(int64(1), s.incrementAt(s.now.Add(time.Minute)))
+}
+
+func (s *MemoryStoreSuite) TestReset_StartNewWindow() {
+	s.incrementAt(s.now)
+
+	s.Require().Nil(s.store.Reset(s.context, "key"))
+
+	s.Assert().Equal(int64(1), s.incrementAt(s.now))
+}
+
+func (s *MemoryStoreSuite) TestCount_ReturnCountOfCurrentWindow() {
+	s.incrementAt(s.now)
+	s.incrementAt(s.now)
+
+	a := s.Assert()
+	s.timer.On("NowInUTC").Return(s.now.Add(30 * time.Second)).Once()
+	count, err := s.store.Count(s.context, "key")
+	a.Nil(err)
+	a.Equal(int64(2), count)
+
+	s.timer.On("NowInUTC").Return(s.now.Add(time.Minute)).Once()
+	count, err = s.store.Count(s.context, "key")
+	a.Nil(err)
+	a.Zero(count)
+}
+
+func (s *MemoryStoreSuite) TestAdd_ExistingMember_ReturnFalse() {
+	s.timer.On("NowInUTC").Return(s.now)
+
+	a := s.Assert()
+	added, err := s.store.Add(s.context, "member", time.Minute)
+	a.Nil(err)
+	a.True(added)
+
+	added, err = s.store.Add(s.context, "member", time.Minute)
+	a.Nil(err)
+	a.False(added)
+}
+
+func (s *MemoryStoreSuite) TestContains_UntilTTLElapsed_ReturnTrue() {
+	s.timer.On("NowInUTC").Return(s.now).Twice()
+	_, err := s.store.Add(s.context, "member", time.Minute)
+	s.Require().Nil(err)
+
+	a := s.Assert()
+	contains, err := s.store.Contains(s.context, "member")
+	a.Nil(err)
+	a.True(contains)
+
+	s.timer.On("NowInUTC").Return(s.now.Add(time.Minute)).Once()
+	contains, err = s.store.Contains(s.context, "member")
+	a.Nil(err)
+	a.False(contains)
+}
+
+func (s *MemoryStoreSuite) TestContains_UnknownMember_ReturnFalse() {
+	s.timer.On("NowInUTC").Return(s.now)
+
+	contains, err := s.store.Contains(s.context, "member")
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(contains)
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const randomTokenLength = 32
+
+type TokenGenerator struct{}
+
+// GenerateToken returns a URL safe random token suitable to be sent to users,
+// e.g. inside confirmation links.
+func (*TokenGenerator) GenerateToken() (string, error) {
+	b := make([]byte, randomTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the value that should be persisted instead of the token
+// itself, so a leaked table can not be used to confirm anything.
+func (*TokenGenerator) HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,50 +1,54 @@
 package constructor
 
 import (
-	"crypto/x509"
+	"crypto/rsa"
 	"database/sql"
-	"encoding/pem"
-	"log"
-	"os"
+	"log/slog"
 
-	"github.com/kelseyhightower/envconfig"
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	auditConstructor "littlerollingsushi.com/example/usecase/audit/constructor"
 	"littlerollingsushi.com/example/usecase/helper"
 	"littlerollingsushi.com/example/usecase/login/handler"
 	"littlerollingsushi.com/example/usecase/login/internal"
 )
 
-type Config struct {
-	PrivateKeyPath string `envconfig:"PRIVATE_KEY_PATH"`
+// ConstructNotificationMailer returns the mailer used for login notifications.
+// Its Run method has to be started for mails to be sent.
+func ConstructNotificationMailer(cfg *config.Config, logger *slog.Logger) *helper.AsyncMailer {
+	return helper.NewAsyncMailer(helper.NewSMTPMailer(cfg.SMTP), cfg.MailQueue.Size, logger)
 }
 
-func ConstructLoginHandler(db *sql.DB) *handler.LoginHandler {
-	cfg := Config{}
-	envconfig.Process("RSA", &cfg)
-
-	rawPrivateKey, err := os.ReadFile(cfg.PrivateKeyPath)
-	if err != nil {
-		log.Fatalf("invalid raw private key PEM path: %v\n", err)
-	}
-
-	privPem, _ := pem.Decode(rawPrivateKey)
-	privKey, err := x509.ParsePKCS1PrivateKey(privPem.Bytes)
-	if err != nil {
-		log.Fatalf("invalid PCKS1 private key: %v\n", err)
-	}
-
+func ConstructLoginHandler(cfg *config.Config, db *sql.DB, mailer *helper.AsyncMailer, privateKey *rsa.PrivateKey) *handler.LoginHandler {
 	gateway := internal.NewGetUserByEmailGateway(db)
 	usecase := internal.NewLoginUsecase(
+		internal.LoginUsecaseConfig{DeletionGracePeriod: cfg.AccountDeletion.GracePeriod},
 		struct {
 			*internal.GetUserByEmailGateway
+			*internal.GetRolesByUserIDGateway
+			*internal.CreateSessionGateway
+			*internal.UserDeviceGateway
+			*helper.AsyncMailer
+			helper.AuditRecorder
 			*helper.PasswordEncrypter
 			helper.Timer
 		}{
-			GetUserByEmailGateway: gateway,
-			PasswordEncrypter:     &helper.PasswordEncrypter{},
-			Timer:                 &helper.TimerImplementation{},
+			GetUserByEmailGateway:   gateway,
+			GetRolesByUserIDGateway: internal.NewGetRolesByUserIDGateway(db),
+			CreateSessionGateway:    internal.NewCreateSessionGateway(db),
+			UserDeviceGateway:       internal.NewUserDeviceGateway(db),
+			AsyncMailer:             mailer,
+			AuditRecorder:           auditConstructor.ConstructAuditRecorder(db),
+			PasswordEncrypter:       &helper.PasswordEncrypter{Observer: metricsConstructor.ConstructMetrics()},
+			Timer:                   &helper.TimerImplementation{},
 		},
-		privKey,
+		privateKey,
 	)
 	timer := &helper.TimerImplementation{}
-	return handler.NewLoginHandler(usecase, timer)
+	counted := &countedLoginUsecase{LoginUsecase: usecase, attempts: metricsConstructor.ConstructMetrics().LoginAttempts}
+	return handler.NewLoginHandler(counted, timer)
+}
+
+func ConstructPersonalDataExporter(db *sql.DB) helper.PersonalDataExporter {
+	return internal.NewDeviceDataExporter(db)
 }
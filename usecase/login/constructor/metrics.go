@@ -0,0 +1,41 @@
+package constructor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/login/handler"
+	"littlerollingsushi.com/example/usecase/login/internal"
+)
+
+// countedLoginUsecase counts login attempts by outcome. An unknown email and a
+// wrong password look the same to the client but not on the dashboards.
+type countedLoginUsecase struct {
+	handler.LoginUsecase
+	attempts *prometheus.CounterVec
+}
+
+func (u *countedLoginUsecase) Login(ctx context.Context, in internal.LoginUsecaseInput) (internal.LoginUsecaseOutput, error) {
+	out, err := u.LoginUsecase.Login(ctx, in)
+	u.attempts.WithLabelValues(loginOutcome(err)).Inc()
+	return out, err
+}
+
+func loginOutcome(err error) string {
+	var domainErr *helper.DomainError
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, internal.ErrUserNotFound):
+		return "user_not_found"
+	case errors.Is(err, internal.ErrInvalidPassword):
+		return "invalid_password"
+	case errors.As(err, &domainErr) && domainErr.Kind != helper.ErrorKindInternal:
+		return domainErr.Code
+	default:
+		return "error"
+	}
+}
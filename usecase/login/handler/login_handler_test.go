@@ -2,15 +2,18 @@ package handler_test
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
 	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
 	"littlerollingsushi.com/example/usecase/login/handler"
 	"littlerollingsushi.com/example/usecase/login/handler/mocks"
@@ -28,12 +31,11 @@ type LoginHandlerSuite struct {
 	timer   *helperMocks.Timer
 	handler *handler.LoginHandler
 
-	expectedUsecaseInput         internal.LoginUsecaseInput
-	expectedUsecaseOutput        internal.LoginUsecaseOutput
-	expectedTimestamp            time.Time
-	expectedSuccessResponseBody  string
-	expectedUserNotFoundResponse string
-	errMock                      error
+	expectedUsecaseInput        internal.LoginUsecaseInput
+	expectedUsecaseOutput       internal.LoginUsecaseOutput
+	expectedTimestamp           time.Time
+	expectedSuccessResponseBody string
+	errMock                     error
 }
 
 func TestLoginHandlerSuite(t *testing.T) {
@@ -46,6 +48,7 @@ func (s *LoginHandlerSuite) SetupTest() {
 	form.Add("password", "verysecure")
 	s.request = httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(form.Encode()))
 	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request.Header.Set("User-Agent", "curl/7.85.0")
 
 	s.responseWriter = httptest.NewRecorder()
 
@@ -56,8 +59,10 @@ func (s *LoginHandlerSuite) SetupTest() {
 	s.handler = handler.NewLoginHandler(s.usecase, s.timer)
 
 	s.expectedUsecaseInput = internal.LoginUsecaseInput{
-		Email:    "john.doe@email.com",
-		Password: "verysecure",
+		Email:     "john.doe@email.com",
+		Password:  "verysecure",
+		IPAddress: "192.0.2.1",
+		UserAgent: "curl/7.85.0",
 	}
 	s.expectedUsecaseOutput = internal.LoginUsecaseOutput{
 		AccessToken: "very secure access token",
@@ -76,21 +81,29 @@ func (s *LoginHandlerSuite) SetupTest() {
 			}
 		}
 	`
-	s.expectedUserNotFoundResponse = `
+
+	s.errMock = errors.New("mock error")
+}
+
+func (s *LoginHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
 		{
-			"message": "Invalid credentials.",
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
 			"meta": {
-				"http_status": 401,
+				"http_status": ` + strconv.Itoa(code.Status) + `,
 				"server_time": "2022-10-29T23:59:59.123Z"
 			}
 		}
 	`
-
-	s.errMock = errors.New("mock error")
 }
 
 func (s *LoginHandlerSuite) TestLogin_UsecaseUnknownError_ReturnInternalServerError() {
 	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
 
 	s.handler.Login(s.responseWriter, s.request, s.requestParams)
 
@@ -98,10 +111,10 @@ func (s *LoginHandlerSuite) TestLogin_UsecaseUnknownError_ReturnInternalServerEr
 	body, _ := io.ReadAll(resp.Body)
 	a := s.Assert()
 	a.Equal(http.StatusInternalServerError, resp.StatusCode)
-	a.Equal("Oops! Something went wrong.", string(body))
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
 }
 
-func (s *LoginHandlerSuite) TestLogin_UserNotFound_ReturnInternalServerError() {
+func (s *LoginHandlerSuite) TestLogin_UserNotFound_ReturnUnauthorized() {
 	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrUserNotFound)
 	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
 
@@ -111,7 +124,189 @@ func (s *LoginHandlerSuite) TestLogin_UserNotFound_ReturnInternalServerError() {
 	body, _ := io.ReadAll(resp.Body)
 	a := s.Assert()
 	a.Equal(http.StatusUnauthorized, resp.StatusCode)
-	a.JSONEq(s.expectedUserNotFoundResponse, string(body))
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "invalid_credentials", Status: 401, Title: "Authentication failed"}, "Invalid credentials."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_InvalidPassword_ReturnUnauthorized() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrInvalidPassword)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "invalid_credentials", Status: 401, Title: "Authentication failed"}, "Invalid credentials."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_EmptyPassword_ReturnUnprocessableEntity() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrEmptyPassword)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "required_field_missing", Status: 422, Title: "Unprocessable request"}, "Password is required."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_WrappedDomainError_ReturnItsProblem() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).
+		Return(internal.LoginUsecaseOutput{}, fmt.Errorf("check status: %w", internal.ErrUserDisabled))
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *LoginHandlerSuite) TestLogin_InvalidPrivateKey_ReturnInternalServerError() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrInvalidPrivateKey)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_PendingDeletion_ReturnForbidden() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrPendingDeletion)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "account_pending_deletion", Status: 403, Title: "Forbidden"}, "Account is scheduled for deletion. Login with cancel_deletion=true to keep it."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_UserDisabled_ReturnForbidden() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrUserDisabled)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "account_disabled", Status: 403, Title: "Forbidden"}, "Account is disabled. Contact support."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_UserSuspended_ReturnForbidden() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrUserSuspended)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "account_suspended", Status: 403, Title: "Forbidden"}, "Account is temporarily suspended. Try again later or contact support."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_UserPending_ReturnForbidden() {
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(internal.LoginUsecaseOutput{}, internal.ErrUserPending)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "account_not_activated", Status: 403, Title: "Forbidden"}, "Account is not activated yet."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_CancelDeletion_PassCancelDeletionToUsecase() {
+	form := url.Values{}
+	form.Add("email", "john.doe@email.com")
+	form.Add("password", "verysecure")
+	form.Add("cancel_deletion", "true")
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(form.Encode()))
+	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request.Header.Set("User-Agent", "curl/7.85.0")
+	s.expectedUsecaseInput.CancelDeletion = true
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_JSONBody_PassFieldsToUsecase() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/login",
+		strings.NewReader(`{"email":"john.doe@email.com","password":"verysecure","cancel_deletion":true}`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.request.Header.Set("User-Agent", "curl/7.85.0")
+	s.expectedUsecaseInput.CancelDeletion = true
+	s.usecase.On("Login", s.request.Context(), s.expectedUsecaseInput).Return(s.expectedUsecaseOutput, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_UnsupportedContentType_ReturnUnsupportedMediaType() {
+	s.request.Header.Set("Content-Type", "text/plain")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.UnsupportedMediaType, "Unsupported content type. Send application/json or a form."), string(body))
+}
+
+func (s *LoginHandlerSuite) TestLogin_MalformedJSON_ReturnBadRequest() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/login", strings.NewReader(`{"email":"john.doe@email.com","remember_me":true}`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Login(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/malformed_request_body",
+			"title": "Malformed request body",
+			"status": 400,
+			"code": "malformed_request_body",
+			"detail": "Malformed request body.",
+			"details": ["unknown field \"remember_me\""],
+			"meta": {
+				"http_status": 400,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
 }
 
 func (s *LoginHandlerSuite) TestLogin_UsecaseSuccess_ReturnCreated() {
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/tracing"
+)
+
+const (
+	maxUserAgentLength = 255
+	createSessionQuery = "INSERT INTO user_session (user_id, ip_address, user_agent, created_at, last_used_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)"
+)
+
+type CreateSessionGateway struct {
+	sql *sql.DB
+}
+
+func NewCreateSessionGateway(sql *sql.DB) *CreateSessionGateway {
+	return &CreateSessionGateway{sql: sql}
+}
+
+func (g *CreateSessionGateway) CreateSession(ctx context.Context, session entity.Session) (id int64, err error) {
+	ctx, span := tracing.StartQuery(ctx, "CreateSessionGateway.CreateSession", createSessionQuery)
+	defer func() { tracing.End(span, err) }()
+
+	userAgent := []rune(session.UserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	result, err := g.sql.ExecContext(
+		ctx,
+		createSessionQuery,
+		session.UserID,
+		session.IPAddress,
+		string(userAgent),
+		session.CreatedAt,
+		session.LastUsedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/login/internal"
+)
+
+type CreateSessionGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	session entity.Session
+	gateway *internal.CreateSessionGateway
+}
+
+func TestCreateSessionGatewaySuite(t *testing.T) {
+	suite.Run(t, &CreateSessionGatewaySuite{})
+}
+
+func (s *CreateSessionGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "INSERT INTO user_session (user_id, ip_address, user_agent, created_at, last_used_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)"
+	s.errMock = errors.New("mocked error")
+
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.gateway = internal.NewCreateSessionGateway(s.db)
+	s.context = context.Background()
+	s.session = entity.Session{
+		UserID:     7,
+		IPAddress:  "192.0.2.1",
+		UserAgent:  "curl/7.85.0",
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+}
+
+func (s *CreateSessionGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *CreateSessionGatewaySuite) TestCreateSession_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	id, err := s.gateway.CreateSession(s.context, s.session)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Zero(id)
+}
+
+func (s *CreateSessionGatewaySuite) TestCreateSession_LongUserAgent_TruncateUserAgent() {
+	s.session.UserAgent = strings.Repeat("é", 300)
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(s.session.UserID, s.session.IPAddress, strings.Repeat("é", 255), s.session.CreatedAt, s.session.LastUsedAt, s.session.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := s.gateway.CreateSession(s.context, s.session)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(3), id)
+}
+
+func (s *CreateSessionGatewaySuite) TestCreateSession_InsertSuccess_ReturnSessionID() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(s.session.UserID, s.session.IPAddress, s.session.UserAgent, s.session.CreatedAt, s.session.LastUsedAt, s.session.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := s.gateway.CreateSession(s.context, s.session)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(3), id)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	deviceDataSection     = "devices"
+	getDevicesByUserQuery = "SELECT ip_address, user_agent, first_seen_at, last_seen_at FROM user_device WHERE user_id = ? ORDER BY id"
+)
+
+type DeviceExport struct {
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// DeviceDataExporter contributes the devices a user logged in from, as
+// remembered for new device notifications, to the personal data export.
+type DeviceDataExporter struct {
+	sql *sql.DB
+}
+
+func NewDeviceDataExporter(sql *sql.DB) *DeviceDataExporter {
+	return &DeviceDataExporter{sql: sql}
+}
+
+func (e *DeviceDataExporter) PersonalDataSection() string {
+	return deviceDataSection
+}
+
+func (e *DeviceDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	rows, err := e.sql.QueryContext(ctx, getDevicesByUserQuery, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []DeviceExport{}
+	for rows.Next() {
+		export := DeviceExport{}
+		var ipAddress, userAgent sql.NullString
+		if err := rows.Scan(&ipAddress, &userAgent, &export.FirstSeenAt, &export.LastSeenAt); err != nil {
+			return nil, err
+		}
+
+		export.IPAddress = ipAddress.String
+		export.UserAgent = userAgent.String
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,84 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/login/internal"
+)
+
+type DeviceDataExporterSuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context  context.Context
+	user     entity.User
+	now      time.Time
+	exporter *internal.DeviceDataExporter
+}
+
+func TestDeviceDataExporterSuite(t *testing.T) {
+	suite.Run(t, &DeviceDataExporterSuite{})
+}
+
+func (s *DeviceDataExporterSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT ip_address, user_agent, first_seen_at, last_seen_at FROM user_device WHERE user_id = ? ORDER BY id"
+	s.errMock = errors.New("mocked error")
+
+	s.exporter = internal.NewDeviceDataExporter(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *DeviceDataExporterSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *DeviceDataExporterSuite) TestPersonalDataSection_ReturnDevices() {
+	s.Assert().Equal("devices", s.exporter.PersonalDataSection())
+}
+
+func (s *DeviceDataExporterSuite) TestExportPersonalData_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnError(s.errMock)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(data)
+}
+
+func (s *DeviceDataExporterSuite) TestExportPersonalData_Success_ReturnDevices() {
+	rows := sqlmock.NewRows([]string{"ip_address", "user_agent", "first_seen_at", "last_seen_at"}).
+		AddRow("192.0.2.1", "curl/7.85.0", s.now, s.now).
+		AddRow(nil, nil, s.now, s.now)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.DeviceExport{
+		{IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0", FirstSeenAt: s.now, LastSeenAt: s.now},
+		{FirstSeenAt: s.now, LastSeenAt: s.now},
+	}, data)
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/tracing"
+)
+
+const (
+	getRolesByUserIDQuery = "SELECT r.name, rp.permission FROM user_role ur " +
+		"JOIN role r ON r.id = ur.role_id " +
+		"LEFT JOIN role_permission rp ON rp.role_id = r.id " +
+		"WHERE ur.user_id = ? ORDER BY r.name, rp.permission"
+)
+
+type GetRolesByUserIDGateway struct {
+	sql *sql.DB
+}
+
+func NewGetRolesByUserIDGateway(sql *sql.DB) *GetRolesByUserIDGateway {
+	return &GetRolesByUserIDGateway{sql: sql}
+}
+
+func (g *GetRolesByUserIDGateway) GetRolesByUserID(ctx context.Context, userID int64) (roles []entity.Role, err error) {
+	ctx, span := tracing.StartQuery(ctx, "GetRolesByUserIDGateway.GetRolesByUserID", getRolesByUserIDQuery)
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := g.sql.QueryContext(ctx, getRolesByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles = []entity.Role{}
+	for rows.Next() {
+		var name string
+		var permission sql.NullString
+		if err := rows.Scan(&name, &permission); err != nil {
+			return nil, err
+		}
+
+		// rows are ordered by role name, so a role spans consecutive rows
+		if len(roles) == 0 || roles[len(roles)-1].Name != name {
+			roles = append(roles, entity.Role{Name: name, Permissions: []string{}})
+		}
+
+		if permission.Valid {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, permission.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,92 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/login/internal"
+)
+
+type GetRolesByUserIDGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	userID  int64
+	gateway *internal.GetRolesByUserIDGateway
+}
+
+func TestGetRolesByUserIDGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetRolesByUserIDGatewaySuite{})
+}
+
+func (s *GetRolesByUserIDGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT r.name, rp.permission FROM user_role ur " +
+		"JOIN role r ON r.id = ur.role_id " +
+		"LEFT JOIN role_permission rp ON rp.role_id = r.id " +
+		"WHERE ur.user_id = ? ORDER BY r.name, rp.permission"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewGetRolesByUserIDGateway(s.db)
+	s.context = context.Background()
+	s.userID = 7
+}
+
+func (s *GetRolesByUserIDGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetRolesByUserIDGatewaySuite) TestGetRolesByUserID_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	roles, err := s.gateway.GetRolesByUserID(s.context, s.userID)
+
+	a := s.Assert()
+	a.Nil(roles)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *GetRolesByUserIDGatewaySuite) TestGetRolesByUserID_NoRoles_ReturnEmptyList() {
+	rows := sqlmock.NewRows([]string{"name", "permission"})
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.userID).WillReturnRows(rows)
+
+	roles, err := s.gateway.GetRolesByUserID(s.context, s.userID)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.Role{}, roles)
+}
+
+func (s *GetRolesByUserIDGatewaySuite) TestGetRolesByUserID_RolesFound_GroupPermissionsByRole() {
+	rows := sqlmock.NewRows([]string{"name", "permission"})
+	rows.AddRow("auditor", nil)
+	rows.AddRow("support", "audit:read")
+	rows.AddRow("support", "users:read")
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.userID).WillReturnRows(rows)
+
+	roles, err := s.gateway.GetRolesByUserID(s.context, s.userID)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.Role{
+		{Name: "auditor", Permissions: []string{}},
+		{Name: "support", Permissions: []string{"audit:read", "users:read"}},
+	}, roles)
+}
@@ -3,12 +3,16 @@ package internal
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/tracing"
 )
 
 const (
-	GetUserByEmailQuery = "SELECT first_name, last_name, email, crypted_password FROM user WHERE email = ?"
+	GetUserByEmailQuery = "SELECT id, first_name, last_name, email, crypted_password, status, status_reason, status_changed_at, suspended_until, deleted_at " +
+		"FROM user WHERE email = ?"
+	restoreUserQuery = "UPDATE user SET deleted_at = NULL, updated_at = ? WHERE id = ?"
 )
 
 type GetUserByEmailGateway struct {
@@ -19,9 +23,24 @@ func NewGetUserByEmailGateway(sql *sql.DB) *GetUserByEmailGateway {
 	return &GetUserByEmailGateway{sql: sql}
 }
 
-func (g *GetUserByEmailGateway) GetUserByEmail(ctx context.Context, email string) (entity.User, error) {
-	user := entity.User{}
-	err := g.sql.QueryRowContext(ctx, GetUserByEmailQuery, email).Scan(&user.FirstName, &user.LastName, &user.Email, &user.CryptedPassword)
+func (g *GetUserByEmailGateway) GetUserByEmail(ctx context.Context, email string) (user entity.User, err error) {
+	ctx, span := tracing.StartQuery(ctx, "GetUserByEmailGateway.GetUserByEmail", GetUserByEmailQuery)
+	defer func() { tracing.End(span, err) }()
+
+	var statusReason sql.NullString
+	err = g.sql.QueryRowContext(ctx, GetUserByEmailQuery, email).Scan(
+		&user.ID,
+		&user.FirstName,
+		&user.LastName,
+		&user.Email,
+		&user.CryptedPassword,
+		&user.Status,
+		&statusReason,
+		&user.StatusChangedAt,
+		&user.SuspendedUntil,
+		&user.DeletedAt,
+	)
+	user.StatusReason = statusReason.String
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return user, ErrUserNotFound
@@ -32,3 +51,12 @@ func (g *GetUserByEmailGateway) GetUserByEmail(ctx context.Context, email string
 
 	return user, nil
 }
+
+// RestoreUser cancels a pending account deletion.
+func (g *GetUserByEmailGateway) RestoreUser(ctx context.Context, userID int64, now time.Time) (err error) {
+	ctx, span := tracing.StartQuery(ctx, "GetUserByEmailGateway.RestoreUser", restoreUserQuery)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = g.sql.ExecContext(ctx, restoreUserQuery, now, userID)
+	return err
+}
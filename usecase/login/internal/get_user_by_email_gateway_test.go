@@ -6,6 +6,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
@@ -16,14 +17,16 @@ import (
 type GetUserByEmailGatewaySuite struct {
 	suite.Suite
 
-	db            *sql.DB
-	mockDb        sqlmock.Sqlmock
-	expectedQuery string
-	errMock       error
+	db                   *sql.DB
+	mockDb               sqlmock.Sqlmock
+	expectedQuery        string
+	expectedRestoreQuery string
+	errMock              error
 
 	context context.Context
 	email   string
 	user    entity.User
+	columns []string
 	gateway *internal.GetUserByEmailGateway
 }
 
@@ -39,18 +42,22 @@ func (s *GetUserByEmailGatewaySuite) SetupTest() {
 
 	s.db = db
 	s.mockDb = mock
-	s.expectedQuery = "SELECT first_name, last_name, email, crypted_password FROM user WHERE email = ?"
+	s.expectedQuery = "SELECT id, first_name, last_name, email, crypted_password, status, status_reason, status_changed_at, suspended_until, deleted_at FROM user WHERE email = ?"
+	s.expectedRestoreQuery = "UPDATE user SET deleted_at = NULL, updated_at = ? WHERE id = ?"
 	s.errMock = errors.New("mocked error")
 
 	s.gateway = internal.NewGetUserByEmailGateway(s.db)
 	s.context = context.Background()
 	s.email = "john.doe@email.com"
 	s.user = entity.User{
+		ID:              7,
 		FirstName:       "John",
 		LastName:        "Doe",
 		Email:           s.email,
 		CryptedPassword: "verysecureencrypted",
+		Status:          entity.UserStatusActive,
 	}
+	s.columns = []string{"id", "first_name", "last_name", "email", "crypted_password", "status", "status_reason", "status_changed_at", "suspended_until", "deleted_at"}
 }
 
 func (s *GetUserByEmailGatewaySuite) TearDownTest() {
@@ -78,8 +85,8 @@ func (s *GetUserByEmailGatewaySuite) TestGetUserByEmail_DuplicateUser_ReturnUser
 }
 
 func (s *GetUserByEmailGatewaySuite) TestGetUserByEmail_InsertSuccess_ReturnNil() {
-	rows := sqlmock.NewRows([]string{"first_name", "last_name", "email", "crypted_password"})
-	rows.AddRow(s.user.FirstName, s.user.LastName, s.user.Email, s.user.CryptedPassword)
+	rows := sqlmock.NewRows(s.columns)
+	rows.AddRow(s.user.ID, s.user.FirstName, s.user.LastName, s.user.Email, s.user.CryptedPassword, "active", nil, nil, nil, nil)
 	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnRows(rows)
 
 	user, err := s.gateway.GetUserByEmail(s.context, s.email)
@@ -88,3 +95,52 @@ func (s *GetUserByEmailGatewaySuite) TestGetUserByEmail_InsertSuccess_ReturnNil(
 	a.EqualValues(s.user, user)
 	a.Nil(err)
 }
+
+func (s *GetUserByEmailGatewaySuite) TestGetUserByEmail_DeletedUser_ReturnDeletedAt() {
+	deletedAt := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.user.DeletedAt = &deletedAt
+	rows := sqlmock.NewRows(s.columns)
+	rows.AddRow(s.user.ID, s.user.FirstName, s.user.LastName, s.user.Email, s.user.CryptedPassword, "active", nil, nil, nil, deletedAt)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnRows(rows)
+
+	user, err := s.gateway.GetUserByEmail(s.context, s.email)
+
+	a := s.Assert()
+	a.EqualValues(s.user, user)
+	a.Nil(err)
+}
+
+func (s *GetUserByEmailGatewaySuite) TestGetUserByEmail_SuspendedUser_ReturnStatus() {
+	changedAt := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	suspendedUntil := changedAt.Add(24 * time.Hour)
+	s.user.Status = entity.UserStatusSuspended
+	s.user.StatusReason = "chargeback"
+	s.user.StatusChangedAt = &changedAt
+	s.user.SuspendedUntil = &suspendedUntil
+	rows := sqlmock.NewRows(s.columns)
+	rows.AddRow(s.user.ID, s.user.FirstName, s.user.LastName, s.user.Email, s.user.CryptedPassword, "suspended", "chargeback", changedAt, suspendedUntil, nil)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnRows(rows)
+
+	user, err := s.gateway.GetUserByEmail(s.context, s.email)
+
+	a := s.Assert()
+	a.EqualValues(s.user, user)
+	a.Nil(err)
+}
+
+func (s *GetUserByEmailGatewaySuite) TestRestoreUser_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRestoreQuery)).WillReturnError(s.errMock)
+
+	err := s.gateway.RestoreUser(s.context, s.user.ID, time.Now())
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *GetUserByEmailGatewaySuite) TestRestoreUser_Success_ReturnNil() {
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRestoreQuery)).WithArgs(now, s.user.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.gateway.RestoreUser(s.context, s.user.ID, now)
+
+	s.Assert().Nil(err)
+}
@@ -1,11 +1,61 @@
 package internal
 
-import "errors"
+import "littlerollingsushi.com/example/usecase/helper"
 
+// An unknown email and a wrong password share code and message, so a failed
+// login does not tell whether an account exists.
 var (
-	ErrEmptyEmail        = errors.New("login email can not be empty")
-	ErrEmptyPassword     = errors.New("login password can not be empty")
-	ErrInvalidPassword   = errors.New("login password is not valid")
-	ErrInvalidPrivateKey = errors.New("login usecase private key is not valid")
-	ErrUserNotFound      = errors.New("user with given email is not found")
+	ErrEmptyEmail = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "required_field_missing",
+		Message: "Email is required.",
+		Reason:  "login email can not be empty",
+	}
+	ErrEmptyPassword = &helper.DomainError{
+		Kind:    helper.ErrorKindUnprocessable,
+		Code:    "required_field_missing",
+		Message: "Password is required.",
+		Reason:  "login password can not be empty",
+	}
+	ErrInvalidPassword = &helper.DomainError{
+		Kind:    helper.ErrorKindUnauthenticated,
+		Code:    "invalid_credentials",
+		Message: "Invalid credentials.",
+		Reason:  "login password is not valid",
+	}
+	ErrInvalidPrivateKey = &helper.DomainError{
+		Kind:   helper.ErrorKindInternal,
+		Code:   "internal_error",
+		Reason: "login usecase private key is not valid",
+	}
+	ErrUserNotFound = &helper.DomainError{
+		Kind:    helper.ErrorKindUnauthenticated,
+		Code:    "invalid_credentials",
+		Message: "Invalid credentials.",
+		Reason:  "user with given email is not found",
+	}
+	ErrPendingDeletion = &helper.DomainError{
+		Kind:    helper.ErrorKindForbidden,
+		Code:    "account_pending_deletion",
+		Message: "Account is scheduled for deletion. Login with cancel_deletion=true to keep it.",
+		Reason:  "user account is scheduled for deletion",
+	}
+	ErrUserDisabled = &helper.DomainError{
+		Kind:    helper.ErrorKindForbidden,
+		Code:    "account_disabled",
+		Message: "Account is disabled. Contact support.",
+		Reason:  "user account is disabled",
+	}
+	ErrUserSuspended = &helper.DomainError{
+		Kind:    helper.ErrorKindForbidden,
+		Code:    "account_suspended",
+		Message: "Account is temporarily suspended. Try again later or contact support.",
+		Reason:  "user account is suspended",
+	}
+	ErrUserPending = &helper.DomainError{
+		Kind:    helper.ErrorKindForbidden,
+		Code:    "account_not_activated",
+		Message: "Account is not activated yet.",
+		Reason:  "user account is pending activation",
+	}
 )
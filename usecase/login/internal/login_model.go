@@ -1,8 +1,20 @@
 package internal
 
+import "time"
+
+const (
+	AuditEventLogin = "auth.login"
+)
+
 type LoginUsecaseInput struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	IPAddress string
+	UserAgent string
+
+	// CancelDeletion restores an account scheduled for deletion instead of
+	// rejecting the login with ErrPendingDeletion.
+	CancelDeletion bool
 }
 
 type LoginUsecaseOutput struct {
@@ -10,3 +22,13 @@ type LoginUsecaseOutput struct {
 	TokenType   string
 	ExpiresIn   int
 }
+
+// Device identifies where a login comes from by the fingerprint of its user
+// agent and IP address.
+type Device struct {
+	UserID      int64
+	Fingerprint string
+	IPAddress   string
+	UserAgent   string
+	SeenAt      time.Time
+}
@@ -3,11 +3,18 @@ package internal
 import (
 	"context"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 
 	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/tracing"
+	"littlerollingsushi.com/example/usecase/helper"
 )
 
 const accessTokenExpirationDurationSeconds = 3600
@@ -15,59 +22,266 @@ const accessTokenExpirationDurationSeconds = 3600
 //go:generate mockery --name=LoginGateway --output=./mocks
 type LoginGateway interface {
 	GetUserByEmail(ctx context.Context, email string) (entity.User, error)
+	RestoreUser(ctx context.Context, userID int64, now time.Time) error
+	GetRolesByUserID(ctx context.Context, userID int64) ([]entity.Role, error)
+	CreateSession(ctx context.Context, session entity.Session) (int64, error)
+	HasKnownDevices(ctx context.Context, userID int64) (bool, error)
+	RememberDevice(ctx context.Context, device Device) (bool, error)
+	SendMail(ctx context.Context, mail helper.Mail) error
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
 	IsHashAndPasswordEqual(hash, password string) bool
 	NowInUTC() time.Time
 }
 
 type LoginUsecase struct {
+	config     LoginUsecaseConfig
 	gateway    LoginGateway
 	privateKey *rsa.PrivateKey
 }
 
-func NewLoginUsecase(gateway LoginGateway, privateKey *rsa.PrivateKey) *LoginUsecase {
-	return &LoginUsecase{gateway: gateway, privateKey: privateKey}
+type LoginUsecaseConfig struct {
+	DeletionGracePeriod time.Duration
 }
 
-func (u *LoginUsecase) Login(ctx context.Context, in LoginUsecaseInput) (LoginUsecaseOutput, error) {
+func NewLoginUsecase(config LoginUsecaseConfig, gateway LoginGateway, privateKey *rsa.PrivateKey) *LoginUsecase {
+	return &LoginUsecase{config: config, gateway: gateway, privateKey: privateKey}
+}
+
+// Login records every attempt in the audit log, successful or not. Failing to
+// record it is logged only, so a session already created is not thrown away.
+func (u *LoginUsecase) Login(ctx context.Context, in LoginUsecaseInput) (out LoginUsecaseOutput, err error) {
+	ctx, span := tracing.Start(ctx, "LoginUsecase.Login")
+	defer func() { tracing.End(span, err) }()
+
+	out, userID, err := u.login(ctx, in)
+
+	event := entity.AuditEvent{
+		Type:      AuditEventLogin,
+		IPAddress: in.IPAddress,
+		UserAgent: in.UserAgent,
+		Outcome:   entity.AuditOutcomeSuccess,
+		CreatedAt: u.gateway.NowInUTC(),
+	}
+	if userID != 0 {
+		event.UserID = &userID
+	}
+	if err != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason = err.Error()
+	}
+
+	if auditErr := u.gateway.RecordAuditEvent(ctx, event); auditErr != nil {
+		logging.FromContext(ctx).Error("recording audit event failed", "event", event.Type, "error", auditErr)
+	}
+
+	return out, err
+}
+
+// login returns the id of the user once it is known, so failed attempts on an
+// existing account can be attributed to it.
+func (u *LoginUsecase) login(ctx context.Context, in LoginUsecaseInput) (LoginUsecaseOutput, int64, error) {
 	if in.Email == "" {
-		return LoginUsecaseOutput{}, ErrEmptyEmail
+		return LoginUsecaseOutput{}, 0, ErrEmptyEmail
 	}
 
 	if in.Password == "" {
-		return LoginUsecaseOutput{}, ErrEmptyPassword
+		return LoginUsecaseOutput{}, 0, ErrEmptyPassword
 	}
 
 	user, err := u.gateway.GetUserByEmail(ctx, in.Email)
 	if err != nil {
-		return LoginUsecaseOutput{}, err
+		return LoginUsecaseOutput{}, 0, err
+	}
+
+	if !u.isPasswordValid(ctx, user, in.Password) {
+		return LoginUsecaseOutput{}, user.ID, ErrInvalidPassword
+	}
+
+	if err := u.checkStatus(user); err != nil {
+		return LoginUsecaseOutput{}, user.ID, err
 	}
 
-	if !u.gateway.IsHashAndPasswordEqual(user.CryptedPassword, in.Password) {
-		return LoginUsecaseOutput{}, ErrInvalidPassword
+	if err := u.checkDeletion(ctx, user, in.CancelDeletion); err != nil {
+		return LoginUsecaseOutput{}, user.ID, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, u.buildJwtClaim(user.Email))
+	roles, err := u.gateway.GetRolesByUserID(ctx, user.ID)
+	if err != nil {
+		return LoginUsecaseOutput{}, user.ID, err
+	}
+
+	sessionID, err := u.createSession(ctx, user, in)
+	if err != nil {
+		return LoginUsecaseOutput{}, user.ID, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, u.buildJwtClaim(user, roles, sessionID))
 	signedToken, err := token.SignedString(u.privateKey)
 	if err != nil {
-		return LoginUsecaseOutput{}, ErrInvalidPrivateKey
+		return LoginUsecaseOutput{}, user.ID, ErrInvalidPrivateKey
 	}
 
+	u.checkDevice(ctx, user, in)
+
 	return LoginUsecaseOutput{
 		AccessToken: signedToken,
 		TokenType:   "Bearer",
 		ExpiresIn:   accessTokenExpirationDurationSeconds,
-	}, nil
+	}, user.ID, nil
+}
+
+// isPasswordValid has a span of its own, bcrypt being the slowest step of a
+// login by design.
+func (u *LoginUsecase) isPasswordValid(ctx context.Context, user entity.User, password string) bool {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+
+	return u.gateway.IsHashAndPasswordEqual(user.CryptedPassword, password)
+}
+
+// checkDevice remembers where the user logged in from and emails the owner
+// when it is a new device. The very first device of an account is remembered
+// silently. The mail is sent asynchronously by the gateway. It only runs once
+// the login succeeded and never fails it, its errors are logged.
+func (u *LoginUsecase) checkDevice(ctx context.Context, user entity.User, in LoginUsecaseInput) {
+	if err := u.notifyNewDevice(ctx, user, in); err != nil {
+		logging.FromContext(ctx).Error("checking login device failed", "error", err)
+	}
+}
+
+func (u *LoginUsecase) notifyNewDevice(ctx context.Context, user entity.User, in LoginUsecaseInput) error {
+	hasKnownDevices, err := u.gateway.HasKnownDevices(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	now := u.gateway.NowInUTC()
+	isNew, err := u.gateway.RememberDevice(ctx, Device{
+		UserID:      user.ID,
+		Fingerprint: deviceFingerprint(in.IPAddress, in.UserAgent),
+		IPAddress:   in.IPAddress,
+		UserAgent:   in.UserAgent,
+		SeenAt:      now,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !isNew || !hasKnownDevices {
+		return nil
+	}
+
+	return u.gateway.SendMail(ctx, u.buildNewDeviceMail(user, in, now))
+}
+
+func (u *LoginUsecase) buildNewDeviceMail(user entity.User, in LoginUsecaseInput, now time.Time) helper.Mail {
+	return helper.Mail{
+		To:      user.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf(
+			"Your password was used to sign in from a new device.\n\n"+
+				"Time: %s\nIP address: %s\nDevice: %s\n\n"+
+				"If this was you, you can ignore this email. Otherwise reset your password "+
+				"and revoke the sessions you do not recognize.\n",
+			now.Format(time.RFC1123),
+			in.IPAddress,
+			in.UserAgent,
+		),
+	}
+}
+
+func deviceFingerprint(ipAddress, userAgent string) string {
+	sum := sha256.Sum256([]byte(ipAddress + "\n" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkStatus is only called once the password is verified, so the status of
+// an account is never revealed to someone who does not own it. Unknown
+// statuses are treated as disabled.
+func (u *LoginUsecase) checkStatus(user entity.User) error {
+	switch user.Status {
+	case entity.UserStatusActive:
+		return nil
+	case entity.UserStatusSuspended:
+		if user.IsSuspended(u.gateway.NowInUTC()) {
+			return ErrUserSuspended
+		}
+
+		return nil
+	case entity.UserStatusPending:
+		return ErrUserPending
+	default:
+		return ErrUserDisabled
+	}
 }
 
-func (u *LoginUsecase) buildJwtClaim(email string) *jwt.RegisteredClaims {
+func (u *LoginUsecase) checkDeletion(ctx context.Context, user entity.User, cancelDeletion bool) error {
+	if user.DeletedAt == nil {
+		return nil
+	}
+
+	now := u.gateway.NowInUTC()
+	if !user.IsPendingDeletion(now, u.config.DeletionGracePeriod) {
+		return ErrUserNotFound
+	}
+
+	if !cancelDeletion {
+		return ErrPendingDeletion
+	}
+
+	return u.gateway.RestoreUser(ctx, user.ID, now)
+}
+
+// createSession persists the session the access token is bound to. It expires
+// together with the token, revoking it invalidates the token early.
+func (u *LoginUsecase) createSession(ctx context.Context, user entity.User, in LoginUsecaseInput) (int64, error) {
+	now := u.gateway.NowInUTC()
+	return u.gateway.CreateSession(ctx, entity.Session{
+		UserID:     user.ID,
+		IPAddress:  in.IPAddress,
+		UserAgent:  in.UserAgent,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(accessTokenExpirationDurationSeconds * time.Second),
+	})
+}
+
+func (u *LoginUsecase) buildJwtClaim(user entity.User, roles []entity.Role, sessionID int64) *helper.AccessTokenClaims {
 	now := u.gateway.NowInUTC()
+	roleNames, permissions := u.flattenRoles(roles)
 
-	return &jwt.RegisteredClaims{
-		Issuer:    "littlerollingsushi.com",
-		Audience:  jwt.ClaimStrings{"littlerollingsushi.com"},
-		Subject:   email,
-		IssuedAt:  jwt.NewNumericDate(now),
-		NotBefore: jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenExpirationDurationSeconds * time.Second)),
+	return &helper.AccessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    helper.AccessTokenIssuer,
+			Audience:  jwt.ClaimStrings{helper.AccessTokenAudience},
+			Subject:   user.Email,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenExpirationDurationSeconds * time.Second)),
+		},
+		UserID:      user.ID,
+		SessionID:   sessionID,
+		Roles:       roleNames,
+		Permissions: permissions,
 	}
 }
+
+// flattenRoles returns the role names and the sorted union of the permissions
+// granted by those roles.
+func (u *LoginUsecase) flattenRoles(roles []entity.Role) ([]string, []string) {
+	roleNames := make([]string, 0, len(roles))
+	seen := map[string]bool{}
+	permissions := []string{}
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+		for _, permission := range role.Permissions {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	sort.Strings(permissions)
+	return roleNames, permissions
+}
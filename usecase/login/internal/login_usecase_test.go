@@ -4,31 +4,43 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/crypto/bcrypt"
 	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/helper"
 	"littlerollingsushi.com/example/usecase/login/internal"
 	"littlerollingsushi.com/example/usecase/login/internal/mocks"
 )
 
+type testContextKey struct{}
+
 type LoginUsecaseSuite struct {
 	suite.Suite
 
-	context context.Context
-	input   internal.LoginUsecaseInput
-	output  internal.LoginUsecaseOutput
+	context        context.Context
+	gatewayContext interface{}
+	input          internal.LoginUsecaseInput
+	output         internal.LoginUsecaseOutput
 
-	priv    *rsa.PrivateKey
-	gateway *mocks.LoginGateway
-	usecase *internal.LoginUsecase
+	config      internal.LoginUsecaseConfig
+	priv        *rsa.PrivateKey
+	gateway     *mocks.LoginGateway
+	usecase     *internal.LoginUsecase
+	auditCall   *mock.Call
+	sessionCall *mock.Call
+	knownCall   *mock.Call
+	deviceCall  *mock.Call
 
 	user    entity.User
+	roles   []entity.Role
 	now     time.Time
 	errMock error
 }
@@ -38,29 +50,72 @@ func TestLoginUsecaseSuite(t *testing.T) {
 }
 
 func (s *LoginUsecaseSuite) SetupTest() {
-	s.context = context.Background()
+	s.context = context.WithValue(context.Background(), testContextKey{}, s.T().Name())
+	// Gateways get a context derived from the one of the call, carrying the span
+	// of the usecase.
+	s.gatewayContext = mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(testContextKey{}) == s.T().Name()
+	})
 	s.input = internal.LoginUsecaseInput{
-		Email:    "john.doe@email.com",
-		Password: "verysecure",
+		Email:     "john.doe@email.com",
+		Password:  "verysecure",
+		IPAddress: "192.0.2.1",
+		UserAgent: "curl/7.85.0",
 	}
 	s.output = internal.LoginUsecaseOutput{
 		TokenType: "Bearer",
 		ExpiresIn: 3600,
 	}
 
+	s.config = internal.LoginUsecaseConfig{DeletionGracePeriod: 24 * time.Hour}
 	s.priv, _ = rsa.GenerateKey(rand.Reader, 2048)
 	s.gateway = mocks.NewLoginGateway(s.T())
-	s.usecase = internal.NewLoginUsecase(s.gateway, s.priv)
+	s.usecase = internal.NewLoginUsecase(s.config, s.gateway, s.priv)
 
 	encrypted, _ := bcrypt.GenerateFromPassword([]byte(s.input.Password), bcrypt.DefaultCost)
 	s.user = entity.User{
+		ID:              7,
 		FirstName:       "John",
 		LastName:        "Doe",
 		Email:           "john.doe@email.com",
 		CryptedPassword: string(encrypted),
+		Status:          entity.UserStatusActive,
+	}
+	s.roles = []entity.Role{
+		{Name: "support", Permissions: []string{"users:read", "audit:read"}},
+		{Name: "auditor", Permissions: []string{"audit:read"}},
 	}
 	s.now = time.Now()
 	s.errMock = errors.New("mock error")
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+	s.auditCall = s.gateway.On("RecordAuditEvent", s.gatewayContext, mock.Anything).Return(nil).Maybe()
+	s.sessionCall = s.gateway.On("CreateSession", s.gatewayContext, mock.Anything).Return(int64(3), nil).Maybe()
+	s.knownCall = s.gateway.On("HasKnownDevices", s.gatewayContext, s.user.ID).Return(true, nil).Maybe()
+	s.deviceCall = s.gateway.On("RememberDevice", s.gatewayContext, mock.Anything).Return(false, nil).Maybe()
+}
+
+func (s *LoginUsecaseSuite) expectedDevice() internal.Device {
+	sum := sha256.Sum256([]byte(s.input.IPAddress + "\n" + s.input.UserAgent))
+	return internal.Device{
+		UserID:      s.user.ID,
+		Fingerprint: hex.EncodeToString(sum[:]),
+		IPAddress:   s.input.IPAddress,
+		UserAgent:   s.input.UserAgent,
+		SeenAt:      s.now,
+	}
+}
+
+func (s *LoginUsecaseSuite) expectedAuditEvent(userID *int64, outcome, reason string) entity.AuditEvent {
+	return entity.AuditEvent{
+		Type:      internal.AuditEventLogin,
+		UserID:    userID,
+		IPAddress: s.input.IPAddress,
+		UserAgent: s.input.UserAgent,
+		Outcome:   outcome,
+		Reason:    reason,
+		CreatedAt: s.now,
+	}
 }
 
 func (s *LoginUsecaseSuite) TestLogin_EmptyEmail_ReturnError() {
@@ -84,7 +139,7 @@ func (s *LoginUsecaseSuite) TestLogin_EmptyPassowrd_ReturnError() {
 }
 
 func (s *LoginUsecaseSuite) TestLogin_GetUserByEmailError_ReturnError() {
-	s.gateway.On("GetUserByEmail", s.context, s.input.Email).Return(entity.User{}, s.errMock)
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(entity.User{}, s.errMock)
 
 	output, err := s.usecase.Login(s.context, s.input)
 
@@ -94,7 +149,7 @@ func (s *LoginUsecaseSuite) TestLogin_GetUserByEmailError_ReturnError() {
 }
 
 func (s *LoginUsecaseSuite) TestLogin_ComparePasswordError_ReturnError() {
-	s.gateway.On("GetUserByEmail", s.context, s.input.Email).Return(s.user, nil)
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
 	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(false)
 
 	output, err := s.usecase.Login(s.context, s.input)
@@ -104,12 +159,142 @@ func (s *LoginUsecaseSuite) TestLogin_ComparePasswordError_ReturnError() {
 	a.ErrorIs(err, internal.ErrInvalidPassword)
 }
 
+func (s *LoginUsecaseSuite) TestLogin_DisabledUser_ReturnErrUserDisabled() {
+	s.user.Status = entity.UserStatusDisabled
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrUserDisabled)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_UnknownStatus_ReturnErrUserDisabled() {
+	s.user.Status = "archived"
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrUserDisabled)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_PendingUser_ReturnErrUserPending() {
+	s.user.Status = entity.UserStatusPending
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrUserPending)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_SuspendedUser_ReturnErrUserSuspended() {
+	suspendedUntil := s.now.Add(time.Hour)
+	s.user.Status = entity.UserStatusSuspended
+	s.user.SuspendedUntil = &suspendedUntil
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrUserSuspended)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_SuspensionElapsed_ReturnAccessToken() {
+	suspendedUntil := s.now.Add(-time.Hour)
+	s.user.Status = entity.UserStatusSuspended
+	s.user.SuspendedUntil = &suspendedUntil
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_PendingDeletion_ReturnErrPendingDeletion() {
+	deletedAt := s.now.Add(-time.Hour)
+	s.user.DeletedAt = &deletedAt
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrPendingDeletion)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_DeletionGracePeriodElapsed_ReturnErrUserNotFound() {
+	deletedAt := s.now.Add(-s.config.DeletionGracePeriod)
+	s.user.DeletedAt = &deletedAt
+	s.input.CancelDeletion = true
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_CancelDeletionRestoreError_ReturnError() {
+	deletedAt := s.now.Add(-time.Hour)
+	s.user.DeletedAt = &deletedAt
+	s.input.CancelDeletion = true
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("RestoreUser", s.gatewayContext, s.user.ID, s.now).Return(s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_CancelDeletion_RestoreUserAndReturnAccessToken() {
+	deletedAt := s.now.Add(-time.Hour)
+	s.user.DeletedAt = &deletedAt
+	s.input.CancelDeletion = true
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("RestoreUser", s.gatewayContext, s.user.ID, s.now).Return(nil)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
+
 func (s *LoginUsecaseSuite) TestLogin_ValidCredentialsInvalidPrivateKey_ReturnErrInvalidKey() {
-	priv, _ := rsa.GenerateKey(strings.NewReader("random bytes."), 13)
-	s.usecase = internal.NewLoginUsecase(s.gateway, priv)
+	priv := &rsa.PrivateKey{}
+	s.usecase = internal.NewLoginUsecase(s.config, s.gateway, priv)
 
-	s.gateway.On("GetUserByEmail", s.context, s.input.Email).Return(s.user, nil)
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
 	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
 	s.gateway.On("NowInUTC").Return(s.now)
 
 	output, err := s.usecase.Login(s.context, s.input)
@@ -119,27 +304,233 @@ func (s *LoginUsecaseSuite) TestLogin_ValidCredentialsInvalidPrivateKey_ReturnEr
 	a.ErrorIs(err, internal.ErrInvalidPrivateKey)
 }
 
+func (s *LoginUsecaseSuite) TestLogin_GetRolesError_ReturnError() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(nil, s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
 func (s *LoginUsecaseSuite) TestLogin_ValidCredentialsValidKey_ReturnAccessToken() {
-	s.gateway.On("GetUserByEmail", s.context, s.input.Email).Return(s.user, nil)
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
 	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
 	s.gateway.On("NowInUTC").Return(s.now)
 
 	output, err := s.usecase.Login(s.context, s.input)
 
 	a := s.Assert()
 	a.Nil(err)
-	parsed, err := jwt.ParseWithClaims(output.AccessToken, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+	parsed, err := jwt.ParseWithClaims(output.AccessToken, &helper.AccessTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return &s.priv.PublicKey, nil
 	})
 	a.Nil(err)
-	claims := parsed.Claims.(*jwt.RegisteredClaims)
+	claims := parsed.Claims.(*helper.AccessTokenClaims)
+	a.Equal([]string{"support", "auditor"}, claims.Roles)
+	a.Equal([]string{"audit:read", "users:read"}, claims.Permissions)
 	a.Nil(claims.Valid())
 	a.Equal("littlerollingsushi.com", claims.Issuer)
 	a.Equal(jwt.ClaimStrings{"littlerollingsushi.com"}, claims.Audience)
 	a.Equal(s.input.Email, claims.Subject)
+	a.Equal(s.user.ID, claims.UserID)
+	a.Equal(int64(3), claims.SessionID)
 	a.Equal(time.Unix(s.now.Unix(), 0), claims.NotBefore.Time)
 	a.Equal(time.Unix(s.now.Unix(), 0), claims.IssuedAt.Time)
 	a.Equal(time.Unix(s.now.Unix(), 0).Add(1*time.Hour), claims.ExpiresAt.Time)
 	a.Equal(s.output.ExpiresIn, output.ExpiresIn)
 	a.Equal(s.output.TokenType, output.TokenType)
 }
+
+func (s *LoginUsecaseSuite) TestLogin_ValidCredentials_CreateSession() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().Nil(err)
+	s.gateway.AssertCalled(s.T(), "CreateSession", s.gatewayContext, entity.Session{
+		UserID:     s.user.ID,
+		IPAddress:  s.input.IPAddress,
+		UserAgent:  s.input.UserAgent,
+		CreatedAt:  s.now,
+		LastUsedAt: s.now,
+		ExpiresAt:  s.now.Add(time.Hour),
+	})
+}
+
+func (s *LoginUsecaseSuite) TestLogin_CreateSessionError_ReturnError() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.sessionCall.Return(int64(0), s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Empty(output)
+	a.ErrorIs(err, s.errMock)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_HasKnownDevicesError_ReturnAccessToken() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.knownCall.Return(false, s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_RememberDeviceError_ReturnAccessToken() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.deviceCall.Return(false, s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_DisabledUser_DoNotCheckDevice() {
+	s.user.Status = entity.UserStatusDisabled
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrUserDisabled)
+	s.gateway.AssertNotCalled(s.T(), "HasKnownDevices", mock.Anything, mock.Anything)
+	s.gateway.AssertNotCalled(s.T(), "RememberDevice", mock.Anything, mock.Anything)
+	s.gateway.AssertNotCalled(s.T(), "SendMail", mock.Anything, mock.Anything)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_PendingDeletion_DoNotCheckDevice() {
+	deletedAt := s.now.Add(-time.Hour)
+	s.user.DeletedAt = &deletedAt
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrPendingDeletion)
+	s.gateway.AssertNotCalled(s.T(), "RememberDevice", mock.Anything, mock.Anything)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_KnownDevice_DoNotSendMail() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().Nil(err)
+	s.gateway.AssertCalled(s.T(), "RememberDevice", s.gatewayContext, s.expectedDevice())
+	s.gateway.AssertNotCalled(s.T(), "SendMail", mock.Anything, mock.Anything)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_FirstDevice_DoNotSendMail() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.knownCall.Return(false, nil)
+	s.deviceCall.Return(true, nil)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().Nil(err)
+	s.gateway.AssertNotCalled(s.T(), "SendMail", mock.Anything, mock.Anything)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_NewDevice_SendNewDeviceMail() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.deviceCall.Return(true, nil)
+	var sent helper.Mail
+	s.gateway.On("SendMail", s.gatewayContext, mock.MatchedBy(func(mail helper.Mail) bool {
+		sent = mail
+		return true
+	})).Return(nil)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(s.user.Email, sent.To)
+	a.Equal("New sign-in to your account", sent.Subject)
+	a.Contains(sent.Body, s.input.IPAddress)
+	a.Contains(sent.Body, s.input.UserAgent)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_NewDeviceSendMailError_ReturnAccessToken() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.deviceCall.Return(true, nil)
+	s.gateway.On("SendMail", s.gatewayContext, mock.Anything).Return(s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
+
+func (s *LoginUsecaseSuite) TestLogin_UnknownUser_RecordFailureWithoutUserID() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(entity.User{}, internal.ErrUserNotFound)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrUserNotFound)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(nil, entity.AuditOutcomeFailure, internal.ErrUserNotFound.Error()))
+}
+
+func (s *LoginUsecaseSuite) TestLogin_InvalidPassword_RecordFailureWithUserID() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(false)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidPassword)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(&s.user.ID, entity.AuditOutcomeFailure, internal.ErrInvalidPassword.Error()))
+}
+
+func (s *LoginUsecaseSuite) TestLogin_ValidCredentials_RecordSuccess() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+
+	_, err := s.usecase.Login(s.context, s.input)
+
+	s.Assert().Nil(err)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(&s.user.ID, entity.AuditOutcomeSuccess, ""))
+}
+
+func (s *LoginUsecaseSuite) TestLogin_RecordAuditEventError_ReturnToken() {
+	s.gateway.On("GetUserByEmail", s.gatewayContext, s.input.Email).Return(s.user, nil)
+	s.gateway.On("IsHashAndPasswordEqual", s.user.CryptedPassword, s.input.Password).Return(true)
+	s.gateway.On("GetRolesByUserID", s.gatewayContext, s.user.ID).Return(s.roles, nil)
+	s.auditCall.Return(s.errMock)
+
+	output, err := s.usecase.Login(s.context, s.input)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.NotEmpty(output.AccessToken)
+}
@@ -6,6 +6,9 @@ import (
 	context "context"
 
 	entity "littlerollingsushi.com/example/entity"
+	helper "littlerollingsushi.com/example/usecase/helper"
+
+	internal "littlerollingsushi.com/example/usecase/login/internal"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -17,6 +20,50 @@ type LoginGateway struct {
 	mock.Mock
 }
 
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *LoginGateway) CreateSession(ctx context.Context, session entity.Session) (int64, error) {
+	ret := _m.Called(ctx, session)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Session) int64); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRolesByUserID provides a mock function with given fields: ctx, userID
+func (_m *LoginGateway) GetRolesByUserID(ctx context.Context, userID int64) ([]entity.Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *LoginGateway) GetUserByEmail(ctx context.Context, email string) (entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -38,6 +85,27 @@ func (_m *LoginGateway) GetUserByEmail(ctx context.Context, email string) (entit
 	return r0, r1
 }
 
+// HasKnownDevices provides a mock function with given fields: ctx, userID
+func (_m *LoginGateway) HasKnownDevices(ctx context.Context, userID int64) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsHashAndPasswordEqual provides a mock function with given fields: hash, password
 func (_m *LoginGateway) IsHashAndPasswordEqual(hash string, password string) bool {
 	ret := _m.Called(hash, password)
@@ -66,6 +134,69 @@ func (_m *LoginGateway) NowInUTC() time.Time {
 	return r0
 }
 
+// RecordAuditEvent provides a mock function with given fields: ctx, event
+func (_m *LoginGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RememberDevice provides a mock function with given fields: ctx, device
+func (_m *LoginGateway) RememberDevice(ctx context.Context, device internal.Device) (bool, error) {
+	ret := _m.Called(ctx, device)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, internal.Device) bool); ok {
+		r0 = rf(ctx, device)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.Device) error); ok {
+		r1 = rf(ctx, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreUser provides a mock function with given fields: ctx, userID, now
+func (_m *LoginGateway) RestoreUser(ctx context.Context, userID int64, now time.Time) error {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMail provides a mock function with given fields: ctx, mail
+func (_m *LoginGateway) SendMail(ctx context.Context, mail helper.Mail) error {
+	ret := _m.Called(ctx, mail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, helper.Mail) error); ok {
+		r0 = rf(ctx, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewLoginGateway interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/tracing"
+)
+
+const (
+	hasKnownDevicesQuery = "SELECT EXISTS(SELECT 1 FROM user_device WHERE user_id = ?)"
+	rememberDeviceQuery  = "INSERT INTO user_device (user_id, fingerprint, ip_address, user_agent, first_seen_at, last_seen_at) VALUES (?, ?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE last_seen_at = VALUES(last_seen_at)"
+)
+
+type UserDeviceGateway struct {
+	sql *sql.DB
+}
+
+func NewUserDeviceGateway(sql *sql.DB) *UserDeviceGateway {
+	return &UserDeviceGateway{sql: sql}
+}
+
+func (g *UserDeviceGateway) HasKnownDevices(ctx context.Context, userID int64) (exists bool, err error) {
+	ctx, span := tracing.StartQuery(ctx, "UserDeviceGateway.HasKnownDevices", hasKnownDevicesQuery)
+	defer func() { tracing.End(span, err) }()
+
+	if err := g.sql.QueryRowContext(ctx, hasKnownDevicesQuery, userID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// RememberDevice reports whether the device was seen for the first time. MySQL
+// counts one affected row for an insert and two, or zero when nothing
+// changed, for an update of an existing row.
+func (g *UserDeviceGateway) RememberDevice(ctx context.Context, device Device) (isNew bool, err error) {
+	ctx, span := tracing.StartQuery(ctx, "UserDeviceGateway.RememberDevice", rememberDeviceQuery)
+	defer func() { tracing.End(span, err) }()
+
+	userAgent := []rune(device.UserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	result, err := g.sql.ExecContext(
+		ctx,
+		rememberDeviceQuery,
+		device.UserID,
+		device.Fingerprint,
+		device.IPAddress,
+		string(userAgent),
+		device.SeenAt,
+		device.SeenAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,110 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/login/internal"
+)
+
+type UserDeviceGatewaySuite struct {
+	suite.Suite
+
+	db                    *sql.DB
+	mockDb                sqlmock.Sqlmock
+	expectedExistsQuery   string
+	expectedRememberQuery string
+	errMock               error
+
+	context context.Context
+	device  internal.Device
+	gateway *internal.UserDeviceGateway
+}
+
+func TestUserDeviceGatewaySuite(t *testing.T) {
+	suite.Run(t, &UserDeviceGatewaySuite{})
+}
+
+func (s *UserDeviceGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedExistsQuery = "SELECT EXISTS(SELECT 1 FROM user_device WHERE user_id = ?)"
+	s.expectedRememberQuery = "INSERT INTO user_device (user_id, fingerprint, ip_address, user_agent, first_seen_at, last_seen_at) VALUES (?, ?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE last_seen_at = VALUES(last_seen_at)"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewUserDeviceGateway(s.db)
+	s.context = context.Background()
+	s.device = internal.Device{
+		UserID:      7,
+		Fingerprint: "fingerprint",
+		IPAddress:   "192.0.2.1",
+		UserAgent:   "curl/7.85.0",
+		SeenAt:      time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC),
+	}
+}
+
+func (s *UserDeviceGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *UserDeviceGatewaySuite) TestHasKnownDevices_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedExistsQuery)).WillReturnError(s.errMock)
+
+	_, err := s.gateway.HasKnownDevices(s.context, 7)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *UserDeviceGatewaySuite) TestHasKnownDevices_Exists_ReturnTrue() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedExistsQuery)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	known, err := s.gateway.HasKnownDevices(s.context, 7)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(known)
+}
+
+func (s *UserDeviceGatewaySuite) TestRememberDevice_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRememberQuery)).WillReturnError(s.errMock)
+
+	_, err := s.gateway.RememberDevice(s.context, s.device)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *UserDeviceGatewaySuite) TestRememberDevice_Inserted_ReturnTrue() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRememberQuery)).
+		WithArgs(s.device.UserID, s.device.Fingerprint, s.device.IPAddress, s.device.UserAgent, s.device.SeenAt, s.device.SeenAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	isNew, err := s.gateway.RememberDevice(s.context, s.device)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(isNew)
+}
+
+func (s *UserDeviceGatewaySuite) TestRememberDevice_Updated_ReturnFalse() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRememberQuery)).WillReturnResult(sqlmock.NewResult(1, 2))
+
+	isNew, err := s.gateway.RememberDevice(s.context, s.device)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(isNew)
+}
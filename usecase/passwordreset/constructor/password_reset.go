@@ -0,0 +1,32 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/passwordreset/handler"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+)
+
+func ConstructPasswordResetHandler(cfg *config.Config, db *sql.DB) *handler.PasswordResetHandler {
+	usecase := internal.NewPasswordResetUsecase(
+		internal.PasswordResetUsecaseConfig{SaltLength: cfg.Password.BcryptCost},
+		struct {
+			*internal.GetPasswordResetGateway
+			*internal.ResetPasswordGateway
+			*helper.PasswordEncrypter
+			*helper.TokenGenerator
+			helper.Timer
+		}{
+			GetPasswordResetGateway: internal.NewGetPasswordResetGateway(db),
+			ResetPasswordGateway:    internal.NewResetPasswordGateway(db),
+			PasswordEncrypter:       &helper.PasswordEncrypter{Observer: metricsConstructor.ConstructMetrics()},
+			TokenGenerator:          &helper.TokenGenerator{},
+			Timer:                   &helper.TimerImplementation{},
+		},
+	)
+	timer := &helper.TimerImplementation{}
+	return handler.NewPasswordResetHandler(usecase, timer)
+}
@@ -0,0 +1,45 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/passwordreset/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordResetUsecase is an autogenerated mock type for the PasswordResetUsecase type
+type PasswordResetUsecase struct {
+	mock.Mock
+}
+
+// ResetPassword provides a mock function with given fields: _a0, _a1
+func (_m *PasswordResetUsecase) ResetPassword(_a0 context.Context, _a1 internal.ResetPasswordUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.ResetPasswordUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswordResetUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordResetUsecase creates a new instance of PasswordResetUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordResetUsecase(t mockConstructorTestingTNewPasswordResetUsecase) *PasswordResetUsecase {
+	mock := &PasswordResetUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+)
+
+type PasswordResetHandler struct {
+	usecase PasswordResetUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=PasswordResetUsecase --output=./mocks
+type PasswordResetUsecase interface {
+	ResetPassword(context.Context, internal.ResetPasswordUsecaseInput) error
+}
+
+func NewPasswordResetHandler(usecase PasswordResetUsecase, timer helper.Timer) *PasswordResetHandler {
+	return &PasswordResetHandler{usecase: usecase, timer: timer}
+}
+
+func (h *PasswordResetHandler) ResetPassword(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	in := internal.ResetPasswordUsecaseInput{
+		Token:    r.FormValue("token"),
+		Password: r.FormValue("password"),
+	}
+
+	err := h.usecase.ResetPassword(r.Context(), in)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "Password changed. Continue to login with the new password.")
+}
+
+func (h *PasswordResetHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case internal.ErrEmptyToken, internal.ErrEmptyPassword:
+		problem.Write(w, h.timer, problem.RequiredFieldMissing, "Required fields are missing.")
+	case internal.ErrPasswordResetNotFound, internal.ErrPasswordResetExpired:
+		problem.Write(w, h.timer, problem.InvalidResetLink, "Reset link is invalid or expired.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *PasswordResetHandler) writeMessageResponse(w http.ResponseWriter, status int, message string) {
+	data := map[string]interface{}{
+		"message": message,
+		"meta": map[string]interface{}{
+			"http_status": status,
+			"server_time": h.timer.NowInUTC().Format("2006-01-02T15:04:05.999Z"),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,140 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+	"littlerollingsushi.com/example/usecase/passwordreset/handler"
+	"littlerollingsushi.com/example/usecase/passwordreset/handler/mocks"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+)
+
+type PasswordResetHandlerSuite struct {
+	suite.Suite
+
+	request        *http.Request
+	requestParams  map[string]string
+	responseWriter *httptest.ResponseRecorder
+
+	usecase *mocks.PasswordResetUsecase
+	timer   *helperMocks.Timer
+	handler *handler.PasswordResetHandler
+
+	expectedInput     internal.ResetPasswordUsecaseInput
+	expectedTimestamp time.Time
+	errMock           error
+}
+
+func TestPasswordResetHandlerSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetHandlerSuite{})
+}
+
+func (s *PasswordResetHandlerSuite) SetupTest() {
+	form := url.Values{}
+	form.Add("token", "random-token")
+	form.Add("password", "verysecure")
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/password/reset", strings.NewReader(form.Encode()))
+	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	s.responseWriter = httptest.NewRecorder()
+
+	s.requestParams = map[string]string{}
+
+	s.usecase = mocks.NewPasswordResetUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewPasswordResetHandler(s.usecase, s.timer)
+
+	s.expectedInput = internal.ResetPasswordUsecaseInput{Token: "random-token", Password: "verysecure"}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *PasswordResetHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *PasswordResetHandlerSuite) expectedResponseBody(status, message string) string {
+	return `
+		{
+			"message": "` + message + `",
+			"meta": {
+				"http_status": ` + status + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *PasswordResetHandlerSuite) TestResetPassword_UsecaseUnknownError_ReturnInternalServerError() {
+	s.usecase.On("ResetPassword", s.request.Context(), s.expectedInput).Return(s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ResetPassword(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *PasswordResetHandlerSuite) TestResetPassword_EmptyPassword_ReturnUnprocessableEntity() {
+	s.usecase.On("ResetPassword", s.request.Context(), s.expectedInput).Return(internal.ErrEmptyPassword)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ResetPassword(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.RequiredFieldMissing, "Required fields are missing."), string(body))
+}
+
+func (s *PasswordResetHandlerSuite) TestResetPassword_Expired_ReturnUnprocessableEntity() {
+	s.usecase.On("ResetPassword", s.request.Context(), s.expectedInput).Return(internal.ErrPasswordResetExpired)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ResetPassword(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidResetLink, "Reset link is invalid or expired."), string(body))
+}
+
+func (s *PasswordResetHandlerSuite) TestResetPassword_Success_ReturnOK() {
+	s.usecase.On("ResetPassword", s.request.Context(), s.expectedInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ResetPassword(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("200", "Password changed. Continue to login with the new password."), string(body))
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	getPasswordResetByTokenHashQuery = "SELECT id, user_id, token_hash, expires_at FROM password_reset WHERE token_hash = ? AND used_at IS NULL"
+)
+
+type GetPasswordResetGateway struct {
+	sql *sql.DB
+}
+
+func NewGetPasswordResetGateway(sql *sql.DB) *GetPasswordResetGateway {
+	return &GetPasswordResetGateway{sql: sql}
+}
+
+func (g *GetPasswordResetGateway) GetPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	reset := PasswordReset{}
+	err := g.sql.QueryRowContext(ctx, getPasswordResetByTokenHashQuery, tokenHash).
+		Scan(&reset.ID, &reset.UserID, &reset.TokenHash, &reset.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return reset, ErrPasswordResetNotFound
+		}
+
+		return reset, err
+	}
+
+	return reset, nil
+}
@@ -0,0 +1,77 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+)
+
+type GetPasswordResetGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	gateway *internal.GetPasswordResetGateway
+}
+
+func TestGetPasswordResetGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetPasswordResetGatewaySuite{})
+}
+
+func (s *GetPasswordResetGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, user_id, token_hash, expires_at FROM password_reset WHERE token_hash = ? AND used_at IS NULL"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewGetPasswordResetGateway(s.db)
+	s.context = context.Background()
+}
+
+func (s *GetPasswordResetGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetPasswordResetGatewaySuite) TestGetPasswordResetByTokenHash_NoRows_ReturnPasswordResetNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs("random-token-hash").WillReturnError(sql.ErrNoRows)
+
+	_, err := s.gateway.GetPasswordResetByTokenHash(s.context, "random-token-hash")
+
+	s.Assert().ErrorIs(err, internal.ErrPasswordResetNotFound)
+}
+
+func (s *GetPasswordResetGatewaySuite) TestGetPasswordResetByTokenHash_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs("random-token-hash").WillReturnError(s.errMock)
+
+	_, err := s.gateway.GetPasswordResetByTokenHash(s.context, "random-token-hash")
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *GetPasswordResetGatewaySuite) TestGetPasswordResetByTokenHash_Success_ReturnPasswordReset() {
+	expiresAt := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at"}).AddRow(3, 7, "random-token-hash", expiresAt)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs("random-token-hash").WillReturnRows(rows)
+
+	reset, err := s.gateway.GetPasswordResetByTokenHash(s.context, "random-token-hash")
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(internal.PasswordReset{ID: 3, UserID: 7, TokenHash: "random-token-hash", ExpiresAt: expiresAt}, reset)
+}
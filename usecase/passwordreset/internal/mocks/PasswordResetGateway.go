@@ -0,0 +1,116 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	internal "littlerollingsushi.com/example/usecase/passwordreset/internal"
+
+	time "time"
+)
+
+// PasswordResetGateway is an autogenerated mock type for the PasswordResetGateway type
+type PasswordResetGateway struct {
+	mock.Mock
+}
+
+// EncryptPassword provides a mock function with given fields: password, saltLength
+func (_m *PasswordResetGateway) EncryptPassword(password string, saltLength int) (string, error) {
+	ret := _m.Called(password, saltLength)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(password, saltLength)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(password, saltLength)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordResetByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PasswordResetGateway) GetPasswordResetByTokenHash(ctx context.Context, tokenHash string) (internal.PasswordReset, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 internal.PasswordReset
+	if rf, ok := ret.Get(0).(func(context.Context, string) internal.PasswordReset); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(internal.PasswordReset)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashToken provides a mock function with given fields: token
+func (_m *PasswordResetGateway) HashToken(token string) string {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *PasswordResetGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, reset, cryptedPassword, now
+func (_m *PasswordResetGateway) ResetPassword(ctx context.Context, reset internal.PasswordReset, cryptedPassword string, now time.Time) error {
+	ret := _m.Called(ctx, reset, cryptedPassword, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.PasswordReset, string, time.Time) error); ok {
+		r0 = rf(ctx, reset, cryptedPassword, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswordResetGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordResetGateway creates a new instance of PasswordResetGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordResetGateway(t mockConstructorTestingTNewPasswordResetGateway) *PasswordResetGateway {
+	mock := &PasswordResetGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,10 @@
+package internal
+
+import "errors"
+
+var (
+	ErrEmptyToken            = errors.New("token is empty")
+	ErrEmptyPassword         = errors.New("password is empty")
+	ErrPasswordResetNotFound = errors.New("password reset with given token is not found")
+	ErrPasswordResetExpired  = errors.New("password reset is expired")
+)
@@ -0,0 +1,15 @@
+package internal
+
+import "time"
+
+type ResetPasswordUsecaseInput struct {
+	Token    string
+	Password string
+}
+
+type PasswordReset struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+}
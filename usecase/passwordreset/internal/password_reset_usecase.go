@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+type PasswordResetUsecase struct {
+	config  PasswordResetUsecaseConfig
+	gateway PasswordResetGateway
+}
+
+type PasswordResetUsecaseConfig struct {
+	SaltLength int
+}
+
+//go:generate mockery --name=PasswordResetGateway --output=./mocks
+type PasswordResetGateway interface {
+	GetPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error)
+	ResetPassword(ctx context.Context, reset PasswordReset, cryptedPassword string, now time.Time) error
+	EncryptPassword(password string, saltLength int) (cryptedPassword string, err error)
+	HashToken(token string) string
+	NowInUTC() time.Time
+}
+
+func NewPasswordResetUsecase(config PasswordResetUsecaseConfig, gateway PasswordResetGateway) *PasswordResetUsecase {
+	return &PasswordResetUsecase{config: config, gateway: gateway}
+}
+
+func (u *PasswordResetUsecase) ResetPassword(ctx context.Context, in ResetPasswordUsecaseInput) error {
+	if in.Token == "" {
+		return ErrEmptyToken
+	}
+
+	if in.Password == "" {
+		return ErrEmptyPassword
+	}
+
+	reset, err := u.gateway.GetPasswordResetByTokenHash(ctx, u.gateway.HashToken(in.Token))
+	if err != nil {
+		return err
+	}
+
+	now := u.gateway.NowInUTC()
+	if !now.Before(reset.ExpiresAt) {
+		return ErrPasswordResetExpired
+	}
+
+	cryptedPassword, err := u.gateway.EncryptPassword(in.Password, u.config.SaltLength)
+	if err != nil {
+		return err
+	}
+
+	return u.gateway.ResetPassword(ctx, reset, cryptedPassword, now)
+}
@@ -0,0 +1,99 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal/mocks"
+)
+
+type PasswordResetUsecaseSuite struct {
+	suite.Suite
+
+	gateway *mocks.PasswordResetGateway
+	usecase *internal.PasswordResetUsecase
+
+	context context.Context
+	input   internal.ResetPasswordUsecaseInput
+	reset   internal.PasswordReset
+	now     time.Time
+	errMock error
+}
+
+func TestPasswordResetUsecaseSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetUsecaseSuite{})
+}
+
+func (s *PasswordResetUsecaseSuite) SetupTest() {
+	s.gateway = mocks.NewPasswordResetGateway(s.T())
+	s.usecase = internal.NewPasswordResetUsecase(internal.PasswordResetUsecaseConfig{SaltLength: 10}, s.gateway)
+
+	s.context = context.Background()
+	s.input = internal.ResetPasswordUsecaseInput{Token: "random-token", Password: "verysecure"}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.reset = internal.PasswordReset{ID: 3, UserID: 7, TokenHash: "random-token-hash", ExpiresAt: s.now.Add(time.Hour)}
+	s.errMock = errors.New("mock error")
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_EmptyToken_ReturnError() {
+	s.input.Token = ""
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrEmptyToken)
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_EmptyPassword_ReturnError() {
+	s.input.Password = ""
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrEmptyPassword)
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_NotFound_ReturnError() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetPasswordResetByTokenHash", s.context, "random-token-hash").Return(internal.PasswordReset{}, internal.ErrPasswordResetNotFound)
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrPasswordResetNotFound)
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_Expired_ReturnError() {
+	s.reset.ExpiresAt = s.now
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetPasswordResetByTokenHash", s.context, "random-token-hash").Return(s.reset, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrPasswordResetExpired)
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_EncryptError_ReturnError() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetPasswordResetByTokenHash", s.context, "random-token-hash").Return(s.reset, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("EncryptPassword", "verysecure", 10).Return("", s.errMock)
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *PasswordResetUsecaseSuite) TestResetPassword_Success_StoreNewPassword() {
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("GetPasswordResetByTokenHash", s.context, "random-token-hash").Return(s.reset, nil)
+	s.gateway.On("NowInUTC").Return(s.now)
+	s.gateway.On("EncryptPassword", "verysecure", 10).Return("verysecureencrypted", nil)
+	s.gateway.On("ResetPassword", s.context, s.reset, "verysecureencrypted", s.now).Return(nil)
+
+	err := s.usecase.ResetPassword(s.context, s.input)
+
+	s.Assert().Nil(err)
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	updateUserPasswordQuery = "UPDATE user SET crypted_password = ?, updated_at = ? WHERE id = ?"
+	usePasswordResetQuery   = "UPDATE password_reset SET used_at = ? WHERE id = ? AND used_at IS NULL"
+)
+
+type ResetPasswordGateway struct {
+	sql *sql.DB
+}
+
+func NewResetPasswordGateway(sql *sql.DB) *ResetPasswordGateway {
+	return &ResetPasswordGateway{sql: sql}
+}
+
+// ResetPassword stores the new password and marks the reset as used in a
+// single transaction, so a token can only be used once.
+func (g *ResetPasswordGateway) ResetPassword(ctx context.Context, reset PasswordReset, cryptedPassword string, now time.Time) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, usePasswordResetQuery, now, reset.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrPasswordResetNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, updateUserPasswordQuery, cryptedPassword, now, reset.UserID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
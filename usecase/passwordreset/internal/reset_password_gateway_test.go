@@ -0,0 +1,105 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/passwordreset/internal"
+)
+
+type ResetPasswordGatewaySuite struct {
+	suite.Suite
+
+	db                          *sql.DB
+	mockDb                      sqlmock.Sqlmock
+	expectedUseResetQuery       string
+	expectedUpdatePasswordQuery string
+	errMock                     error
+
+	context context.Context
+	reset   internal.PasswordReset
+	now     time.Time
+	gateway *internal.ResetPasswordGateway
+}
+
+func TestResetPasswordGatewaySuite(t *testing.T) {
+	suite.Run(t, &ResetPasswordGatewaySuite{})
+}
+
+func (s *ResetPasswordGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedUseResetQuery = "UPDATE password_reset SET used_at = ? WHERE id = ? AND used_at IS NULL"
+	s.expectedUpdatePasswordQuery = "UPDATE user SET crypted_password = ?, updated_at = ? WHERE id = ?"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewResetPasswordGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.reset = internal.PasswordReset{ID: 3, UserID: 7}
+}
+
+func (s *ResetPasswordGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ResetPasswordGatewaySuite) TestResetPassword_BeginError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin().WillReturnError(s.errMock)
+
+	err := s.gateway.ResetPassword(s.context, s.reset, "encrypted", s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *ResetPasswordGatewaySuite) TestResetPassword_AlreadyUsed_ReturnPasswordResetNotFoundErr() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUseResetQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ResetPassword(s.context, s.reset, "encrypted", s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, internal.ErrPasswordResetNotFound)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ResetPasswordGatewaySuite) TestResetPassword_UpdatePasswordError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUseResetQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdatePasswordQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ResetPassword(s.context, s.reset, "encrypted", s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ResetPasswordGatewaySuite) TestResetPassword_Success_ReturnNil() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUseResetQuery)).
+		WithArgs(s.now, s.reset.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedUpdatePasswordQuery)).
+		WithArgs("encrypted", s.now, s.reset.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.ResetPassword(s.context, s.reset, "encrypted", s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
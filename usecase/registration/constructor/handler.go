@@ -3,25 +3,35 @@ package constructor
 import (
 	"database/sql"
 
-	"golang.org/x/crypto/bcrypt"
-
+	"littlerollingsushi.com/example/config"
+	metricsConstructor "littlerollingsushi.com/example/metrics/constructor"
+	auditConstructor "littlerollingsushi.com/example/usecase/audit/constructor"
 	"littlerollingsushi.com/example/usecase/helper"
 	"littlerollingsushi.com/example/usecase/registration/handler"
 	"littlerollingsushi.com/example/usecase/registration/internal"
 )
 
-func ConstructRegisterHandler(db *sql.DB) *handler.RegisterHandler {
+// ConstructRegisterHandler takes the verifier from the challenge constructor so
+// that challenges are verified against the stores they were issued with.
+func ConstructRegisterHandler(cfg *config.Config, db *sql.DB, verifier helper.ChallengeVerifier) *handler.RegisterHandler {
 	gateway := internal.NewInsertUserGateway(db)
 	usecase := internal.NewRegisterUsecase(
-		internal.RegisterUsecaseConfig{SaltLength: bcrypt.DefaultCost},
+		internal.RegisterUsecaseConfig{SaltLength: cfg.Password.BcryptCost},
 		struct {
 			*internal.InsertUserGateway
+			helper.AuditRecorder
+			helper.ChallengeVerifier
 			*helper.PasswordEncrypter
+			helper.Timer
 		}{
 			InsertUserGateway: gateway,
-			PasswordEncrypter: &helper.PasswordEncrypter{},
+			AuditRecorder:     auditConstructor.ConstructAuditRecorder(db),
+			ChallengeVerifier: verifier,
+			PasswordEncrypter: &helper.PasswordEncrypter{Observer: metricsConstructor.ConstructMetrics()},
+			Timer:             &helper.TimerImplementation{},
 		},
 	)
 	timer := &helper.TimerImplementation{}
-	return handler.NewRegisterHandler(usecase, timer)
+	counted := &countedRegisterUsecase{RegisterUsecase: usecase, registrations: metricsConstructor.ConstructMetrics().Registrations}
+	return handler.NewRegisterHandler(counted, timer)
 }
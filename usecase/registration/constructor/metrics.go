@@ -0,0 +1,36 @@
+package constructor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"littlerollingsushi.com/example/usecase/registration/handler"
+	"littlerollingsushi.com/example/usecase/registration/internal"
+)
+
+// countedRegisterUsecase counts registration attempts by outcome.
+type countedRegisterUsecase struct {
+	handler.RegisterUsecase
+	registrations *prometheus.CounterVec
+}
+
+func (u *countedRegisterUsecase) Register(ctx context.Context, in internal.RegisterUsecaseInput) error {
+	err := u.RegisterUsecase.Register(ctx, in)
+	u.registrations.WithLabelValues(registrationOutcome(err)).Inc()
+	return err
+}
+
+func registrationOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, internal.ErrUserAlreadyExist):
+		return "duplicate"
+	case errors.Is(err, internal.ErrInvalidChallenge):
+		return "invalid_challenge"
+	default:
+		return "error"
+	}
+}
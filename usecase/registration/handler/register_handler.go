@@ -3,9 +3,9 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"littlerollingsushi.com/example/problem"
 	"littlerollingsushi.com/example/usecase/helper"
 	"littlerollingsushi.com/example/usecase/registration/internal"
 )
@@ -20,52 +20,47 @@ type RegisterUsecase interface {
 	Register(context.Context, internal.RegisterUsecaseInput) error
 }
 
+type registerRequest struct {
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	Challenge      string `json:"challenge"`
+	ChallengeNonce string `json:"challenge_nonce"`
+}
+
 func NewRegisterHandler(usecase RegisterUsecase, timer helper.Timer) *RegisterHandler {
 	return &RegisterHandler{usecase: usecase, timer: timer}
 }
 
 func (h *RegisterHandler) Register(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	req := registerRequest{}
+	if err := helper.DecodeRequestBody(w, r, helper.MaxRequestBodyBytes, &req); err != nil {
+		problem.WriteError(w, r, h.timer, err)
+		return
+	}
+
 	in := internal.RegisterUsecaseInput{
-		FirstName: r.FormValue("first_name"),
-		LastName:  r.FormValue("last_name"),
-		Email:     r.FormValue("email"),
-		Password:  r.FormValue("password"),
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Password:  req.Password,
+		IPAddress: helper.ClientIP(r),
+		UserAgent: r.UserAgent(),
+
+		ChallengeToken: req.Challenge,
+		ChallengeNonce: req.ChallengeNonce,
 	}
 
 	err := h.usecase.Register(r.Context(), in)
 	if err != nil {
-		h.processError(w, err)
+		problem.WriteError(w, r, h.timer, err)
 		return
 	}
 
 	h.writeRegisterResponse(w)
 }
 
-func (h *RegisterHandler) processError(w http.ResponseWriter, err error) {
-	switch err {
-	case internal.ErrUserAlreadyExist:
-		h.processUserAlreadyExistErr(w, err)
-	default:
-		fmt.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Oops! Something went wrong."))
-	}
-}
-
-func (h *RegisterHandler) processUserAlreadyExistErr(w http.ResponseWriter, err error) {
-	data := map[string]interface{}{
-		"message": "User already exists. Choose different email.",
-		"meta": map[string]interface{}{
-			"http_status": http.StatusUnprocessableEntity,
-			"server_time": h.timer.NowInUTC().Format("2006-01-02T15:04:05.999Z"),
-		},
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnprocessableEntity)
-	json.NewEncoder(w).Encode(data)
-}
-
 func (h *RegisterHandler) writeRegisterResponse(w http.ResponseWriter) {
 	data := map[string]interface{}{
 		"message": "User registered. Continue to login.",
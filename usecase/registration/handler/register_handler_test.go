@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/problem"
 	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
 	"littlerollingsushi.com/example/usecase/registration/handler"
 	"littlerollingsushi.com/example/usecase/registration/handler/mocks"
@@ -28,11 +30,10 @@ type RegisterHandlerSuite struct {
 	timer   *helperMocks.Timer
 	handler *handler.RegisterHandler
 
-	expectedUsecaseInput              internal.RegisterUsecaseInput
-	expectedTimestamp                 time.Time
-	expectedSuccessResponseBody       string
-	expectedDuplicateUserResponseBody string
-	errMock                           error
+	expectedUsecaseInput        internal.RegisterUsecaseInput
+	expectedTimestamp           time.Time
+	expectedSuccessResponseBody string
+	errMock                     error
 }
 
 func TestRegisterHandlerSuite(t *testing.T) {
@@ -45,8 +46,11 @@ func (s *RegisterHandlerSuite) SetupTest() {
 	form.Add("last_name", "doe")
 	form.Add("email", "john.doe@email.com")
 	form.Add("password", "verysecure")
+	form.Add("challenge", "v1.seed.20.1667087999.signature")
+	form.Add("challenge_nonce", "4242")
 	s.request = httptest.NewRequest("POST", "http://test.com/v1/register", strings.NewReader(form.Encode()))
 	s.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.request.Header.Set("User-Agent", "curl/7.85.0")
 
 	s.responseWriter = httptest.NewRecorder()
 
@@ -61,6 +65,11 @@ func (s *RegisterHandlerSuite) SetupTest() {
 		LastName:  "doe",
 		Email:     "john.doe@email.com",
 		Password:  "verysecure",
+		IPAddress: "192.0.2.1",
+		UserAgent: "curl/7.85.0",
+
+		ChallengeToken: "v1.seed.20.1667087999.signature",
+		ChallengeNonce: "4242",
 	}
 	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
 	s.expectedSuccessResponseBody = `
@@ -72,21 +81,29 @@ func (s *RegisterHandlerSuite) SetupTest() {
 			}
 		}
 	`
-	s.expectedDuplicateUserResponseBody = `
+
+	s.errMock = errors.New("mock error")
+}
+
+func (s *RegisterHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
 		{
-			"message": "User already exists. Choose different email.",
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
 			"meta": {
-				"http_status": 422,
+				"http_status": ` + strconv.Itoa(code.Status) + `,
 				"server_time": "2022-10-29T23:59:59.123Z"
 			}
 		}
 	`
-
-	s.errMock = errors.New("mock error")
 }
 
 func (s *RegisterHandlerSuite) TestRegister_UsecaseUnknownError_ReturnInternalServerError() {
 	s.usecase.On("Register", s.request.Context(), s.expectedUsecaseInput).Return(s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
 
 	s.handler.Register(s.responseWriter, s.request, s.requestParams)
 
@@ -94,10 +111,10 @@ func (s *RegisterHandlerSuite) TestRegister_UsecaseUnknownError_ReturnInternalSe
 	body, _ := io.ReadAll(resp.Body)
 	a := s.Assert()
 	a.Equal(http.StatusInternalServerError, resp.StatusCode)
-	a.Equal("Oops! Something went wrong.", string(body))
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
 }
 
-func (s *RegisterHandlerSuite) TestRegister_DuplicateUser_ReturnInternalServerError() {
+func (s *RegisterHandlerSuite) TestRegister_DuplicateUser_ReturnConflict() {
 	s.usecase.On("Register", s.request.Context(), s.expectedUsecaseInput).Return(internal.ErrUserAlreadyExist)
 	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
 
@@ -106,8 +123,86 @@ func (s *RegisterHandlerSuite) TestRegister_DuplicateUser_ReturnInternalServerEr
 	resp := s.responseWriter.Result()
 	body, _ := io.ReadAll(resp.Body)
 	a := s.Assert()
-	a.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
-	a.JSONEq(s.expectedDuplicateUserResponseBody, string(body))
+	a.Equal(http.StatusConflict, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "user_already_exists", Status: 409, Title: "Conflict"}, "User already exists. Choose different email."), string(body))
+}
+
+func (s *RegisterHandlerSuite) TestRegister_InvalidChallenge_ReturnBadRequest() {
+	s.usecase.On("Register", s.request.Context(), s.expectedUsecaseInput).Return(internal.ErrInvalidChallenge)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Register(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Code{Code: "invalid_challenge", Status: 400, Title: "Invalid request"}, "Proof-of-work challenge is missing, invalid or expired. Request a new one from /v1/challenge."), string(body))
+}
+
+func (s *RegisterHandlerSuite) TestRegister_JSONBody_PassFieldsToUsecase() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/register", strings.NewReader(`
+		{
+			"first_name": "john",
+			"last_name": "doe",
+			"email": "john.doe@email.com",
+			"password": "verysecure",
+			"challenge": "v1.seed.20.1667087999.signature",
+			"challenge_nonce": "4242"
+		}
+	`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.request.Header.Set("User-Agent", "curl/7.85.0")
+	s.usecase.On("Register", s.request.Context(), s.expectedUsecaseInput).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Register(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusCreated, resp.StatusCode)
+	a.JSONEq(s.expectedSuccessResponseBody, string(body))
+}
+
+func (s *RegisterHandlerSuite) TestRegister_UnsupportedContentType_ReturnUnsupportedMediaType() {
+	s.request.Header.Set("Content-Type", "application/xml")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Register(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.UnsupportedMediaType, "Unsupported content type. Send application/json or a form."), string(body))
+}
+
+func (s *RegisterHandlerSuite) TestRegister_MalformedJSON_ReturnBadRequest() {
+	s.request = httptest.NewRequest("POST", "http://test.com/v1/register", strings.NewReader(`{"email": 42}`))
+	s.request.Header.Set("Content-Type", "application/json")
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.Register(s.responseWriter, s.request, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"type": "https://littlerollingsushi.com/problems/malformed_request_body",
+			"title": "Malformed request body",
+			"status": 400,
+			"code": "malformed_request_body",
+			"detail": "Malformed request body.",
+			"details": ["field \"email\" must be a string"],
+			"meta": {
+				"http_status": 400,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
 }
 
 func (s *RegisterHandlerSuite) TestRegister_UsecaseSuccess_ReturnCreated() {
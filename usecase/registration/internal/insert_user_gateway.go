@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/tracing"
 )
 
 const (
@@ -21,17 +22,21 @@ func NewInsertUserGateway(sql *sql.DB) *InsertUserGateway {
 	return &InsertUserGateway{sql: sql}
 }
 
-func (g *InsertUserGateway) InsertUser(ctx context.Context, user entity.User) error {
-	_, err := g.sql.ExecContext(ctx, insertUserQuery, user.FirstName, user.LastName, user.Email, user.CryptedPassword, time.Now().UTC())
+// InsertUser returns the id of the inserted user.
+func (g *InsertUserGateway) InsertUser(ctx context.Context, user entity.User) (id int64, err error) {
+	ctx, span := tracing.StartQuery(ctx, "InsertUserGateway.InsertUser", insertUserQuery)
+	defer func() { tracing.End(span, err) }()
+
+	res, err := g.sql.ExecContext(ctx, insertUserQuery, user.FirstName, user.LastName, user.Email, user.CryptedPassword, time.Now().UTC())
 	if err != nil {
 		if me, ok := err.(*mysql.MySQLError); ok {
 			if me.Number == errNoDuplicateRecord {
-				return ErrUserAlreadyExist
+				return 0, ErrUserAlreadyExist
 			}
 		}
 
-		return err
+		return 0, err
 	}
 
-	return nil
+	return res.LastInsertId()
 }
@@ -61,7 +61,7 @@ func (s *InsertUserGatewaySuite) TearDownTest() {
 func (s *InsertUserGatewaySuite) TestInsertUser_UnknownError_ReturnOriginalError() {
 	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
 
-	err := s.gateway.InsertUser(s.context, s.input)
+	_, err := s.gateway.InsertUser(s.context, s.input)
 
 	s.Assert().ErrorIs(err, s.errMock)
 }
@@ -69,15 +69,17 @@ func (s *InsertUserGatewaySuite) TestInsertUser_UnknownError_ReturnOriginalError
 func (s *InsertUserGatewaySuite) TestInsertUser_DuplicateUser_ReturnUserAlreadyExistErr() {
 	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errDuplicateRecord)
 
-	err := s.gateway.InsertUser(s.context, s.input)
+	_, err := s.gateway.InsertUser(s.context, s.input)
 
 	s.Assert().ErrorIs(err, internal.ErrUserAlreadyExist)
 }
 
-func (s *InsertUserGatewaySuite) TestInsertUser_InsertSuccess_ReturnNil() {
-	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnResult(sqlmock.NewResult(1, 1))
+func (s *InsertUserGatewaySuite) TestInsertUser_InsertSuccess_ReturnUserID() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnResult(sqlmock.NewResult(7, 1))
 
-	err := s.gateway.InsertUser(s.context, s.input)
+	userID, err := s.gateway.InsertUser(s.context, s.input)
 
-	s.Assert().Nil(err)
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(7), userID)
 }
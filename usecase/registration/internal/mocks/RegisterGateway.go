@@ -8,6 +8,8 @@ import (
 	entity "littlerollingsushi.com/example/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RegisterGateway is an autogenerated mock type for the RegisterGateway type
@@ -37,12 +39,47 @@ func (_m *RegisterGateway) EncryptPassword(password string, saltLength int) (str
 }
 
 // InsertUser provides a mock function with given fields: _a0, _a1
-func (_m *RegisterGateway) InsertUser(_a0 context.Context, _a1 entity.User) error {
+func (_m *RegisterGateway) InsertUser(_a0 context.Context, _a1 entity.User) (int64, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) int64); ok {
 		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *RegisterGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, event
+func (_m *RegisterGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,6 +87,27 @@ func (_m *RegisterGateway) InsertUser(_a0 context.Context, _a1 entity.User) erro
 	return r0
 }
 
+// VerifyChallenge provides a mock function with given fields: ctx, token, nonce
+func (_m *RegisterGateway) VerifyChallenge(ctx context.Context, token string, nonce string) (bool, error) {
+	ret := _m.Called(ctx, token, nonce)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, token, nonce)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRegisterGateway interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,11 +1,22 @@
 package internal
 
-import "errors"
+import "littlerollingsushi.com/example/usecase/helper"
 
 const (
 	errNoDuplicateRecord = 1062
 )
 
 var (
-	ErrUserAlreadyExist = errors.New("user already exists")
+	ErrUserAlreadyExist = &helper.DomainError{
+		Kind:    helper.ErrorKindConflict,
+		Code:    "user_already_exists",
+		Message: "User already exists. Choose different email.",
+		Reason:  "user already exists",
+	}
+	ErrInvalidChallenge = &helper.DomainError{
+		Kind:    helper.ErrorKindInvalid,
+		Code:    "invalid_challenge",
+		Message: "Proof-of-work challenge is missing, invalid or expired. Request a new one from /v1/challenge.",
+		Reason:  "proof-of-work challenge is invalid",
+	}
 )
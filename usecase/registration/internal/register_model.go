@@ -1,8 +1,17 @@
 package internal
 
+const (
+	AuditEventRegister = "auth.register"
+)
+
 type RegisterUsecaseInput struct {
 	FirstName string
 	LastName  string
 	Email     string
 	Password  string
+	IPAddress string
+	UserAgent string
+
+	ChallengeToken string
+	ChallengeNonce string
 }
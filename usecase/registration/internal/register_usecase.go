@@ -2,8 +2,11 @@ package internal
 
 import (
 	"context"
+	"time"
 
 	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/logging"
+	"littlerollingsushi.com/example/tracing"
 )
 
 type RegisterUsecase struct {
@@ -18,7 +21,10 @@ type RegisterUsecaseConfig struct {
 //go:generate mockery --name=RegisterGateway --output=./mocks
 type RegisterGateway interface {
 	EncryptPassword(password string, saltLength int) (cryptedPassword string, err error)
-	InsertUser(context.Context, entity.User) error
+	InsertUser(context.Context, entity.User) (int64, error)
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
+	VerifyChallenge(ctx context.Context, token, nonce string) (bool, error)
+	NowInUTC() time.Time
 }
 
 func NewRegisterUsecase(config RegisterUsecaseConfig, gateway RegisterGateway) *RegisterUsecase {
@@ -28,10 +34,51 @@ func NewRegisterUsecase(config RegisterUsecaseConfig, gateway RegisterGateway) *
 	}
 }
 
-func (u *RegisterUsecase) Register(ctx context.Context, in RegisterUsecaseInput) error {
-	cryptedPassword, err := u.gateway.EncryptPassword(in.Password, u.config.SaltLength)
+// Register records every attempt in the audit log, successful or not. Failing
+// to record it is logged only, as the user may already be inserted and a retry
+// would then be refused.
+func (u *RegisterUsecase) Register(ctx context.Context, in RegisterUsecaseInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RegisterUsecase.Register")
+	defer func() { tracing.End(span, err) }()
+
+	userID, err := u.register(ctx, in)
+
+	event := entity.AuditEvent{
+		Type:      AuditEventRegister,
+		IPAddress: in.IPAddress,
+		UserAgent: in.UserAgent,
+		Outcome:   entity.AuditOutcomeSuccess,
+		CreatedAt: u.gateway.NowInUTC(),
+	}
+	if userID != 0 {
+		event.UserID = &userID
+	}
+	if err != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason = err.Error()
+	}
+
+	if auditErr := u.gateway.RecordAuditEvent(ctx, event); auditErr != nil {
+		logging.FromContext(ctx).Error("recording audit event failed", "event", event.Type, "error", auditErr)
+	}
+
+	return err
+}
+
+// register checks the proof-of-work challenge before hashing the password, so
+// requests without a solved challenge are turned away cheaply.
+func (u *RegisterUsecase) register(ctx context.Context, in RegisterUsecaseInput) (int64, error) {
+	valid, err := u.gateway.VerifyChallenge(ctx, in.ChallengeToken, in.ChallengeNonce)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if !valid {
+		return 0, ErrInvalidChallenge
+	}
+
+	cryptedPassword, err := u.encryptPassword(ctx, in.Password)
+	if err != nil {
+		return 0, err
 	}
 
 	user := entity.User{
@@ -43,3 +90,11 @@ func (u *RegisterUsecase) Register(ctx context.Context, in RegisterUsecaseInput)
 
 	return u.gateway.InsertUser(ctx, user)
 }
+
+func (u *RegisterUsecase) encryptPassword(ctx context.Context, password string) (string, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
+	cryptedPassword, err := u.gateway.EncryptPassword(password, u.config.SaltLength)
+	tracing.End(span, err)
+
+	return cryptedPassword, err
+}
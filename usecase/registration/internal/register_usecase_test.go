@@ -4,23 +4,31 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"littlerollingsushi.com/example/entity"
 	"littlerollingsushi.com/example/usecase/registration/internal"
 	"littlerollingsushi.com/example/usecase/registration/internal/mocks"
 )
 
+type testContextKey struct{}
+
 type RegisterUsecaseSuite struct {
 	suite.Suite
 
-	config  internal.RegisterUsecaseConfig
-	gateway *mocks.RegisterGateway
-	usecase *internal.RegisterUsecase
+	config        internal.RegisterUsecaseConfig
+	gateway       *mocks.RegisterGateway
+	usecase       *internal.RegisterUsecase
+	auditCall     *mock.Call
+	challengeCall *mock.Call
 
 	context                context.Context
+	gatewayContext         interface{}
 	input                  internal.RegisterUsecaseInput
 	cryptedPassword        string
+	now                    time.Time
 	errMock                error
 	expectedInsertUserData entity.User
 }
@@ -34,14 +42,25 @@ func (s *RegisterUsecaseSuite) SetupTest() {
 	s.gateway = mocks.NewRegisterGateway(s.T())
 	s.usecase = internal.NewRegisterUsecase(s.config, s.gateway)
 
-	s.context = context.Background()
+	s.context = context.WithValue(context.Background(), testContextKey{}, s.T().Name())
+	// Gateways get a context derived from the one of the call, carrying the span
+	// of the usecase.
+	s.gatewayContext = mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(testContextKey{}) == s.T().Name()
+	})
 	s.input = internal.RegisterUsecaseInput{
 		FirstName: "John",
 		LastName:  "Doe",
 		Email:     "john.doe@email.com",
 		Password:  "verysecure",
+		IPAddress: "192.0.2.1",
+		UserAgent: "curl/7.85.0",
+
+		ChallengeToken: "v1.seed.20.1667087999.signature",
+		ChallengeNonce: "4242",
 	}
 	s.cryptedPassword = "verysecureencrypted"
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
 	s.errMock = errors.New("mock error")
 	s.expectedInsertUserData = entity.User{
 		FirstName:       s.input.FirstName,
@@ -49,6 +68,43 @@ func (s *RegisterUsecaseSuite) SetupTest() {
 		Email:           s.input.Email,
 		CryptedPassword: s.cryptedPassword,
 	}
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+	s.auditCall = s.gateway.On("RecordAuditEvent", s.gatewayContext, mock.Anything).Return(nil).Maybe()
+	s.challengeCall = s.gateway.On("VerifyChallenge", s.gatewayContext, s.input.ChallengeToken, s.input.ChallengeNonce).
+		Return(true, nil).Maybe()
+}
+
+func (s *RegisterUsecaseSuite) expectedAuditEvent(userID *int64, outcome, reason string) entity.AuditEvent {
+	return entity.AuditEvent{
+		Type:      internal.AuditEventRegister,
+		UserID:    userID,
+		IPAddress: s.input.IPAddress,
+		UserAgent: s.input.UserAgent,
+		Outcome:   outcome,
+		Reason:    reason,
+		CreatedAt: s.now,
+	}
+}
+
+func (s *RegisterUsecaseSuite) TestRegister_VerifyChallengeError_ReturnOriginalError() {
+	s.challengeCall.Return(false, s.errMock)
+
+	err := s.usecase.Register(s.context, s.input)
+
+	s.Assert().ErrorIs(err, s.errMock)
+	s.gateway.AssertNotCalled(s.T(), "EncryptPassword", mock.Anything, mock.Anything)
+}
+
+func (s *RegisterUsecaseSuite) TestRegister_InvalidChallenge_ReturnErrInvalidChallenge() {
+	s.challengeCall.Return(false, nil)
+
+	err := s.usecase.Register(s.context, s.input)
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidChallenge)
+	s.gateway.AssertNotCalled(s.T(), "EncryptPassword", mock.Anything, mock.Anything)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(nil, entity.AuditOutcomeFailure, internal.ErrInvalidChallenge.Error()))
 }
 
 func (s *RegisterUsecaseSuite) TestRegister_GeneratePasswordFailed_ReturnOriginalError() {
@@ -61,16 +117,31 @@ func (s *RegisterUsecaseSuite) TestRegister_GeneratePasswordFailed_ReturnOrigina
 
 func (s *RegisterUsecaseSuite) TestRegister_InsertUserFailed_ReturnOriginalError() {
 	s.gateway.On("EncryptPassword", s.input.Password, s.config.SaltLength).Return(s.cryptedPassword, nil)
-	s.gateway.On("InsertUser", s.context, s.expectedInsertUserData).Return(s.errMock)
+	s.gateway.On("InsertUser", s.gatewayContext, s.expectedInsertUserData).Return(int64(0), s.errMock)
 
 	err := s.usecase.Register(s.context, s.input)
 
 	s.Assert().ErrorIs(err, s.errMock)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(nil, entity.AuditOutcomeFailure, s.errMock.Error()))
 }
 
 func (s *RegisterUsecaseSuite) TestRegister_InsertUserSuccess_ReturnNil() {
 	s.gateway.On("EncryptPassword", s.input.Password, s.config.SaltLength).Return(s.cryptedPassword, nil)
-	s.gateway.On("InsertUser", s.context, s.expectedInsertUserData).Return(nil)
+	s.gateway.On("InsertUser", s.gatewayContext, s.expectedInsertUserData).Return(int64(7), nil)
+
+	err := s.usecase.Register(s.context, s.input)
+
+	userID := int64(7)
+	s.Assert().Nil(err)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.gatewayContext,
+		s.expectedAuditEvent(&userID, entity.AuditOutcomeSuccess, ""))
+}
+
+func (s *RegisterUsecaseSuite) TestRegister_RecordAuditEventError_ReturnNil() {
+	s.gateway.On("EncryptPassword", s.input.Password, s.config.SaltLength).Return(s.cryptedPassword, nil)
+	s.gateway.On("InsertUser", s.gatewayContext, s.expectedInsertUserData).Return(int64(7), nil)
+	s.auditCall.Return(s.errMock)
 
 	err := s.usecase.Register(s.context, s.input)
 
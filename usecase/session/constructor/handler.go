@@ -0,0 +1,43 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/config"
+	auditConstructor "littlerollingsushi.com/example/usecase/audit/constructor"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/session/handler"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+func ConstructSessionHandler(cfg *config.Config, db *sql.DB) *handler.SessionHandler {
+	timer := &helper.TimerImplementation{}
+	return handler.NewSessionHandler(ConstructSessionUsecase(cfg, db), timer)
+}
+
+// ConstructSessionUsecase is also used by the authentication middleware to
+// check that the session of an access token is still active.
+func ConstructSessionUsecase(cfg *config.Config, db *sql.DB) *internal.SessionUsecase {
+	return internal.NewSessionUsecase(
+		internal.SessionUsecaseConfig{TouchInterval: cfg.Session.TouchInterval},
+		struct {
+			*internal.ListActiveSessionsGateway
+			*internal.GetSessionGateway
+			*internal.RevokeSessionGateway
+			*internal.TouchSessionGateway
+			helper.AuditRecorder
+			helper.Timer
+		}{
+			ListActiveSessionsGateway: internal.NewListActiveSessionsGateway(db),
+			GetSessionGateway:         internal.NewGetSessionGateway(db),
+			RevokeSessionGateway:      internal.NewRevokeSessionGateway(db),
+			TouchSessionGateway:       internal.NewTouchSessionGateway(db),
+			AuditRecorder:             auditConstructor.ConstructAuditRecorder(db),
+			Timer:                     &helper.TimerImplementation{},
+		},
+	)
+}
+
+func ConstructPersonalDataExporter(db *sql.DB) helper.PersonalDataExporter {
+	return internal.NewSessionDataExporter(db)
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+
+	internal "littlerollingsushi.com/example/usecase/session/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionUsecase is an autogenerated mock type for the SessionUsecase type
+type SessionUsecase struct {
+	mock.Mock
+}
+
+// ListSessions provides a mock function with given fields: ctx, userID
+func (_m *SessionUsecase) ListSessions(ctx context.Context, userID int64) ([]entity.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeOtherSessions provides a mock function with given fields: _a0, _a1
+func (_m *SessionUsecase) RevokeOtherSessions(_a0 context.Context, _a1 internal.Actor) (internal.RevokeOtherSessionsUsecaseOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.RevokeOtherSessionsUsecaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, internal.Actor) internal.RevokeOtherSessionsUsecaseOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.RevokeOtherSessionsUsecaseOutput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.Actor) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: _a0, _a1
+func (_m *SessionUsecase) RevokeSession(_a0 context.Context, _a1 internal.RevokeSessionUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.RevokeSessionUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionUsecase creates a new instance of SessionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionUsecase(t mockConstructorTestingTNewSessionUsecase) *SessionUsecase {
+	mock := &SessionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
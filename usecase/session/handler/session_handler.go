@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+const timeFormat = "2006-01-02T15:04:05.999Z"
+
+type SessionHandler struct {
+	usecase SessionUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=SessionUsecase --output=./mocks
+type SessionUsecase interface {
+	ListSessions(ctx context.Context, userID int64) ([]entity.Session, error)
+	RevokeSession(context.Context, internal.RevokeSessionUsecaseInput) error
+	RevokeOtherSessions(context.Context, internal.Actor) (internal.RevokeOtherSessionsUsecaseOutput, error)
+}
+
+func NewSessionHandler(usecase SessionUsecase, timer helper.Timer) *SessionHandler {
+	return &SessionHandler{usecase: usecase, timer: timer}
+}
+
+// ListSessions must be registered behind the authentication middleware. The
+// session the request is made with is flagged as current.
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.usecase.ListSessions(r.Context(), actor.UserID)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	data := []map[string]interface{}{}
+	for _, session := range sessions {
+		data = append(data, map[string]interface{}{
+			"id":           session.ID,
+			"ip_address":   session.IPAddress,
+			"user_agent":   session.UserAgent,
+			"created_at":   session.CreatedAt.Format(timeFormat),
+			"last_used_at": session.LastUsedAt.Format(timeFormat),
+			"expires_at":   session.ExpiresAt.Format(timeFormat),
+			"current":      session.ID == actor.SessionID,
+		})
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{"sessions": data})
+}
+
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil || sessionID <= 0 {
+		problem.Write(w, h.timer, problem.SessionNotFound, "Session not found.")
+		return
+	}
+
+	if err := h.usecase.RevokeSession(r.Context(), internal.RevokeSessionUsecaseInput{Actor: actor, SessionID: sessionID}); err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "Session has been revoked.")
+}
+
+func (h *SessionHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.usecase.RevokeOtherSessions(r.Context(), actor)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{
+		"message":          "Other sessions have been revoked.",
+		"revoked_sessions": out.RevokedSessions,
+	})
+}
+
+func (h *SessionHandler) actor(w http.ResponseWriter, r *http.Request) (internal.Actor, bool) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok || claims.UserID == 0 || claims.SessionID == 0 {
+		problem.Write(w, h.timer, problem.Unauthorized, "Missing or invalid access token.")
+		return internal.Actor{}, false
+	}
+
+	return internal.Actor{
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		IPAddress: helper.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}, true
+}
+
+func (h *SessionHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case internal.ErrSessionNotFound:
+		problem.Write(w, h.timer, problem.SessionNotFound, "Session not found.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *SessionHandler) writeMessageResponse(w http.ResponseWriter, status int, message string) {
+	h.writeDataResponse(w, status, map[string]interface{}{"message": message})
+}
+
+func (h *SessionHandler) writeDataResponse(w http.ResponseWriter, status int, data map[string]interface{}) {
+	data["meta"] = map[string]interface{}{
+		"http_status": status,
+		"server_time": h.timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,238 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+	"littlerollingsushi.com/example/usecase/session/handler"
+	"littlerollingsushi.com/example/usecase/session/handler/mocks"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type SessionHandlerSuite struct {
+	suite.Suite
+
+	responseWriter *httptest.ResponseRecorder
+	requestParams  map[string]string
+
+	usecase *mocks.SessionUsecase
+	timer   *helperMocks.Timer
+	handler *handler.SessionHandler
+
+	expectedActor     internal.Actor
+	expectedTimestamp time.Time
+	errMock           error
+}
+
+func TestSessionHandlerSuite(t *testing.T) {
+	suite.Run(t, &SessionHandlerSuite{})
+}
+
+func (s *SessionHandlerSuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+	s.requestParams = map[string]string{"id": "4"}
+
+	s.usecase = mocks.NewSessionUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewSessionHandler(s.usecase, s.timer)
+
+	s.expectedActor = internal.Actor{UserID: 7, SessionID: 3, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *SessionHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *SessionHandlerSuite) newRequest(method, target string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	r.RemoteAddr = "192.0.2.1:54321"
+	r.Header.Set("User-Agent", "curl/7.85.0")
+
+	claims := &helper.AccessTokenClaims{UserID: 7, SessionID: 3}
+	return r.WithContext(helper.ContextWithAccessTokenClaims(r.Context(), claims))
+}
+
+func (s *SessionHandlerSuite) expectedResponseBody(status, message string) string {
+	return `
+		{
+			"message": "` + message + `",
+			"meta": {
+				"http_status": ` + status + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *SessionHandlerSuite) TestListSessions_NoClaims_ReturnUnauthorized() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListSessions(s.responseWriter, httptest.NewRequest("GET", "http://test.com/v1/me/sessions", nil), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.Unauthorized, "Missing or invalid access token."), string(body))
+}
+
+func (s *SessionHandlerSuite) TestListSessions_UsecaseError_ReturnInternalServerError() {
+	r := s.newRequest("GET", "http://test.com/v1/me/sessions")
+	s.usecase.On("ListSessions", r.Context(), int64(7)).Return(nil, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListSessions(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *SessionHandlerSuite) TestListSessions_Success_ReturnSessionsWithCurrentFlag() {
+	r := s.newRequest("GET", "http://test.com/v1/me/sessions")
+	createdAt := time.Date(2022, 10, 29, 12, 0, 0, 0, time.UTC)
+	s.usecase.On("ListSessions", r.Context(), int64(7)).Return([]entity.Session{
+		{ID: 3, UserID: 7, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0", CreatedAt: createdAt, LastUsedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour)},
+		{ID: 2, UserID: 7, IPAddress: "198.51.100.7", UserAgent: "Mozilla/5.0", CreatedAt: createdAt, LastUsedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour)},
+	}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListSessions(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"sessions": [
+				{
+					"id": 3,
+					"ip_address": "192.0.2.1",
+					"user_agent": "curl/7.85.0",
+					"created_at": "2022-10-29T12:00:00Z",
+					"last_used_at": "2022-10-29T12:00:00Z",
+					"expires_at": "2022-10-29T13:00:00Z",
+					"current": true
+				},
+				{
+					"id": 2,
+					"ip_address": "198.51.100.7",
+					"user_agent": "Mozilla/5.0",
+					"created_at": "2022-10-29T12:00:00Z",
+					"last_used_at": "2022-10-29T12:00:00Z",
+					"expires_at": "2022-10-29T13:00:00Z",
+					"current": false
+				}
+			],
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
+
+func (s *SessionHandlerSuite) TestRevokeSession_InvalidID_ReturnNotFound() {
+	s.requestParams["id"] = "abc"
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RevokeSession(s.responseWriter, s.newRequest("DELETE", "http://test.com/v1/me/sessions/abc"), s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusNotFound, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.SessionNotFound, "Session not found."), string(body))
+}
+
+func (s *SessionHandlerSuite) TestRevokeSession_SessionNotFound_ReturnNotFound() {
+	r := s.newRequest("DELETE", "http://test.com/v1/me/sessions/4")
+	s.usecase.On("RevokeSession", r.Context(), internal.RevokeSessionUsecaseInput{Actor: s.expectedActor, SessionID: 4}).
+		Return(internal.ErrSessionNotFound)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RevokeSession(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusNotFound, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.SessionNotFound, "Session not found."), string(body))
+}
+
+func (s *SessionHandlerSuite) TestRevokeSession_Success_ReturnOK() {
+	r := s.newRequest("DELETE", "http://test.com/v1/me/sessions/4")
+	s.usecase.On("RevokeSession", r.Context(), internal.RevokeSessionUsecaseInput{Actor: s.expectedActor, SessionID: 4}).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RevokeSession(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(s.expectedResponseBody("200", "Session has been revoked."), string(body))
+}
+
+func (s *SessionHandlerSuite) TestRevokeOtherSessions_UsecaseError_ReturnInternalServerError() {
+	r := s.newRequest("DELETE", "http://test.com/v1/me/sessions")
+	s.usecase.On("RevokeOtherSessions", r.Context(), s.expectedActor).Return(internal.RevokeOtherSessionsUsecaseOutput{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RevokeOtherSessions(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+}
+
+func (s *SessionHandlerSuite) TestRevokeOtherSessions_Success_ReturnRevokedCount() {
+	r := s.newRequest("DELETE", "http://test.com/v1/me/sessions")
+	s.usecase.On("RevokeOtherSessions", r.Context(), s.expectedActor).Return(internal.RevokeOtherSessionsUsecaseOutput{RevokedSessions: 2}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.RevokeOtherSessions(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.JSONEq(`
+		{
+			"message": "Other sessions have been revoked.",
+			"revoked_sessions": 2,
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, string(body))
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	getSessionQuery = "SELECT id, user_id, ip_address, user_agent, created_at, last_used_at, expires_at, revoked_at FROM user_session WHERE id = ?"
+)
+
+type GetSessionGateway struct {
+	sql *sql.DB
+}
+
+func NewGetSessionGateway(sql *sql.DB) *GetSessionGateway {
+	return &GetSessionGateway{sql: sql}
+}
+
+func (g *GetSessionGateway) GetSession(ctx context.Context, sessionID int64) (entity.Session, error) {
+	session := entity.Session{}
+	var ipAddress, userAgent sql.NullString
+	var revokedAt sql.NullTime
+	err := g.sql.QueryRowContext(ctx, getSessionQuery, sessionID).Scan(
+		&session.ID,
+		&session.UserID,
+		&ipAddress,
+		&userAgent,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err == sql.ErrNoRows {
+		return entity.Session{}, ErrSessionNotFound
+	}
+
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	session.IPAddress = ipAddress.String
+	session.UserAgent = userAgent.String
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return session, nil
+}
@@ -0,0 +1,90 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type GetSessionGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	columns       []string
+	errMock       error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.GetSessionGateway
+}
+
+func TestGetSessionGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetSessionGatewaySuite{})
+}
+
+func (s *GetSessionGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, user_id, ip_address, user_agent, created_at, last_used_at, expires_at, revoked_at FROM user_session WHERE id = ?"
+	s.columns = []string{"id", "user_id", "ip_address", "user_agent", "created_at", "last_used_at", "expires_at", "revoked_at"}
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewGetSessionGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *GetSessionGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetSessionGatewaySuite) TestGetSession_NoRows_ReturnSessionNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows(s.columns))
+
+	_, err := s.gateway.GetSession(s.context, 3)
+
+	s.Assert().ErrorIs(err, internal.ErrSessionNotFound)
+}
+
+func (s *GetSessionGatewaySuite) TestGetSession_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	_, err := s.gateway.GetSession(s.context, 3)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *GetSessionGatewaySuite) TestGetSession_RevokedSession_ReturnRevokedAt() {
+	rows := sqlmock.NewRows(s.columns).AddRow(3, 7, "192.0.2.1", "curl/7.85.0", s.now, s.now, s.now.Add(time.Hour), s.now)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(int64(3)).WillReturnRows(rows)
+
+	session, err := s.gateway.GetSession(s.context, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(entity.Session{
+		ID:         3,
+		UserID:     7,
+		IPAddress:  "192.0.2.1",
+		UserAgent:  "curl/7.85.0",
+		CreatedAt:  s.now,
+		LastUsedAt: s.now,
+		ExpiresAt:  s.now.Add(time.Hour),
+		RevokedAt:  &s.now,
+	}, session)
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	listActiveSessionsQuery = "SELECT id, user_id, ip_address, user_agent, created_at, last_used_at, expires_at FROM user_session " +
+		"WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ? ORDER BY last_used_at DESC, id DESC"
+)
+
+type ListActiveSessionsGateway struct {
+	sql *sql.DB
+}
+
+func NewListActiveSessionsGateway(sql *sql.DB) *ListActiveSessionsGateway {
+	return &ListActiveSessionsGateway{sql: sql}
+}
+
+// ListActiveSessions returns the sessions of a user that are neither revoked
+// nor expired, most recently used first.
+func (g *ListActiveSessionsGateway) ListActiveSessions(ctx context.Context, userID int64, now time.Time) ([]entity.Session, error) {
+	rows, err := g.sql.QueryContext(ctx, listActiveSessionsQuery, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []entity.Session{}
+	for rows.Next() {
+		session := entity.Session{}
+		var ipAddress, userAgent sql.NullString
+		if err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&ipAddress,
+			&userAgent,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+
+		session.IPAddress = ipAddress.String
+		session.UserAgent = userAgent.String
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,79 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type ListActiveSessionsGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.ListActiveSessionsGateway
+}
+
+func TestListActiveSessionsGatewaySuite(t *testing.T) {
+	suite.Run(t, &ListActiveSessionsGatewaySuite{})
+}
+
+func (s *ListActiveSessionsGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, user_id, ip_address, user_agent, created_at, last_used_at, expires_at FROM user_session " +
+		"WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ? ORDER BY last_used_at DESC, id DESC"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewListActiveSessionsGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *ListActiveSessionsGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ListActiveSessionsGatewaySuite) TestListActiveSessions_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	sessions, err := s.gateway.ListActiveSessions(s.context, 7, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(sessions)
+}
+
+func (s *ListActiveSessionsGatewaySuite) TestListActiveSessions_Success_ReturnSessions() {
+	rows := sqlmock.NewRows([]string{"id", "user_id", "ip_address", "user_agent", "created_at", "last_used_at", "expires_at"}).
+		AddRow(3, 7, "192.0.2.1", "curl/7.85.0", s.now, s.now, s.now.Add(time.Hour)).
+		AddRow(2, 7, nil, nil, s.now, s.now, s.now.Add(time.Hour))
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(int64(7), s.now).WillReturnRows(rows)
+
+	sessions, err := s.gateway.ListActiveSessions(s.context, 7, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.Session{
+		{ID: 3, UserID: 7, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0", CreatedAt: s.now, LastUsedAt: s.now, ExpiresAt: s.now.Add(time.Hour)},
+		{ID: 2, UserID: 7, CreatedAt: s.now, LastUsedAt: s.now, ExpiresAt: s.now.Add(time.Hour)},
+	}, sessions)
+}
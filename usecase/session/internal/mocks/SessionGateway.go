@@ -0,0 +1,154 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SessionGateway is an autogenerated mock type for the SessionGateway type
+type SessionGateway struct {
+	mock.Mock
+}
+
+// GetSession provides a mock function with given fields: ctx, sessionID
+func (_m *SessionGateway) GetSession(ctx context.Context, sessionID int64) (entity.Session, error) {
+	ret := _m.Called(ctx, sessionID)
+
+	var r0 entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.Session); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Get(0).(entity.Session)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActiveSessions provides a mock function with given fields: ctx, userID, now
+func (_m *SessionGateway) ListActiveSessions(ctx context.Context, userID int64, now time.Time) ([]entity.Session, error) {
+	ret := _m.Called(ctx, userID, now)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) []entity.Session); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *SessionGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, event
+func (_m *SessionGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOtherSessions provides a mock function with given fields: ctx, userID, keepSessionID, now
+func (_m *SessionGateway) RevokeOtherSessions(ctx context.Context, userID int64, keepSessionID int64, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, userID, keepSessionID, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, time.Time) int64); ok {
+		r0 = rf(ctx, userID, keepSessionID, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, keepSessionID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID, now
+func (_m *SessionGateway) RevokeSession(ctx context.Context, userID int64, sessionID int64, now time.Time) error {
+	ret := _m.Called(ctx, userID, sessionID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, time.Time) error); ok {
+		r0 = rf(ctx, userID, sessionID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchSession provides a mock function with given fields: ctx, sessionID, now
+func (_m *SessionGateway) TouchSession(ctx context.Context, sessionID int64, now time.Time) error {
+	ret := _m.Called(ctx, sessionID, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, sessionID, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionGateway creates a new instance of SessionGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionGateway(t mockConstructorTestingTNewSessionGateway) *SessionGateway {
+	mock := &SessionGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
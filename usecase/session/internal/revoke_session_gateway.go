@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	revokeSessionQuery       = "UPDATE user_session SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?"
+	revokeOtherSessionsQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?"
+)
+
+type RevokeSessionGateway struct {
+	sql *sql.DB
+}
+
+func NewRevokeSessionGateway(sql *sql.DB) *RevokeSessionGateway {
+	return &RevokeSessionGateway{sql: sql}
+}
+
+// RevokeSession returns ErrSessionNotFound when the session does not belong to
+// the user or is not active anymore.
+func (g *RevokeSessionGateway) RevokeSession(ctx context.Context, userID, sessionID int64, now time.Time) error {
+	result, err := g.sql.ExecContext(ctx, revokeSessionQuery, now, sessionID, userID, now)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions revokes every active session of the user except the
+// given one and returns how many were revoked.
+func (g *RevokeSessionGateway) RevokeOtherSessions(ctx context.Context, userID, keepSessionID int64, now time.Time) (int64, error) {
+	result, err := g.sql.ExecContext(ctx, revokeOtherSessionsQuery, now, userID, keepSessionID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
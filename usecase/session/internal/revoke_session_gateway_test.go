@@ -0,0 +1,103 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type RevokeSessionGatewaySuite struct {
+	suite.Suite
+
+	db                        *sql.DB
+	mockDb                    sqlmock.Sqlmock
+	expectedRevokeQuery       string
+	expectedRevokeOthersQuery string
+	errMock                   error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.RevokeSessionGateway
+}
+
+func TestRevokeSessionGatewaySuite(t *testing.T) {
+	suite.Run(t, &RevokeSessionGatewaySuite{})
+}
+
+func (s *RevokeSessionGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedRevokeQuery = "UPDATE user_session SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?"
+	s.expectedRevokeOthersQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewRevokeSessionGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *RevokeSessionGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *RevokeSessionGatewaySuite) TestRevokeSession_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnError(s.errMock)
+
+	err := s.gateway.RevokeSession(s.context, 7, 3, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *RevokeSessionGatewaySuite) TestRevokeSession_NoRowsAffected_ReturnSessionNotFoundErr() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.now, int64(3), int64(7), s.now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := s.gateway.RevokeSession(s.context, 7, 3, s.now)
+
+	s.Assert().ErrorIs(err, internal.ErrSessionNotFound)
+}
+
+func (s *RevokeSessionGatewaySuite) TestRevokeSession_Success_ReturnNil() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.now, int64(3), int64(7), s.now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.gateway.RevokeSession(s.context, 7, 3, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *RevokeSessionGatewaySuite) TestRevokeOtherSessions_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeOthersQuery)).WillReturnError(s.errMock)
+
+	_, err := s.gateway.RevokeOtherSessions(s.context, 7, 3, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *RevokeSessionGatewaySuite) TestRevokeOtherSessions_Success_ReturnRevokedCount() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeOthersQuery)).
+		WithArgs(s.now, int64(7), int64(3), s.now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	revoked, err := s.gateway.RevokeOtherSessions(s.context, 7, 3, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(int64(2), revoked)
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	sessionDataSection     = "sessions"
+	getSessionsByUserQuery = "SELECT ip_address, user_agent, created_at, last_used_at, revoked_at FROM user_session WHERE user_id = ? ORDER BY id"
+)
+
+type SessionExport struct {
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// SessionDataExporter contributes the login history of a user to the personal
+// data export.
+type SessionDataExporter struct {
+	sql *sql.DB
+}
+
+func NewSessionDataExporter(sql *sql.DB) *SessionDataExporter {
+	return &SessionDataExporter{sql: sql}
+}
+
+func (e *SessionDataExporter) PersonalDataSection() string {
+	return sessionDataSection
+}
+
+func (e *SessionDataExporter) ExportPersonalData(ctx context.Context, user entity.User) (interface{}, error) {
+	rows, err := e.sql.QueryContext(ctx, getSessionsByUserQuery, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []SessionExport{}
+	for rows.Next() {
+		export := SessionExport{}
+		var ipAddress, userAgent sql.NullString
+		var revokedAt sql.NullTime
+		if err := rows.Scan(&ipAddress, &userAgent, &export.CreatedAt, &export.LastUsedAt, &revokedAt); err != nil {
+			return nil, err
+		}
+
+		export.IPAddress = ipAddress.String
+		export.UserAgent = userAgent.String
+		if revokedAt.Valid {
+			export.RevokedAt = &revokedAt.Time
+		}
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,84 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type SessionDataExporterSuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context  context.Context
+	user     entity.User
+	now      time.Time
+	exporter *internal.SessionDataExporter
+}
+
+func TestSessionDataExporterSuite(t *testing.T) {
+	suite.Run(t, &SessionDataExporterSuite{})
+}
+
+func (s *SessionDataExporterSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT ip_address, user_agent, created_at, last_used_at, revoked_at FROM user_session WHERE user_id = ? ORDER BY id"
+	s.errMock = errors.New("mocked error")
+
+	s.exporter = internal.NewSessionDataExporter(s.db)
+	s.context = context.Background()
+	s.user = entity.User{ID: 7}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *SessionDataExporterSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *SessionDataExporterSuite) TestPersonalDataSection_ReturnSessions() {
+	s.Assert().Equal("sessions", s.exporter.PersonalDataSection())
+}
+
+func (s *SessionDataExporterSuite) TestExportPersonalData_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnError(s.errMock)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(data)
+}
+
+func (s *SessionDataExporterSuite) TestExportPersonalData_Success_ReturnSessions() {
+	rows := sqlmock.NewRows([]string{"ip_address", "user_agent", "created_at", "last_used_at", "revoked_at"}).
+		AddRow("192.0.2.1", "curl/7.85.0", s.now, s.now, s.now).
+		AddRow(nil, nil, s.now, s.now, nil)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WithArgs(s.user.ID).WillReturnRows(rows)
+
+	data, err := s.exporter.ExportPersonalData(s.context, s.user)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.SessionExport{
+		{IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0", CreatedAt: s.now, LastUsedAt: s.now, RevokedAt: &s.now},
+		{CreatedAt: s.now, LastUsedAt: s.now},
+	}, data)
+}
@@ -0,0 +1,7 @@
+package internal
+
+import "errors"
+
+var (
+	ErrSessionNotFound = errors.New("session with given id is not found")
+)
@@ -0,0 +1,23 @@
+package internal
+
+const (
+	AuditEventRevokeSession       = "auth.session.revoke"
+	AuditEventRevokeOtherSessions = "auth.session.revoke_others"
+)
+
+// Actor is the signed-in user and the session their access token belongs to.
+type Actor struct {
+	UserID    int64
+	SessionID int64
+	IPAddress string
+	UserAgent string
+}
+
+type RevokeSessionUsecaseInput struct {
+	Actor     Actor
+	SessionID int64
+}
+
+type RevokeOtherSessionsUsecaseOutput struct {
+	RevokedSessions int64
+}
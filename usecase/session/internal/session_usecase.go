@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/logging"
+)
+
+type SessionUsecase struct {
+	config  SessionUsecaseConfig
+	gateway SessionGateway
+}
+
+type SessionUsecaseConfig struct {
+	// TouchInterval throttles how often last_used_at is written, so an active
+	// client does not cause a write on every request.
+	TouchInterval time.Duration
+}
+
+//go:generate mockery --name=SessionGateway --output=./mocks
+type SessionGateway interface {
+	ListActiveSessions(ctx context.Context, userID int64, now time.Time) ([]entity.Session, error)
+	GetSession(ctx context.Context, sessionID int64) (entity.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID int64, now time.Time) error
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID int64, now time.Time) (int64, error)
+	TouchSession(ctx context.Context, sessionID int64, now time.Time) error
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
+	NowInUTC() time.Time
+}
+
+func NewSessionUsecase(config SessionUsecaseConfig, gateway SessionGateway) *SessionUsecase {
+	return &SessionUsecase{config: config, gateway: gateway}
+}
+
+func (u *SessionUsecase) ListSessions(ctx context.Context, userID int64) ([]entity.Session, error) {
+	return u.gateway.ListActiveSessions(ctx, userID, u.gateway.NowInUTC())
+}
+
+// IsSessionActive is called for every authenticated request. Access tokens
+// issued before sessions existed carry no session id and are rejected.
+func (u *SessionUsecase) IsSessionActive(ctx context.Context, userID, sessionID int64) (bool, error) {
+	if userID == 0 || sessionID == 0 {
+		return false, nil
+	}
+
+	session, err := u.gateway.GetSession(ctx, sessionID)
+	if err == ErrSessionNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	now := u.gateway.NowInUTC()
+	if session.UserID != userID || !session.IsActive(now) {
+		return false, nil
+	}
+
+	if now.Sub(session.LastUsedAt) >= u.config.TouchInterval {
+		if err := u.gateway.TouchSession(ctx, sessionID, now); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// RevokeSession revokes one session of the signed-in user, which may be the
+// current one to log out.
+func (u *SessionUsecase) RevokeSession(ctx context.Context, in RevokeSessionUsecaseInput) error {
+	now := u.gateway.NowInUTC()
+	err := u.gateway.RevokeSession(ctx, in.Actor.UserID, in.SessionID, now)
+
+	u.recordAuditEvent(ctx, in.Actor, AuditEventRevokeSession, fmt.Sprintf("session_id=%d", in.SessionID), now, err)
+
+	return err
+}
+
+// RevokeOtherSessions revokes every session of the signed-in user except the
+// one the request is made with.
+func (u *SessionUsecase) RevokeOtherSessions(ctx context.Context, actor Actor) (RevokeOtherSessionsUsecaseOutput, error) {
+	now := u.gateway.NowInUTC()
+	revoked, err := u.gateway.RevokeOtherSessions(ctx, actor.UserID, actor.SessionID, now)
+
+	u.recordAuditEvent(ctx, actor, AuditEventRevokeOtherSessions, fmt.Sprintf("revoked=%d", revoked), now, err)
+	if err != nil {
+		return RevokeOtherSessionsUsecaseOutput{}, err
+	}
+
+	return RevokeOtherSessionsUsecaseOutput{RevokedSessions: revoked}, nil
+}
+
+// recordAuditEvent records the outcome of a revocation. The revocation is
+// committed by then, so a failing audit write is logged and not returned.
+func (u *SessionUsecase) recordAuditEvent(ctx context.Context, actor Actor, eventType, reason string, now time.Time, err error) {
+	event := entity.AuditEvent{
+		Type:        eventType,
+		ActorUserID: &actor.UserID,
+		UserID:      &actor.UserID,
+		IPAddress:   actor.IPAddress,
+		UserAgent:   actor.UserAgent,
+		Outcome:     entity.AuditOutcomeSuccess,
+		Reason:      reason,
+		CreatedAt:   now,
+	}
+	if err != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason += " " + err.Error()
+	}
+
+	if auditErr := u.gateway.RecordAuditEvent(ctx, event); auditErr != nil {
+		logging.FromContext(ctx).Error("recording audit event failed", "event", event.Type, "error", auditErr)
+	}
+}
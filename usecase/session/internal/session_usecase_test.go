@@ -0,0 +1,223 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/session/internal"
+	"littlerollingsushi.com/example/usecase/session/internal/mocks"
+)
+
+type SessionUsecaseSuite struct {
+	suite.Suite
+
+	gateway   *mocks.SessionGateway
+	usecase   *internal.SessionUsecase
+	auditCall *mock.Call
+
+	context context.Context
+	actor   internal.Actor
+	session entity.Session
+	now     time.Time
+	errMock error
+}
+
+func TestSessionUsecaseSuite(t *testing.T) {
+	suite.Run(t, &SessionUsecaseSuite{})
+}
+
+func (s *SessionUsecaseSuite) SetupTest() {
+	s.gateway = mocks.NewSessionGateway(s.T())
+	s.usecase = internal.NewSessionUsecase(internal.SessionUsecaseConfig{TouchInterval: time.Minute}, s.gateway)
+
+	s.context = context.Background()
+	s.actor = internal.Actor{UserID: 7, SessionID: 3, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.session = entity.Session{ID: 3, UserID: 7, LastUsedAt: s.now.Add(-time.Second), ExpiresAt: s.now.Add(time.Hour)}
+	s.errMock = errors.New("mock error")
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+	s.auditCall = s.gateway.On("RecordAuditEvent", s.context, mock.Anything).Return(nil).Maybe()
+}
+
+func (s *SessionUsecaseSuite) expectedAuditEvent(eventType, outcome, reason string) entity.AuditEvent {
+	return entity.AuditEvent{
+		Type:        eventType,
+		ActorUserID: &s.actor.UserID,
+		UserID:      &s.actor.UserID,
+		IPAddress:   s.actor.IPAddress,
+		UserAgent:   s.actor.UserAgent,
+		Outcome:     outcome,
+		Reason:      reason,
+		CreatedAt:   s.now,
+	}
+}
+
+func (s *SessionUsecaseSuite) TestListSessions_ReturnActiveSessions() {
+	s.gateway.On("ListActiveSessions", s.context, int64(7), s.now).Return([]entity.Session{s.session}, nil)
+
+	sessions, err := s.usecase.ListSessions(s.context, 7)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]entity.Session{s.session}, sessions)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_MissingSessionID_ReturnFalse() {
+	active, err := s.usecase.IsSessionActive(s.context, 7, 0)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_SessionNotFound_ReturnFalse() {
+	s.gateway.On("GetSession", s.context, int64(3)).Return(entity.Session{}, internal.ErrSessionNotFound)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_GetSessionError_ReturnError() {
+	s.gateway.On("GetSession", s.context, int64(3)).Return(entity.Session{}, s.errMock)
+
+	_, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_OtherUser_ReturnFalse() {
+	s.session.UserID = 8
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_RevokedSession_ReturnFalse() {
+	s.session.RevokedAt = &s.now
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_ExpiredSession_ReturnFalse() {
+	s.session.ExpiresAt = s.now
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.False(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_RecentlyUsed_ReturnTrueWithoutTouch() {
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(active)
+	s.gateway.AssertNotCalled(s.T(), "TouchSession", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_TouchIntervalElapsed_TouchSession() {
+	s.session.LastUsedAt = s.now.Add(-time.Minute)
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+	s.gateway.On("TouchSession", s.context, int64(3), s.now).Return(nil)
+
+	active, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.True(active)
+}
+
+func (s *SessionUsecaseSuite) TestIsSessionActive_TouchError_ReturnError() {
+	s.session.LastUsedAt = s.now.Add(-time.Minute)
+	s.gateway.On("GetSession", s.context, int64(3)).Return(s.session, nil)
+	s.gateway.On("TouchSession", s.context, int64(3), s.now).Return(s.errMock)
+
+	_, err := s.usecase.IsSessionActive(s.context, 7, 3)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *SessionUsecaseSuite) TestRevokeSession_NotFound_ReturnErrorAndRecordFailure() {
+	s.gateway.On("RevokeSession", s.context, int64(7), int64(4), s.now).Return(internal.ErrSessionNotFound)
+
+	err := s.usecase.RevokeSession(s.context, internal.RevokeSessionUsecaseInput{Actor: s.actor, SessionID: 4})
+
+	s.Assert().ErrorIs(err, internal.ErrSessionNotFound)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.context, s.expectedAuditEvent(
+		internal.AuditEventRevokeSession, entity.AuditOutcomeFailure, "session_id=4 "+internal.ErrSessionNotFound.Error()))
+}
+
+func (s *SessionUsecaseSuite) TestRevokeSession_Success_RecordSuccess() {
+	s.gateway.On("RevokeSession", s.context, int64(7), int64(4), s.now).Return(nil)
+
+	err := s.usecase.RevokeSession(s.context, internal.RevokeSessionUsecaseInput{Actor: s.actor, SessionID: 4})
+
+	s.Assert().Nil(err)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.context, s.expectedAuditEvent(
+		internal.AuditEventRevokeSession, entity.AuditOutcomeSuccess, "session_id=4"))
+}
+
+func (s *SessionUsecaseSuite) TestRevokeSession_AuditError_ReturnNil() {
+	s.gateway.On("RevokeSession", s.context, int64(7), int64(4), s.now).Return(nil)
+	s.auditCall.Return(s.errMock)
+
+	err := s.usecase.RevokeSession(s.context, internal.RevokeSessionUsecaseInput{Actor: s.actor, SessionID: 4})
+
+	s.Assert().Nil(err)
+}
+
+func (s *SessionUsecaseSuite) TestRevokeOtherSessions_GatewayError_ReturnError() {
+	s.gateway.On("RevokeOtherSessions", s.context, int64(7), int64(3), s.now).Return(int64(0), s.errMock)
+
+	_, err := s.usecase.RevokeOtherSessions(s.context, s.actor)
+
+	s.Assert().ErrorIs(err, s.errMock)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.context, s.expectedAuditEvent(
+		internal.AuditEventRevokeOtherSessions, entity.AuditOutcomeFailure, "revoked=0 "+s.errMock.Error()))
+}
+
+func (s *SessionUsecaseSuite) TestRevokeOtherSessions_Success_ReturnRevokedCount() {
+	s.gateway.On("RevokeOtherSessions", s.context, int64(7), int64(3), s.now).Return(int64(2), nil)
+
+	out, err := s.usecase.RevokeOtherSessions(s.context, s.actor)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(internal.RevokeOtherSessionsUsecaseOutput{RevokedSessions: 2}, out)
+	s.gateway.AssertCalled(s.T(), "RecordAuditEvent", s.context, s.expectedAuditEvent(
+		internal.AuditEventRevokeOtherSessions, entity.AuditOutcomeSuccess, "revoked=2"))
+}
+
+func (s *SessionUsecaseSuite) TestRevokeOtherSessions_AuditError_ReturnRevokedCount() {
+	s.gateway.On("RevokeOtherSessions", s.context, int64(7), int64(3), s.now).Return(int64(2), nil)
+	s.auditCall.Return(s.errMock)
+
+	out, err := s.usecase.RevokeOtherSessions(s.context, s.actor)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(internal.RevokeOtherSessionsUsecaseOutput{RevokedSessions: 2}, out)
+}
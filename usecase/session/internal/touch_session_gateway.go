@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	touchSessionQuery = "UPDATE user_session SET last_used_at = ? WHERE id = ?"
+)
+
+type TouchSessionGateway struct {
+	sql *sql.DB
+}
+
+func NewTouchSessionGateway(sql *sql.DB) *TouchSessionGateway {
+	return &TouchSessionGateway{sql: sql}
+}
+
+func (g *TouchSessionGateway) TouchSession(ctx context.Context, sessionID int64, now time.Time) error {
+	_, err := g.sql.ExecContext(ctx, touchSessionQuery, now, sessionID)
+	return err
+}
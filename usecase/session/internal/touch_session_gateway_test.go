@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/session/internal"
+)
+
+type TouchSessionGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	errMock       error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.TouchSessionGateway
+}
+
+func TestTouchSessionGatewaySuite(t *testing.T) {
+	suite.Run(t, &TouchSessionGatewaySuite{})
+}
+
+func (s *TouchSessionGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "UPDATE user_session SET last_used_at = ? WHERE id = ?"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewTouchSessionGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *TouchSessionGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *TouchSessionGatewaySuite) TestTouchSession_UnknownError_ReturnOriginalError() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	err := s.gateway.TouchSession(s.context, 3, s.now)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *TouchSessionGatewaySuite) TestTouchSession_Success_ReturnNil() {
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(s.now, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.gateway.TouchSession(s.context, 3, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
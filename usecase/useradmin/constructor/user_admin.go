@@ -0,0 +1,43 @@
+package constructor
+
+import (
+	"database/sql"
+
+	"littlerollingsushi.com/example/config"
+	auditConstructor "littlerollingsushi.com/example/usecase/audit/constructor"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/useradmin/handler"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+func ConstructUserAdminHandler(cfg *config.Config, db *sql.DB) *handler.UserAdminHandler {
+	usecase := internal.NewUserAdminUsecase(
+		internal.UserAdminUsecaseConfig{
+			PasswordResetURL:        cfg.PasswordReset.URL,
+			PasswordResetExpiration: cfg.PasswordReset.Expiration,
+		},
+		struct {
+			*internal.ListUsersGateway
+			*internal.GetUserByIDGateway
+			*internal.SetUserStatusGateway
+			*internal.ForcePasswordResetGateway
+			*internal.AssignRolesGateway
+			helper.AuditRecorder
+			*helper.TokenGenerator
+			*helper.SMTPMailer
+			helper.Timer
+		}{
+			ListUsersGateway:          internal.NewListUsersGateway(db),
+			GetUserByIDGateway:        internal.NewGetUserByIDGateway(db),
+			SetUserStatusGateway:      internal.NewSetUserStatusGateway(db),
+			ForcePasswordResetGateway: internal.NewForcePasswordResetGateway(db),
+			AssignRolesGateway:        internal.NewAssignRolesGateway(db),
+			AuditRecorder:             auditConstructor.ConstructAuditRecorder(db),
+			TokenGenerator:            &helper.TokenGenerator{},
+			SMTPMailer:                helper.NewSMTPMailer(cfg.SMTP),
+			Timer:                     &helper.TimerImplementation{},
+		},
+	)
+	timer := &helper.TimerImplementation{}
+	return handler.NewUserAdminHandler(usecase, timer)
+}
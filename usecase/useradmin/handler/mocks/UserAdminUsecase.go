@@ -0,0 +1,152 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	internal "littlerollingsushi.com/example/usecase/useradmin/internal"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserAdminUsecase is an autogenerated mock type for the UserAdminUsecase type
+type UserAdminUsecase struct {
+	mock.Mock
+}
+
+// AssignRoles provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) AssignRoles(_a0 context.Context, _a1 internal.AssignRolesUsecaseInput) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, internal.AssignRolesUsecaseInput) []string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.AssignRolesUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableUser provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) DisableUser(_a0 context.Context, _a1 internal.DisableUserUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.DisableUserUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableUser provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) EnableUser(_a0 context.Context, _a1 internal.UserUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.UserUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForcePasswordReset provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) ForcePasswordReset(_a0 context.Context, _a1 internal.UserUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.UserUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUser provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) GetUser(_a0 context.Context, _a1 internal.UserUsecaseInput) (internal.UserSummary, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.UserSummary
+	if rf, ok := ret.Get(0).(func(context.Context, internal.UserUsecaseInput) internal.UserSummary); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.UserSummary)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.UserUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) ListUsers(_a0 context.Context, _a1 internal.ListUsersUsecaseInput) (internal.ListUsersUsecaseOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 internal.ListUsersUsecaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, internal.ListUsersUsecaseInput) internal.ListUsersUsecaseOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(internal.ListUsersUsecaseOutput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.ListUsersUsecaseInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendUser provides a mock function with given fields: _a0, _a1
+func (_m *UserAdminUsecase) SuspendUser(_a0 context.Context, _a1 internal.SuspendUserUsecaseInput) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.SuspendUserUsecaseInput) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserAdminUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserAdminUsecase creates a new instance of UserAdminUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserAdminUsecase(t mockConstructorTestingTNewUserAdminUsecase) *UserAdminUsecase {
+	mock := &UserAdminUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+const timeFormat = "2006-01-02T15:04:05.999Z"
+
+type UserAdminHandler struct {
+	usecase UserAdminUsecase
+	timer   helper.Timer
+}
+
+//go:generate mockery --name=UserAdminUsecase --output=./mocks
+type UserAdminUsecase interface {
+	ListUsers(context.Context, internal.ListUsersUsecaseInput) (internal.ListUsersUsecaseOutput, error)
+	GetUser(context.Context, internal.UserUsecaseInput) (internal.UserSummary, error)
+	DisableUser(context.Context, internal.DisableUserUsecaseInput) error
+	SuspendUser(context.Context, internal.SuspendUserUsecaseInput) error
+	EnableUser(context.Context, internal.UserUsecaseInput) error
+	ForcePasswordReset(context.Context, internal.UserUsecaseInput) error
+	AssignRoles(context.Context, internal.AssignRolesUsecaseInput) ([]string, error)
+}
+
+func NewUserAdminHandler(usecase UserAdminUsecase, timer helper.Timer) *UserAdminHandler {
+	return &UserAdminHandler{usecase: usecase, timer: timer}
+}
+
+// All handlers must be registered behind the authentication and permission
+// middlewares, the acting admin is taken from the access token.
+
+func (h *UserAdminHandler) ListUsers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if r.FormValue("limit") != "" && (err != nil || limit <= 0) {
+		problem.Write(w, h.timer, problem.InvalidParameter, "Limit must be a positive number.")
+		return
+	}
+
+	in := internal.ListUsersUsecaseInput{
+		Actor:  actor,
+		Query:  r.FormValue("q"),
+		Cursor: r.FormValue("cursor"),
+		Limit:  limit,
+	}
+
+	out, err := h.usecase.ListUsers(r.Context(), in)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	users := []map[string]interface{}{}
+	for _, user := range out.Users {
+		users = append(users, h.buildUser(user))
+	}
+
+	var nextCursor interface{}
+	if out.NextCursor != "" {
+		nextCursor = out.NextCursor
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{
+		"users":       users,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (h *UserAdminHandler) GetUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	user, err := h.usecase.GetUser(r.Context(), in)
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{"user": h.buildUser(user)})
+}
+
+func (h *UserAdminHandler) DisableUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	err := h.usecase.DisableUser(r.Context(), internal.DisableUserUsecaseInput{
+		Actor:  in.Actor,
+		UserID: in.UserID,
+		Reason: r.FormValue("reason"),
+	})
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "User disabled.")
+}
+
+// SuspendUser takes an optional RFC 3339 "until" timestamp. Without it the
+// suspension lasts until the user is enabled again.
+func (h *UserAdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	var until *time.Time
+	if value := r.FormValue("until"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problem.Write(w, h.timer, problem.InvalidParameter, "Until must be an RFC 3339 timestamp.")
+			return
+		}
+
+		parsed = parsed.UTC()
+		until = &parsed
+	}
+
+	err := h.usecase.SuspendUser(r.Context(), internal.SuspendUserUsecaseInput{
+		Actor:  in.Actor,
+		UserID: in.UserID,
+		Reason: r.FormValue("reason"),
+		Until:  until,
+	})
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "User suspended.")
+}
+
+func (h *UserAdminHandler) EnableUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.EnableUser(r.Context(), in); err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusOK, "User enabled.")
+}
+
+func (h *UserAdminHandler) ForcePasswordReset(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ForcePasswordReset(r.Context(), in); err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeMessageResponse(w, http.StatusAccepted, "Password reset. A reset link was sent to the user.")
+}
+
+func (h *UserAdminHandler) AssignRoles(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in, ok := h.userInput(w, r, params)
+	if !ok {
+		return
+	}
+
+	r.ParseForm()
+	roles, err := h.usecase.AssignRoles(r.Context(), internal.AssignRolesUsecaseInput{
+		Actor:  in.Actor,
+		UserID: in.UserID,
+		Roles:  r.PostForm["roles"],
+	})
+	if err != nil {
+		h.processError(w, r, err)
+		return
+	}
+
+	h.writeDataResponse(w, http.StatusOK, map[string]interface{}{"roles": roles})
+}
+
+func (h *UserAdminHandler) actor(w http.ResponseWriter, r *http.Request) (internal.Actor, bool) {
+	claims, ok := helper.AccessTokenClaimsFromContext(r.Context())
+	if !ok || claims.UserID == 0 {
+		problem.Write(w, h.timer, problem.Unauthorized, "Missing or invalid access token.")
+		return internal.Actor{}, false
+	}
+
+	return internal.Actor{
+		UserID:    claims.UserID,
+		IPAddress: helper.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}, true
+}
+
+func (h *UserAdminHandler) userInput(w http.ResponseWriter, r *http.Request, params map[string]string) (internal.UserUsecaseInput, bool) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return internal.UserUsecaseInput{}, false
+	}
+
+	userID, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil || userID <= 0 {
+		problem.Write(w, h.timer, problem.UserNotFound, "User not found.")
+		return internal.UserUsecaseInput{}, false
+	}
+
+	return internal.UserUsecaseInput{Actor: actor, UserID: userID}, true
+}
+
+func (h *UserAdminHandler) processError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case internal.ErrUserNotFound:
+		problem.Write(w, h.timer, problem.UserNotFound, "User not found.")
+	case internal.ErrInvalidCursor:
+		problem.Write(w, h.timer, problem.InvalidCursor, "Cursor is invalid.")
+	case internal.ErrRoleNotFound:
+		problem.Write(w, h.timer, problem.RoleNotFound, "One of the given roles does not exist.")
+	case internal.ErrInvalidSuspension:
+		problem.Write(w, h.timer, problem.InvalidSuspension, "Suspension must end in the future.")
+	case internal.ErrSelfModification:
+		problem.Write(w, h.timer, problem.SelfModification, "You cannot perform this action on your own account.")
+	default:
+		problem.WriteInternalError(w, r, h.timer, err)
+	}
+}
+
+func (h *UserAdminHandler) buildUser(user internal.UserSummary) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":                user.ID,
+		"first_name":        user.FirstName,
+		"last_name":         user.LastName,
+		"email":             user.Email,
+		"created_at":        user.CreatedAt.Format(timeFormat),
+		"status":            user.Status,
+		"status_reason":     user.StatusReason,
+		"status_changed_at": formatOptionalTime(user.StatusChangedAt),
+		"suspended_until":   formatOptionalTime(user.SuspendedUntil),
+		"deleted_at":        formatOptionalTime(user.DeletedAt),
+	}
+	if user.Roles != nil {
+		data["roles"] = user.Roles
+	}
+
+	return data
+}
+
+func formatOptionalTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return t.Format(timeFormat)
+}
+
+func (h *UserAdminHandler) writeMessageResponse(w http.ResponseWriter, status int, message string) {
+	h.writeDataResponse(w, status, map[string]interface{}{"message": message})
+}
+
+func (h *UserAdminHandler) writeDataResponse(w http.ResponseWriter, status int, data map[string]interface{}) {
+	data["meta"] = map[string]interface{}{
+		"http_status": status,
+		"server_time": h.timer.NowInUTC().Format(timeFormat),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
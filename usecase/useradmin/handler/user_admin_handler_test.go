@@ -0,0 +1,378 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/problem"
+	"littlerollingsushi.com/example/usecase/helper"
+	helperMocks "littlerollingsushi.com/example/usecase/helper/mocks"
+	"littlerollingsushi.com/example/usecase/useradmin/handler"
+	"littlerollingsushi.com/example/usecase/useradmin/handler/mocks"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type UserAdminHandlerSuite struct {
+	suite.Suite
+
+	responseWriter *httptest.ResponseRecorder
+	requestParams  map[string]string
+
+	usecase *mocks.UserAdminUsecase
+	timer   *helperMocks.Timer
+	handler *handler.UserAdminHandler
+
+	expectedActor     internal.Actor
+	expectedTimestamp time.Time
+	errMock           error
+}
+
+func TestUserAdminHandlerSuite(t *testing.T) {
+	suite.Run(t, &UserAdminHandlerSuite{})
+}
+
+func (s *UserAdminHandlerSuite) SetupTest() {
+	s.responseWriter = httptest.NewRecorder()
+	s.requestParams = map[string]string{"id": "7"}
+
+	s.usecase = mocks.NewUserAdminUsecase(s.T())
+	s.timer = helperMocks.NewTimer(s.T())
+	s.handler = handler.NewUserAdminHandler(s.usecase, s.timer)
+
+	s.expectedActor = internal.Actor{UserID: 1, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.expectedTimestamp = time.Date(2022, 10, 29, 23, 59, 59, 123000000, time.UTC)
+	s.errMock = errors.New("mock error")
+}
+
+func (s *UserAdminHandlerSuite) expectedProblemBody(code problem.Code, detail string) string {
+	return `
+		{
+			"type": "` + code.TypeURI() + `",
+			"title": "` + code.Title + `",
+			"status": ` + strconv.Itoa(code.Status) + `,
+			"code": "` + code.Code + `",
+			"detail": "` + detail + `",
+			"meta": {
+				"http_status": ` + strconv.Itoa(code.Status) + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *UserAdminHandlerSuite) newRequest(method, target string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(method, target, body)
+	r.RemoteAddr = "192.0.2.1:54321"
+	r.Header.Set("User-Agent", "curl/7.85.0")
+	if body != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return r.WithContext(helper.ContextWithAccessTokenClaims(r.Context(), &helper.AccessTokenClaims{UserID: 1}))
+}
+
+func (s *UserAdminHandlerSuite) expectedResponseBody(status, message string) string {
+	return `
+		{
+			"message": "` + message + `",
+			"meta": {
+				"http_status": ` + status + `,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`
+}
+
+func (s *UserAdminHandlerSuite) TestListUsers_NoClaims_ReturnUnauthorized() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListUsers(s.responseWriter, httptest.NewRequest("GET", "http://test.com/v1/admin/users", nil), s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusUnauthorized, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.Unauthorized, "Missing or invalid access token."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestListUsers_InvalidLimit_ReturnBadRequest() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListUsers(s.responseWriter, s.newRequest("GET", "http://test.com/v1/admin/users?limit=abc", nil), s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidParameter, "Limit must be a positive number."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestListUsers_InvalidCursor_ReturnBadRequest() {
+	r := s.newRequest("GET", "http://test.com/v1/admin/users?cursor=bad", nil)
+	in := internal.ListUsersUsecaseInput{Actor: s.expectedActor, Cursor: "bad"}
+	s.usecase.On("ListUsers", r.Context(), in).Return(internal.ListUsersUsecaseOutput{}, internal.ErrInvalidCursor)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListUsers(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidCursor, "Cursor is invalid."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestListUsers_Success_ReturnUsersAndNextCursor() {
+	r := s.newRequest("GET", "http://test.com/v1/admin/users?q=doe&limit=1", nil)
+	in := internal.ListUsersUsecaseInput{Actor: s.expectedActor, Query: "doe", Limit: 1}
+	s.usecase.On("ListUsers", r.Context(), in).Return(internal.ListUsersUsecaseOutput{
+		Users: []internal.UserSummary{{
+			ID:              7,
+			FirstName:       "John",
+			LastName:        "Doe",
+			Email:           "john.doe@email.com",
+			CreatedAt:       s.expectedTimestamp,
+			Status:          entity.UserStatusDisabled,
+			StatusReason:    "spam",
+			StatusChangedAt: &s.expectedTimestamp,
+		}},
+		NextCursor: "Nw",
+	}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ListUsers(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(`
+		{
+			"users": [
+				{
+					"id": 7,
+					"first_name": "John",
+					"last_name": "Doe",
+					"email": "john.doe@email.com",
+					"created_at": "2022-10-29T23:59:59.123Z",
+					"status": "disabled",
+					"status_reason": "spam",
+					"status_changed_at": "2022-10-29T23:59:59.123Z",
+					"suspended_until": null,
+					"deleted_at": null
+				}
+			],
+			"next_cursor": "Nw",
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestGetUser_InvalidID_ReturnNotFound() {
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.GetUser(s.responseWriter, s.newRequest("GET", "http://test.com/v1/admin/users/abc", nil), map[string]string{"id": "abc"})
+
+	a := s.Assert()
+	a.Equal(http.StatusNotFound, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.UserNotFound, "User not found."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestGetUser_UsecaseUnknownError_ReturnInternalServerError() {
+	r := s.newRequest("GET", "http://test.com/v1/admin/users/7", nil)
+	s.usecase.On("GetUser", r.Context(), internal.UserUsecaseInput{Actor: s.expectedActor, UserID: 7}).
+		Return(internal.UserSummary{}, s.errMock)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.GetUser(s.responseWriter, r, s.requestParams)
+
+	resp := s.responseWriter.Result()
+	body, _ := io.ReadAll(resp.Body)
+	a := s.Assert()
+	a.Equal(http.StatusInternalServerError, resp.StatusCode)
+	a.JSONEq(s.expectedProblemBody(problem.InternalError, "Oops! Something went wrong."), string(body))
+}
+
+func (s *UserAdminHandlerSuite) TestGetUser_Success_ReturnUserWithRoles() {
+	r := s.newRequest("GET", "http://test.com/v1/admin/users/7", nil)
+	s.usecase.On("GetUser", r.Context(), internal.UserUsecaseInput{Actor: s.expectedActor, UserID: 7}).
+		Return(internal.UserSummary{
+			ID:        7,
+			Email:     "john.doe@email.com",
+			CreatedAt: s.expectedTimestamp,
+			Status:    entity.UserStatusActive,
+			Roles:     []string{"support"},
+		}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.GetUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(`
+		{
+			"user": {
+				"id": 7,
+				"first_name": "",
+				"last_name": "",
+				"email": "john.doe@email.com",
+				"created_at": "2022-10-29T23:59:59.123Z",
+				"status": "active",
+				"status_reason": "",
+				"status_changed_at": null,
+				"suspended_until": null,
+				"deleted_at": null,
+				"roles": ["support"]
+			},
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestDisableUser_SelfModification_ReturnUnprocessableEntity() {
+	form := url.Values{"reason": {"spam"}}
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/1/disable", strings.NewReader(form.Encode()))
+	in := internal.DisableUserUsecaseInput{Actor: s.expectedActor, UserID: 1, Reason: "spam"}
+	s.usecase.On("DisableUser", r.Context(), in).Return(internal.ErrSelfModification)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DisableUser(s.responseWriter, r, map[string]string{"id": "1"})
+
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.SelfModification, "You cannot perform this action on your own account."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestDisableUser_Success_ReturnOK() {
+	form := url.Values{"reason": {"spam"}}
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/disable", strings.NewReader(form.Encode()))
+	in := internal.DisableUserUsecaseInput{Actor: s.expectedActor, UserID: 7, Reason: "spam"}
+	s.usecase.On("DisableUser", r.Context(), in).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.DisableUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(s.expectedResponseBody("200", "User disabled."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestSuspendUser_InvalidUntil_ReturnBadRequest() {
+	form := url.Values{"until": {"tomorrow"}}
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/suspend", strings.NewReader(form.Encode()))
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.SuspendUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusBadRequest, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidParameter, "Until must be an RFC 3339 timestamp."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestSuspendUser_InvalidSuspension_ReturnUnprocessableEntity() {
+	form := url.Values{"until": {"2022-10-28T00:00:00Z"}}
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/suspend", strings.NewReader(form.Encode()))
+	until := time.Date(2022, 10, 28, 0, 0, 0, 0, time.UTC)
+	in := internal.SuspendUserUsecaseInput{Actor: s.expectedActor, UserID: 7, Until: &until}
+	s.usecase.On("SuspendUser", r.Context(), in).Return(internal.ErrInvalidSuspension)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.SuspendUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.InvalidSuspension, "Suspension must end in the future."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestSuspendUser_WithoutUntil_ReturnOK() {
+	form := url.Values{"reason": {"chargeback"}}
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/suspend", strings.NewReader(form.Encode()))
+	in := internal.SuspendUserUsecaseInput{Actor: s.expectedActor, UserID: 7, Reason: "chargeback"}
+	s.usecase.On("SuspendUser", r.Context(), in).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.SuspendUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(s.expectedResponseBody("200", "User suspended."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestEnableUser_UserNotFound_ReturnNotFound() {
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/enable", nil)
+	s.usecase.On("EnableUser", r.Context(), internal.UserUsecaseInput{Actor: s.expectedActor, UserID: 7}).Return(internal.ErrUserNotFound)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.EnableUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusNotFound, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.UserNotFound, "User not found."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestEnableUser_Success_ReturnOK() {
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/enable", nil)
+	s.usecase.On("EnableUser", r.Context(), internal.UserUsecaseInput{Actor: s.expectedActor, UserID: 7}).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.EnableUser(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(s.expectedResponseBody("200", "User enabled."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestForcePasswordReset_Success_ReturnAccepted() {
+	r := s.newRequest("POST", "http://test.com/v1/admin/users/7/password-reset", nil)
+	s.usecase.On("ForcePasswordReset", r.Context(), internal.UserUsecaseInput{Actor: s.expectedActor, UserID: 7}).Return(nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.ForcePasswordReset(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusAccepted, s.responseWriter.Code)
+	a.JSONEq(s.expectedResponseBody("202", "Password reset. A reset link was sent to the user."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestAssignRoles_RoleNotFound_ReturnUnprocessableEntity() {
+	form := url.Values{"roles": {"unknown"}}
+	r := s.newRequest("PUT", "http://test.com/v1/admin/users/7/roles", strings.NewReader(form.Encode()))
+	in := internal.AssignRolesUsecaseInput{Actor: s.expectedActor, UserID: 7, Roles: []string{"unknown"}}
+	s.usecase.On("AssignRoles", r.Context(), in).Return(nil, internal.ErrRoleNotFound)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.AssignRoles(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusUnprocessableEntity, s.responseWriter.Code)
+	a.JSONEq(s.expectedProblemBody(problem.RoleNotFound, "One of the given roles does not exist."), s.responseWriter.Body.String())
+}
+
+func (s *UserAdminHandlerSuite) TestAssignRoles_Success_ReturnAssignedRoles() {
+	form := url.Values{"roles": {"support", "admin"}}
+	r := s.newRequest("PUT", "http://test.com/v1/admin/users/7/roles", strings.NewReader(form.Encode()))
+	in := internal.AssignRolesUsecaseInput{Actor: s.expectedActor, UserID: 7, Roles: []string{"support", "admin"}}
+	s.usecase.On("AssignRoles", r.Context(), in).Return([]string{"admin", "support"}, nil)
+	s.timer.On("NowInUTC").Return(s.expectedTimestamp)
+
+	s.handler.AssignRoles(s.responseWriter, r, s.requestParams)
+
+	a := s.Assert()
+	a.Equal(http.StatusOK, s.responseWriter.Code)
+	a.JSONEq(`
+		{
+			"roles": ["admin", "support"],
+			"meta": {
+				"http_status": 200,
+				"server_time": "2022-10-29T23:59:59.123Z"
+			}
+		}
+	`, s.responseWriter.Body.String())
+}
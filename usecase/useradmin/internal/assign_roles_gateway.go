@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const (
+	deleteUserRolesQuery = "DELETE FROM user_role WHERE user_id = ?"
+	insertUserRolesQuery = "INSERT INTO user_role (user_id, role_id, created_at) SELECT ?, id, ? FROM role WHERE name IN (%s)"
+)
+
+type AssignRolesGateway struct {
+	sql *sql.DB
+}
+
+func NewAssignRolesGateway(sql *sql.DB) *AssignRolesGateway {
+	return &AssignRolesGateway{sql: sql}
+}
+
+// AssignRoles replaces the roles of the user with the given, de-duplicated,
+// role names. Nothing is changed when one of the roles does not exist.
+func (g *AssignRolesGateway) AssignRoles(ctx context.Context, userID int64, roles []string, now time.Time) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteUserRolesQuery, userID); err != nil {
+		return err
+	}
+
+	if len(roles) > 0 {
+		args := []interface{}{userID, now}
+		for _, role := range roles {
+			args = append(args, role)
+		}
+
+		query := strings.Replace(insertUserRolesQuery, "%s", strings.TrimSuffix(strings.Repeat("?, ", len(roles)), ", "), 1)
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected != int64(len(roles)) {
+			return ErrRoleNotFound
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,105 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type AssignRolesGatewaySuite struct {
+	suite.Suite
+
+	db                  *sql.DB
+	mockDb              sqlmock.Sqlmock
+	expectedDeleteQuery string
+	expectedInsertQuery string
+	errMock             error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.AssignRolesGateway
+}
+
+func TestAssignRolesGatewaySuite(t *testing.T) {
+	suite.Run(t, &AssignRolesGatewaySuite{})
+}
+
+func (s *AssignRolesGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedDeleteQuery = "DELETE FROM user_role WHERE user_id = ?"
+	s.expectedInsertQuery = "INSERT INTO user_role (user_id, role_id, created_at) SELECT ?, id, ? FROM role WHERE name IN (?, ?)"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewAssignRolesGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *AssignRolesGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *AssignRolesGatewaySuite) TestAssignRoles_DeleteError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.AssignRoles(s.context, 7, []string{"admin", "support"}, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *AssignRolesGatewaySuite) TestAssignRoles_UnknownRole_ReturnRoleNotFoundErr() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.AssignRoles(s.context, 7, []string{"admin", "unknown"}, s.now)
+
+	a := s.Assert()
+	a.ErrorIs(err, internal.ErrRoleNotFound)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *AssignRolesGatewaySuite) TestAssignRoles_NoRoles_OnlyRemoveRoles() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.AssignRoles(s.context, 7, []string{}, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *AssignRolesGatewaySuite) TestAssignRoles_Success_ReturnNil() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteQuery)).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertQuery)).
+		WithArgs(7, s.now, "admin", "support").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.AssignRoles(s.context, 7, []string{"admin", "support"}, s.now)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	clearPasswordQuery              = "UPDATE user SET crypted_password = '', updated_at = ? WHERE id = ?"
+	deleteUnusedPasswordResetsQuery = "DELETE FROM password_reset WHERE user_id = ? AND used_at IS NULL"
+	insertPasswordResetQuery        = "INSERT INTO password_reset (user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)"
+)
+
+type ForcePasswordResetGateway struct {
+	sql *sql.DB
+}
+
+func NewForcePasswordResetGateway(sql *sql.DB) *ForcePasswordResetGateway {
+	return &ForcePasswordResetGateway{sql: sql}
+}
+
+// ForcePasswordReset clears the password of the user, so it can no longer be
+// used to login, revokes the sessions logged in with it and stores the reset
+// token in place of any unused one in a single transaction, so only the latest
+// reset link works. The usecase checks that the user exists first.
+func (g *ForcePasswordResetGateway) ForcePasswordReset(ctx context.Context, reset PasswordReset) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, clearPasswordQuery, reset.CreatedAt, reset.UserID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, revokeUserSessionsQuery, reset.CreatedAt, reset.UserID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteUnusedPasswordResetsQuery, reset.UserID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, insertPasswordResetQuery, reset.UserID, reset.TokenHash, reset.ExpiresAt, reset.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
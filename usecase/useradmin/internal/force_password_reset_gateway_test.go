@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type ForcePasswordResetGatewaySuite struct {
+	suite.Suite
+
+	db                         *sql.DB
+	mockDb                     sqlmock.Sqlmock
+	expectedClearPasswordQuery string
+	expectedRevokeQuery        string
+	expectedDeleteResetsQuery  string
+	expectedInsertResetQuery   string
+	errMock                    error
+
+	context context.Context
+	reset   internal.PasswordReset
+	gateway *internal.ForcePasswordResetGateway
+}
+
+func TestForcePasswordResetGatewaySuite(t *testing.T) {
+	suite.Run(t, &ForcePasswordResetGatewaySuite{})
+}
+
+func (s *ForcePasswordResetGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedClearPasswordQuery = "UPDATE user SET crypted_password = '', updated_at = ? WHERE id = ?"
+	s.expectedRevokeQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+	s.expectedDeleteResetsQuery = "DELETE FROM password_reset WHERE user_id = ? AND used_at IS NULL"
+	s.expectedInsertResetQuery = "INSERT INTO password_reset (user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)"
+	s.errMock = errors.New("mocked error")
+
+	now := time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.gateway = internal.NewForcePasswordResetGateway(s.db)
+	s.context = context.Background()
+	s.reset = internal.PasswordReset{UserID: 7, TokenHash: "random-token-hash", ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+}
+
+func (s *ForcePasswordResetGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ForcePasswordResetGatewaySuite) TestForcePasswordReset_BeginError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin().WillReturnError(s.errMock)
+
+	err := s.gateway.ForcePasswordReset(s.context, s.reset)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *ForcePasswordResetGatewaySuite) TestForcePasswordReset_PasswordAlreadyCleared_StoreReset() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedClearPasswordQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteResetsQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertResetQuery)).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.ForcePasswordReset(s.context, s.reset)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ForcePasswordResetGatewaySuite) TestForcePasswordReset_DeleteUnusedResetsError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedClearPasswordQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteResetsQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ForcePasswordReset(s.context, s.reset)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ForcePasswordResetGatewaySuite) TestForcePasswordReset_InsertError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedClearPasswordQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteResetsQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertResetQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.ForcePasswordReset(s.context, s.reset)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ForcePasswordResetGatewaySuite) TestForcePasswordReset_Success_RevokeSessionsAndReplaceUnusedResets() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedClearPasswordQuery)).
+		WithArgs(s.reset.CreatedAt, s.reset.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.reset.CreatedAt, s.reset.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedDeleteResetsQuery)).
+		WithArgs(s.reset.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedInsertResetQuery)).
+		WithArgs(s.reset.UserID, s.reset.TokenHash, s.reset.ExpiresAt, s.reset.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.ForcePasswordReset(s.context, s.reset)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
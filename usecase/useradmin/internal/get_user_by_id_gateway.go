@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	getUserByIDQuery          = "SELECT id, first_name, last_name, email, created_at, status, status_reason, status_changed_at, suspended_until, deleted_at FROM user WHERE id = ?"
+	getRoleNamesByUserIDQuery = "SELECT r.name FROM user_role ur JOIN role r ON r.id = ur.role_id WHERE ur.user_id = ? ORDER BY r.name"
+)
+
+type GetUserByIDGateway struct {
+	sql *sql.DB
+}
+
+func NewGetUserByIDGateway(sql *sql.DB) *GetUserByIDGateway {
+	return &GetUserByIDGateway{sql: sql}
+}
+
+func (g *GetUserByIDGateway) GetUserByID(ctx context.Context, userID int64) (UserSummary, error) {
+	user, err := scanUserSummary(g.sql.QueryRowContext(ctx, getUserByIDQuery, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserSummary{}, ErrUserNotFound
+		}
+
+		return UserSummary{}, err
+	}
+
+	return user, nil
+}
+
+func (g *GetUserByIDGateway) GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := g.sql.QueryContext(ctx, getRoleNamesByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
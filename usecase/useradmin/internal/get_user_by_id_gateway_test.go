@@ -0,0 +1,114 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type GetUserByIDGatewaySuite struct {
+	suite.Suite
+
+	db                 *sql.DB
+	mockDb             sqlmock.Sqlmock
+	expectedUserQuery  string
+	expectedRolesQuery string
+	errMock            error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.GetUserByIDGateway
+}
+
+func TestGetUserByIDGatewaySuite(t *testing.T) {
+	suite.Run(t, &GetUserByIDGatewaySuite{})
+}
+
+func (s *GetUserByIDGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedUserQuery = "SELECT id, first_name, last_name, email, created_at, status, status_reason, status_changed_at, suspended_until, deleted_at FROM user WHERE id = ?"
+	s.expectedRolesQuery = "SELECT r.name FROM user_role ur JOIN role r ON r.id = ur.role_id WHERE ur.user_id = ? ORDER BY r.name"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewGetUserByIDGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *GetUserByIDGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_NoRows_ReturnUserNotFoundErr() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedUserQuery)).WithArgs(7).WillReturnError(sql.ErrNoRows)
+
+	_, err := s.gateway.GetUserByID(s.context, 7)
+
+	s.Assert().ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedUserQuery)).WithArgs(7).WillReturnError(s.errMock)
+
+	_, err := s.gateway.GetUserByID(s.context, 7)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetUserByID_Success_ReturnUser() {
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "created_at", "status", "status_reason", "status_changed_at", "suspended_until", "deleted_at"}).
+		AddRow(7, "John", "Doe", "john.doe@email.com", s.now, "suspended", "chargeback", s.now, s.now, s.now)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedUserQuery)).WithArgs(7).WillReturnRows(rows)
+
+	user, err := s.gateway.GetUserByID(s.context, 7)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(internal.UserSummary{
+		ID:              7,
+		FirstName:       "John",
+		LastName:        "Doe",
+		Email:           "john.doe@email.com",
+		CreatedAt:       s.now,
+		Status:          entity.UserStatusSuspended,
+		StatusReason:    "chargeback",
+		StatusChangedAt: &s.now,
+		SuspendedUntil:  &s.now,
+		DeletedAt:       &s.now,
+	}, user)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetRoleNamesByUserID_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedRolesQuery)).WithArgs(7).WillReturnError(s.errMock)
+
+	roles, err := s.gateway.GetRoleNamesByUserID(s.context, 7)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(roles)
+}
+
+func (s *GetUserByIDGatewaySuite) TestGetRoleNamesByUserID_Success_ReturnRoleNames() {
+	rows := sqlmock.NewRows([]string{"name"}).AddRow("admin").AddRow("support")
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedRolesQuery)).WithArgs(7).WillReturnRows(rows)
+
+	roles, err := s.gateway.GetRoleNamesByUserID(s.context, 7)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]string{"admin", "support"}, roles)
+}
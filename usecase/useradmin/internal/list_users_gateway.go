@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+const (
+	listUsersQuery = "SELECT id, first_name, last_name, email, created_at, status, status_reason, status_changed_at, suspended_until, deleted_at FROM user " +
+		"WHERE id > ? AND (? = '' OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?) ORDER BY id LIMIT ?"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type ListUsersGateway struct {
+	sql *sql.DB
+}
+
+func NewListUsersGateway(sql *sql.DB) *ListUsersGateway {
+	return &ListUsersGateway{sql: sql}
+}
+
+// ListUsers returns up to filter.Limit users with an id greater than
+// filter.AfterID whose email or names contain filter.Query.
+func (g *ListUsersGateway) ListUsers(ctx context.Context, filter UserFilter) ([]UserSummary, error) {
+	pattern := "%" + likeEscaper.Replace(filter.Query) + "%"
+	rows, err := g.sql.QueryContext(ctx, listUsersQuery, filter.AfterID, filter.Query, pattern, pattern, pattern, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []UserSummary{}
+	for rows.Next() {
+		user, err := scanUserSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUserSummary(row scanner) (UserSummary, error) {
+	user := UserSummary{}
+	var firstName, lastName, reason sql.NullString
+	err := row.Scan(
+		&user.ID,
+		&firstName,
+		&lastName,
+		&user.Email,
+		&user.CreatedAt,
+		&user.Status,
+		&reason,
+		&user.StatusChangedAt,
+		&user.SuspendedUntil,
+		&user.DeletedAt,
+	)
+	user.FirstName = firstName.String
+	user.LastName = lastName.String
+	user.StatusReason = reason.String
+	return user, err
+}
@@ -0,0 +1,96 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type ListUsersGatewaySuite struct {
+	suite.Suite
+
+	db            *sql.DB
+	mockDb        sqlmock.Sqlmock
+	expectedQuery string
+	columns       []string
+	errMock       error
+
+	context context.Context
+	now     time.Time
+	gateway *internal.ListUsersGateway
+}
+
+func TestListUsersGatewaySuite(t *testing.T) {
+	suite.Run(t, &ListUsersGatewaySuite{})
+}
+
+func (s *ListUsersGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "SELECT id, first_name, last_name, email, created_at, status, status_reason, status_changed_at, suspended_until, deleted_at FROM user " +
+		"WHERE id > ? AND (? = '' OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?) ORDER BY id LIMIT ?"
+	s.columns = []string{"id", "first_name", "last_name", "email", "created_at", "status", "status_reason", "status_changed_at", "suspended_until", "deleted_at"}
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewListUsersGateway(s.db)
+	s.context = context.Background()
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+}
+
+func (s *ListUsersGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ListUsersGatewaySuite) TestListUsers_QueryError_ReturnOriginalError() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+
+	users, err := s.gateway.ListUsers(s.context, internal.UserFilter{Limit: 21})
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(users)
+}
+
+func (s *ListUsersGatewaySuite) TestListUsers_QueryWithWildcards_EscapeLikePattern() {
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(int64(7), "50%_off", `%50\%\_off%`, `%50\%\_off%`, `%50\%\_off%`, 21).
+		WillReturnRows(sqlmock.NewRows(s.columns))
+
+	users, err := s.gateway.ListUsers(s.context, internal.UserFilter{Query: "50%_off", AfterID: 7, Limit: 21})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.UserSummary{}, users)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *ListUsersGatewaySuite) TestListUsers_Success_ReturnUsers() {
+	rows := sqlmock.NewRows(s.columns).
+		AddRow(1, "John", "Doe", "john.doe@email.com", s.now, "active", nil, nil, nil, nil).
+		AddRow(2, nil, nil, "jane.doe@email.com", s.now, "disabled", "spam", s.now, nil, nil)
+	s.mockDb.ExpectQuery(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs(int64(0), "", "%%", "%%", "%%", 21).
+		WillReturnRows(rows)
+
+	users, err := s.gateway.ListUsers(s.context, internal.UserFilter{Limit: 21})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.UserSummary{
+		{ID: 1, FirstName: "John", LastName: "Doe", Email: "john.doe@email.com", CreatedAt: s.now, Status: entity.UserStatusActive},
+		{ID: 2, Email: "jane.doe@email.com", CreatedAt: s.now, Status: entity.UserStatusDisabled, StatusReason: "spam", StatusChangedAt: &s.now},
+	}, users)
+}
@@ -0,0 +1,222 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "littlerollingsushi.com/example/entity"
+	helper "littlerollingsushi.com/example/usecase/helper"
+
+	internal "littlerollingsushi.com/example/usecase/useradmin/internal"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UserAdminGateway is an autogenerated mock type for the UserAdminGateway type
+type UserAdminGateway struct {
+	mock.Mock
+}
+
+// AssignRoles provides a mock function with given fields: ctx, userID, roles, now
+func (_m *UserAdminGateway) AssignRoles(ctx context.Context, userID int64, roles []string, now time.Time) error {
+	ret := _m.Called(ctx, userID, roles, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, time.Time) error); ok {
+		r0 = rf(ctx, userID, roles, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForcePasswordReset provides a mock function with given fields: ctx, reset
+func (_m *UserAdminGateway) ForcePasswordReset(ctx context.Context, reset internal.PasswordReset) error {
+	ret := _m.Called(ctx, reset)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.PasswordReset) error); ok {
+		r0 = rf(ctx, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateToken provides a mock function with given fields:
+func (_m *UserAdminGateway) GenerateToken() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleNamesByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserAdminGateway) GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userID
+func (_m *UserAdminGateway) GetUserByID(ctx context.Context, userID int64) (internal.UserSummary, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 internal.UserSummary
+	if rf, ok := ret.Get(0).(func(context.Context, int64) internal.UserSummary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(internal.UserSummary)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HashToken provides a mock function with given fields: token
+func (_m *UserAdminGateway) HashToken(token string) string {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *UserAdminGateway) ListUsers(ctx context.Context, filter internal.UserFilter) ([]internal.UserSummary, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []internal.UserSummary
+	if rf, ok := ret.Get(0).(func(context.Context, internal.UserFilter) []internal.UserSummary); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]internal.UserSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, internal.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NowInUTC provides a mock function with given fields:
+func (_m *UserAdminGateway) NowInUTC() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// RecordAuditEvent provides a mock function with given fields: ctx, event
+func (_m *UserAdminGateway) RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMail provides a mock function with given fields: ctx, mail
+func (_m *UserAdminGateway) SendMail(ctx context.Context, mail helper.Mail) error {
+	ret := _m.Called(ctx, mail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, helper.Mail) error); ok {
+		r0 = rf(ctx, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserStatus provides a mock function with given fields: ctx, change
+func (_m *UserAdminGateway) SetUserStatus(ctx context.Context, change internal.UserStatusChange) error {
+	ret := _m.Called(ctx, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.UserStatusChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserAdminGateway interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserAdminGateway creates a new instance of UserAdminGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserAdminGateway(t mockConstructorTestingTNewUserAdminGateway) *UserAdminGateway {
+	mock := &UserAdminGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
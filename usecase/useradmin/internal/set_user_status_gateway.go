@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	setUserStatusQuery      = "UPDATE user SET status = ?, status_reason = ?, status_changed_at = ?, suspended_until = ?, updated_at = ? WHERE id = ?"
+	revokeUserSessionsQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+)
+
+type SetUserStatusGateway struct {
+	sql *sql.DB
+}
+
+func NewSetUserStatusGateway(sql *sql.DB) *SetUserStatusGateway {
+	return &SetUserStatusGateway{sql: sql}
+}
+
+// SetUserStatus changes the status of the user. Any status but active also
+// revokes every session of the user in the same transaction, so access tokens
+// already issued stop working right away. The usecase checks that the user
+// exists first; no rows changed only means the status was already set.
+func (g *SetUserStatusGateway) SetUserStatus(ctx context.Context, change UserStatusChange) error {
+	tx, err := g.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	reason := sql.NullString{String: change.Reason, Valid: change.Reason != ""}
+	if _, err := tx.ExecContext(ctx, setUserStatusQuery, change.Status, reason, change.ChangedAt, change.SuspendedUntil, change.ChangedAt, change.UserID); err != nil {
+		return err
+	}
+
+	if change.Status != entity.UserStatusActive {
+		if _, err := tx.ExecContext(ctx, revokeUserSessionsQuery, change.ChangedAt, change.UserID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
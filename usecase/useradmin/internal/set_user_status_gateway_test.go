@@ -0,0 +1,156 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+)
+
+type SetUserStatusGatewaySuite struct {
+	suite.Suite
+
+	db                  *sql.DB
+	mockDb              sqlmock.Sqlmock
+	expectedQuery       string
+	expectedRevokeQuery string
+	errMock             error
+
+	context context.Context
+	change  internal.UserStatusChange
+	gateway *internal.SetUserStatusGateway
+}
+
+func TestSetUserStatusGatewaySuite(t *testing.T) {
+	suite.Run(t, &SetUserStatusGatewaySuite{})
+}
+
+func (s *SetUserStatusGatewaySuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error occured on opening a stub database: %v\n", err)
+	}
+
+	s.db = db
+	s.mockDb = mock
+	s.expectedQuery = "UPDATE user SET status = ?, status_reason = ?, status_changed_at = ?, suspended_until = ?, updated_at = ? WHERE id = ?"
+	s.expectedRevokeQuery = "UPDATE user_session SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL"
+	s.errMock = errors.New("mocked error")
+
+	s.gateway = internal.NewSetUserStatusGateway(s.db)
+	s.context = context.Background()
+	s.change = internal.UserStatusChange{
+		UserID:    7,
+		Status:    entity.UserStatusDisabled,
+		Reason:    "spam",
+		ChangedAt: time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC),
+	}
+}
+
+func (s *SetUserStatusGatewaySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_BeginError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin().WillReturnError(s.errMock)
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_ExecError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_StatusUnchanged_ReturnNil() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_RevokeError_ReturnOriginalError() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).WillReturnError(s.errMock)
+	s.mockDb.ExpectRollback()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	a := s.Assert()
+	a.ErrorIs(err, s.errMock)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_Disable_RevokeSessions() {
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs("disabled", "spam", s.change.ChangedAt, nil, s.change.ChangedAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.change.ChangedAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_EmptyReason_StoreNull() {
+	s.change.Status = entity.UserStatusActive
+	s.change.Reason = ""
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs("active", nil, s.change.ChangedAt, nil, s.change.ChangedAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
+
+func (s *SetUserStatusGatewaySuite) TestSetUserStatus_Suspension_StoreSuspendedUntil() {
+	suspendedUntil := s.change.ChangedAt.Add(24 * time.Hour)
+	s.change.Status = entity.UserStatusSuspended
+	s.change.SuspendedUntil = &suspendedUntil
+	s.mockDb.ExpectBegin()
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedQuery)).
+		WithArgs("suspended", "spam", s.change.ChangedAt, suspendedUntil, s.change.ChangedAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectExec(regexp.QuoteMeta(s.expectedRevokeQuery)).
+		WithArgs(s.change.ChangedAt, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDb.ExpectCommit()
+
+	err := s.gateway.SetUserStatus(s.context, s.change)
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Nil(s.mockDb.ExpectationsWereMet())
+}
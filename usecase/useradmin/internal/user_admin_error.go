@@ -0,0 +1,11 @@
+package internal
+
+import "errors"
+
+var (
+	ErrUserNotFound      = errors.New("user with given id is not found")
+	ErrInvalidCursor     = errors.New("user list cursor is not valid")
+	ErrRoleNotFound      = errors.New("one of the given roles does not exist")
+	ErrSelfModification  = errors.New("admin cannot disable, suspend, reset or change roles of own account")
+	ErrInvalidSuspension = errors.New("suspension must end in the future")
+)
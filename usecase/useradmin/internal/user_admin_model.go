@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+)
+
+const (
+	AuditEventListUsers          = "admin.user.list"
+	AuditEventViewUser           = "admin.user.view"
+	AuditEventDisableUser        = "admin.user.disable"
+	AuditEventSuspendUser        = "admin.user.suspend"
+	AuditEventEnableUser         = "admin.user.enable"
+	AuditEventForcePasswordReset = "admin.user.password_reset"
+	AuditEventAssignRoles        = "admin.user.roles_assign"
+)
+
+// Actor is the admin performing an action, recorded in the audit log.
+type Actor struct {
+	UserID    int64
+	IPAddress string
+	UserAgent string
+}
+
+type ListUsersUsecaseInput struct {
+	Actor  Actor
+	Query  string
+	Cursor string
+	Limit  int
+}
+
+type ListUsersUsecaseOutput struct {
+	Users      []UserSummary
+	NextCursor string
+}
+
+type UserUsecaseInput struct {
+	Actor  Actor
+	UserID int64
+}
+
+type DisableUserUsecaseInput struct {
+	Actor  Actor
+	UserID int64
+	Reason string
+}
+
+// SuspendUserUsecaseInput suspends the user until Until, or until the user is
+// enabled again when Until is nil.
+type SuspendUserUsecaseInput struct {
+	Actor  Actor
+	UserID int64
+	Reason string
+	Until  *time.Time
+}
+
+type AssignRolesUsecaseInput struct {
+	Actor  Actor
+	UserID int64
+	Roles  []string
+}
+
+type UserFilter struct {
+	Query   string
+	AfterID int64
+	Limit   int
+}
+
+type UserSummary struct {
+	ID              int64
+	FirstName       string
+	LastName        string
+	Email           string
+	CreatedAt       time.Time
+	Status          entity.UserStatus
+	StatusReason    string
+	StatusChangedAt *time.Time
+	SuspendedUntil  *time.Time
+	DeletedAt       *time.Time
+	Roles           []string
+}
+
+type UserStatusChange struct {
+	UserID         int64
+	Status         entity.UserStatus
+	Reason         string
+	SuspendedUntil *time.Time
+	ChangedAt      time.Time
+}
+
+type PasswordReset struct {
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
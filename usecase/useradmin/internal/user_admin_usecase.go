@@ -0,0 +1,295 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/helper"
+)
+
+const (
+	defaultListUsersLimit = 20
+	maxListUsersLimit     = 100
+)
+
+type UserAdminUsecase struct {
+	config  UserAdminUsecaseConfig
+	gateway UserAdminGateway
+}
+
+type UserAdminUsecaseConfig struct {
+	PasswordResetURL        string
+	PasswordResetExpiration time.Duration
+}
+
+//go:generate mockery --name=UserAdminGateway --output=./mocks
+type UserAdminGateway interface {
+	ListUsers(ctx context.Context, filter UserFilter) ([]UserSummary, error)
+	GetUserByID(ctx context.Context, userID int64) (UserSummary, error)
+	GetRoleNamesByUserID(ctx context.Context, userID int64) ([]string, error)
+	SetUserStatus(ctx context.Context, change UserStatusChange) error
+	ForcePasswordReset(ctx context.Context, reset PasswordReset) error
+	AssignRoles(ctx context.Context, userID int64, roles []string, now time.Time) error
+	RecordAuditEvent(ctx context.Context, event entity.AuditEvent) error
+	GenerateToken() (string, error)
+	HashToken(token string) string
+	SendMail(ctx context.Context, mail helper.Mail) error
+	NowInUTC() time.Time
+}
+
+func NewUserAdminUsecase(config UserAdminUsecaseConfig, gateway UserAdminGateway) *UserAdminUsecase {
+	return &UserAdminUsecase{config: config, gateway: gateway}
+}
+
+func (u *UserAdminUsecase) ListUsers(ctx context.Context, in ListUsersUsecaseInput) (ListUsersUsecaseOutput, error) {
+	out, err := u.listUsers(ctx, in)
+	reason := "query=" + in.Query
+	if err := u.audit(ctx, in.Actor, AuditEventListUsers, nil, reason, err); err != nil {
+		return ListUsersUsecaseOutput{}, err
+	}
+
+	return out, err
+}
+
+func (u *UserAdminUsecase) GetUser(ctx context.Context, in UserUsecaseInput) (UserSummary, error) {
+	user, err := u.getUser(ctx, in.UserID)
+	if err := u.audit(ctx, in.Actor, AuditEventViewUser, &in.UserID, "", err); err != nil {
+		return UserSummary{}, err
+	}
+
+	return user, err
+}
+
+func (u *UserAdminUsecase) DisableUser(ctx context.Context, in DisableUserUsecaseInput) error {
+	err := u.ensureNotSelf(in.Actor, in.UserID)
+	if err == nil {
+		err = u.setStatus(ctx, in.UserID, entity.UserStatusDisabled, in.Reason, nil)
+	}
+
+	return u.audit(ctx, in.Actor, AuditEventDisableUser, &in.UserID, in.Reason, err)
+}
+
+func (u *UserAdminUsecase) SuspendUser(ctx context.Context, in SuspendUserUsecaseInput) error {
+	err := u.ensureNotSelf(in.Actor, in.UserID)
+	if err == nil && in.Until != nil && !in.Until.After(u.gateway.NowInUTC()) {
+		err = ErrInvalidSuspension
+	}
+	if err == nil {
+		err = u.setStatus(ctx, in.UserID, entity.UserStatusSuspended, in.Reason, in.Until)
+	}
+
+	return u.audit(ctx, in.Actor, AuditEventSuspendUser, &in.UserID, in.Reason, err)
+}
+
+func (u *UserAdminUsecase) EnableUser(ctx context.Context, in UserUsecaseInput) error {
+	err := u.setStatus(ctx, in.UserID, entity.UserStatusActive, "", nil)
+
+	return u.audit(ctx, in.Actor, AuditEventEnableUser, &in.UserID, "", err)
+}
+
+func (u *UserAdminUsecase) ForcePasswordReset(ctx context.Context, in UserUsecaseInput) error {
+	err := u.forcePasswordReset(ctx, in)
+
+	return u.audit(ctx, in.Actor, AuditEventForcePasswordReset, &in.UserID, "", err)
+}
+
+func (u *UserAdminUsecase) AssignRoles(ctx context.Context, in AssignRolesUsecaseInput) ([]string, error) {
+	roles := normalizeRoles(in.Roles)
+	err := u.assignRoles(ctx, in.Actor, in.UserID, roles)
+	reason := "roles=" + strings.Join(roles, ",")
+	if err := u.audit(ctx, in.Actor, AuditEventAssignRoles, &in.UserID, reason, err); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (u *UserAdminUsecase) listUsers(ctx context.Context, in ListUsersUsecaseInput) (ListUsersUsecaseOutput, error) {
+	afterID, err := decodeCursor(in.Cursor)
+	if err != nil {
+		return ListUsersUsecaseOutput{}, err
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultListUsersLimit
+	}
+	if limit > maxListUsersLimit {
+		limit = maxListUsersLimit
+	}
+
+	// One extra row tells whether there is a next page.
+	users, err := u.gateway.ListUsers(ctx, UserFilter{Query: in.Query, AfterID: afterID, Limit: limit + 1})
+	if err != nil {
+		return ListUsersUsecaseOutput{}, err
+	}
+
+	out := ListUsersUsecaseOutput{Users: users}
+	if len(users) > limit {
+		out.Users = users[:limit]
+		out.NextCursor = encodeCursor(out.Users[limit-1].ID)
+	}
+
+	return out, nil
+}
+
+func (u *UserAdminUsecase) getUser(ctx context.Context, userID int64) (UserSummary, error) {
+	user, err := u.gateway.GetUserByID(ctx, userID)
+	if err != nil {
+		return UserSummary{}, err
+	}
+
+	user.Roles, err = u.gateway.GetRoleNamesByUserID(ctx, userID)
+	if err != nil {
+		return UserSummary{}, err
+	}
+
+	return user, nil
+}
+
+func (u *UserAdminUsecase) setStatus(ctx context.Context, userID int64, status entity.UserStatus, reason string, suspendedUntil *time.Time) error {
+	return u.gateway.SetUserStatus(ctx, UserStatusChange{
+		UserID:         userID,
+		Status:         status,
+		Reason:         reason,
+		SuspendedUntil: suspendedUntil,
+		ChangedAt:      u.gateway.NowInUTC(),
+	})
+}
+
+func (u *UserAdminUsecase) forcePasswordReset(ctx context.Context, in UserUsecaseInput) error {
+	if err := u.ensureNotSelf(in.Actor, in.UserID); err != nil {
+		return err
+	}
+
+	user, err := u.gateway.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return err
+	}
+
+	token, err := u.gateway.GenerateToken()
+	if err != nil {
+		return err
+	}
+
+	now := u.gateway.NowInUTC()
+	reset := PasswordReset{
+		UserID:    user.ID,
+		TokenHash: u.gateway.HashToken(token),
+		ExpiresAt: now.Add(u.config.PasswordResetExpiration),
+		CreatedAt: now,
+	}
+	if err := u.gateway.ForcePasswordReset(ctx, reset); err != nil {
+		return err
+	}
+
+	return u.gateway.SendMail(ctx, u.buildPasswordResetMail(user, reset, token))
+}
+
+func (u *UserAdminUsecase) assignRoles(ctx context.Context, actor Actor, userID int64, roles []string) error {
+	if err := u.ensureNotSelf(actor, userID); err != nil {
+		return err
+	}
+
+	if _, err := u.gateway.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+
+	return u.gateway.AssignRoles(ctx, userID, roles, u.gateway.NowInUTC())
+}
+
+func (u *UserAdminUsecase) ensureNotSelf(actor Actor, userID int64) error {
+	if actor.UserID == userID {
+		return ErrSelfModification
+	}
+
+	return nil
+}
+
+// audit records the outcome of an action and returns actionErr. When the
+// action succeeded, a failure to record it is returned instead, so nothing
+// reaches the admin without leaving a trace.
+func (u *UserAdminUsecase) audit(ctx context.Context, actor Actor, eventType string, userID *int64, reason string, actionErr error) error {
+	event := entity.AuditEvent{
+		Type:        eventType,
+		ActorUserID: &actor.UserID,
+		UserID:      userID,
+		IPAddress:   actor.IPAddress,
+		UserAgent:   actor.UserAgent,
+		Outcome:     entity.AuditOutcomeSuccess,
+		Reason:      reason,
+		CreatedAt:   u.gateway.NowInUTC(),
+	}
+	if actionErr != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason = strings.TrimSpace(reason + " " + actionErr.Error())
+	}
+
+	err := u.gateway.RecordAuditEvent(ctx, event)
+	if actionErr != nil {
+		return actionErr
+	}
+
+	return err
+}
+
+func (u *UserAdminUsecase) buildPasswordResetMail(user UserSummary, reset PasswordReset, token string) helper.Mail {
+	link := u.config.PasswordResetURL + "?token=" + url.QueryEscape(token)
+
+	return helper.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"An administrator has reset the password of your account.\n\n"+
+				"Open the link below to choose a new password. It expires at %s.\n\n%s\n",
+			reset.ExpiresAt.Format(time.RFC1123),
+			link,
+		),
+	}
+}
+
+func normalizeRoles(roles []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role == "" || seen[role] {
+			continue
+		}
+
+		seen[role] = true
+		out = append(out, role)
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
@@ -0,0 +1,264 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"littlerollingsushi.com/example/entity"
+	"littlerollingsushi.com/example/usecase/helper"
+	"littlerollingsushi.com/example/usecase/useradmin/internal"
+	"littlerollingsushi.com/example/usecase/useradmin/internal/mocks"
+)
+
+type UserAdminUsecaseSuite struct {
+	suite.Suite
+
+	config  internal.UserAdminUsecaseConfig
+	gateway *mocks.UserAdminGateway
+	usecase *internal.UserAdminUsecase
+
+	context context.Context
+	actor   internal.Actor
+	userID  int64
+	user    internal.UserSummary
+	now     time.Time
+	errMock error
+}
+
+func TestUserAdminUsecaseSuite(t *testing.T) {
+	suite.Run(t, &UserAdminUsecaseSuite{})
+}
+
+func (s *UserAdminUsecaseSuite) SetupTest() {
+	s.config = internal.UserAdminUsecaseConfig{
+		PasswordResetURL:        "http://test.com/v1/password/reset",
+		PasswordResetExpiration: time.Hour,
+	}
+	s.gateway = mocks.NewUserAdminGateway(s.T())
+	s.usecase = internal.NewUserAdminUsecase(s.config, s.gateway)
+
+	s.context = context.Background()
+	s.actor = internal.Actor{UserID: 1, IPAddress: "192.0.2.1", UserAgent: "curl/7.85.0"}
+	s.userID = 7
+	s.now = time.Date(2022, 10, 29, 23, 59, 59, 0, time.UTC)
+	s.user = internal.UserSummary{ID: s.userID, FirstName: "John", LastName: "Doe", Email: "john.doe@email.com", CreatedAt: s.now}
+	s.errMock = errors.New("mock error")
+
+	s.gateway.On("NowInUTC").Return(s.now).Maybe()
+}
+
+func (s *UserAdminUsecaseSuite) expectAuditEvent(eventType string, userID *int64, outcome, reason string) {
+	s.gateway.On("RecordAuditEvent", s.context, entity.AuditEvent{
+		Type:        eventType,
+		ActorUserID: &s.actor.UserID,
+		UserID:      userID,
+		IPAddress:   s.actor.IPAddress,
+		UserAgent:   s.actor.UserAgent,
+		Outcome:     outcome,
+		Reason:      reason,
+		CreatedAt:   s.now,
+	}).Return(nil).Once()
+}
+
+func (s *UserAdminUsecaseSuite) TestListUsers_InvalidCursor_AuditFailureAndReturnError() {
+	s.expectAuditEvent(internal.AuditEventListUsers, nil, entity.AuditOutcomeFailure, "query= "+internal.ErrInvalidCursor.Error())
+
+	_, err := s.usecase.ListUsers(s.context, internal.ListUsersUsecaseInput{Actor: s.actor, Cursor: "not a cursor"})
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidCursor)
+}
+
+func (s *UserAdminUsecaseSuite) TestListUsers_MoreRowsThanLimit_ReturnNextCursor() {
+	s.gateway.On("ListUsers", s.context, internal.UserFilter{Query: "doe", AfterID: 0, Limit: 3}).
+		Return([]internal.UserSummary{{ID: 1}, {ID: 5}, {ID: 9}}, nil)
+	s.expectAuditEvent(internal.AuditEventListUsers, nil, entity.AuditOutcomeSuccess, "query=doe")
+
+	out, err := s.usecase.ListUsers(s.context, internal.ListUsersUsecaseInput{Actor: s.actor, Query: "doe", Limit: 2})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.UserSummary{{ID: 1}, {ID: 5}}, out.Users)
+	a.Equal("NQ", out.NextCursor)
+}
+
+func (s *UserAdminUsecaseSuite) TestListUsers_CursorAndLimitAboveMax_UseDecodedIDAndMaxLimit() {
+	s.gateway.On("ListUsers", s.context, internal.UserFilter{AfterID: 5, Limit: 101}).
+		Return([]internal.UserSummary{{ID: 9}}, nil)
+	s.expectAuditEvent(internal.AuditEventListUsers, nil, entity.AuditOutcomeSuccess, "query=")
+
+	out, err := s.usecase.ListUsers(s.context, internal.ListUsersUsecaseInput{Actor: s.actor, Cursor: "NQ", Limit: 500})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]internal.UserSummary{{ID: 9}}, out.Users)
+	a.Empty(out.NextCursor)
+}
+
+func (s *UserAdminUsecaseSuite) TestListUsers_AuditError_ReturnError() {
+	s.gateway.On("ListUsers", s.context, internal.UserFilter{Limit: 21}).Return([]internal.UserSummary{}, nil)
+	s.gateway.On("RecordAuditEvent", s.context, mock.Anything).Return(s.errMock)
+
+	_, err := s.usecase.ListUsers(s.context, internal.ListUsersUsecaseInput{Actor: s.actor})
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *UserAdminUsecaseSuite) TestGetUser_NotFound_AuditFailureAndReturnError() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(internal.UserSummary{}, internal.ErrUserNotFound)
+	s.expectAuditEvent(internal.AuditEventViewUser, &s.userID, entity.AuditOutcomeFailure, internal.ErrUserNotFound.Error())
+
+	_, err := s.usecase.GetUser(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	s.Assert().ErrorIs(err, internal.ErrUserNotFound)
+}
+
+func (s *UserAdminUsecaseSuite) TestGetUser_Success_ReturnUserWithRoles() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(s.user, nil)
+	s.gateway.On("GetRoleNamesByUserID", s.context, s.userID).Return([]string{"support"}, nil)
+	s.expectAuditEvent(internal.AuditEventViewUser, &s.userID, entity.AuditOutcomeSuccess, "")
+
+	user, err := s.usecase.GetUser(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	expected := s.user
+	expected.Roles = []string{"support"}
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal(expected, user)
+}
+
+func (s *UserAdminUsecaseSuite) TestDisableUser_OwnAccount_ReturnSelfModificationErr() {
+	s.expectAuditEvent(internal.AuditEventDisableUser, &s.actor.UserID, entity.AuditOutcomeFailure, "spam "+internal.ErrSelfModification.Error())
+
+	err := s.usecase.DisableUser(s.context, internal.DisableUserUsecaseInput{Actor: s.actor, UserID: s.actor.UserID, Reason: "spam"})
+
+	s.Assert().ErrorIs(err, internal.ErrSelfModification)
+}
+
+func (s *UserAdminUsecaseSuite) TestDisableUser_Success_AuditWithReason() {
+	s.gateway.On("SetUserStatus", s.context, internal.UserStatusChange{
+		UserID:    s.userID,
+		Status:    entity.UserStatusDisabled,
+		Reason:    "spam",
+		ChangedAt: s.now,
+	}).Return(nil)
+	s.expectAuditEvent(internal.AuditEventDisableUser, &s.userID, entity.AuditOutcomeSuccess, "spam")
+
+	err := s.usecase.DisableUser(s.context, internal.DisableUserUsecaseInput{Actor: s.actor, UserID: s.userID, Reason: "spam"})
+
+	s.Assert().Nil(err)
+}
+
+func (s *UserAdminUsecaseSuite) TestSuspendUser_UntilInThePast_ReturnInvalidSuspensionErr() {
+	until := s.now.Add(-time.Hour)
+	s.expectAuditEvent(internal.AuditEventSuspendUser, &s.userID, entity.AuditOutcomeFailure, "chargeback "+internal.ErrInvalidSuspension.Error())
+
+	err := s.usecase.SuspendUser(s.context, internal.SuspendUserUsecaseInput{Actor: s.actor, UserID: s.userID, Reason: "chargeback", Until: &until})
+
+	s.Assert().ErrorIs(err, internal.ErrInvalidSuspension)
+}
+
+func (s *UserAdminUsecaseSuite) TestSuspendUser_Success_StoreSuspendedUntil() {
+	until := s.now.Add(24 * time.Hour)
+	s.gateway.On("SetUserStatus", s.context, internal.UserStatusChange{
+		UserID:         s.userID,
+		Status:         entity.UserStatusSuspended,
+		Reason:         "chargeback",
+		SuspendedUntil: &until,
+		ChangedAt:      s.now,
+	}).Return(nil)
+	s.expectAuditEvent(internal.AuditEventSuspendUser, &s.userID, entity.AuditOutcomeSuccess, "chargeback")
+
+	err := s.usecase.SuspendUser(s.context, internal.SuspendUserUsecaseInput{Actor: s.actor, UserID: s.userID, Reason: "chargeback", Until: &until})
+
+	s.Assert().Nil(err)
+}
+
+func (s *UserAdminUsecaseSuite) TestEnableUser_GatewayError_ReturnError() {
+	s.gateway.On("SetUserStatus", s.context, mock.Anything).Return(s.errMock)
+	s.expectAuditEvent(internal.AuditEventEnableUser, &s.userID, entity.AuditOutcomeFailure, s.errMock.Error())
+
+	err := s.usecase.EnableUser(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *UserAdminUsecaseSuite) TestEnableUser_Success_ReturnNil() {
+	s.gateway.On("SetUserStatus", s.context, internal.UserStatusChange{
+		UserID:    s.userID,
+		Status:    entity.UserStatusActive,
+		ChangedAt: s.now,
+	}).Return(nil)
+	s.expectAuditEvent(internal.AuditEventEnableUser, &s.userID, entity.AuditOutcomeSuccess, "")
+
+	err := s.usecase.EnableUser(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	s.Assert().Nil(err)
+}
+
+func (s *UserAdminUsecaseSuite) TestForcePasswordReset_StoreError_ReturnErrorWithoutMail() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(s.user, nil)
+	s.gateway.On("GenerateToken").Return("random-token", nil)
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("ForcePasswordReset", s.context, mock.Anything).Return(s.errMock)
+	s.expectAuditEvent(internal.AuditEventForcePasswordReset, &s.userID, entity.AuditOutcomeFailure, s.errMock.Error())
+
+	err := s.usecase.ForcePasswordReset(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	s.Assert().ErrorIs(err, s.errMock)
+}
+
+func (s *UserAdminUsecaseSuite) TestForcePasswordReset_Success_StoreResetAndSendMail() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(s.user, nil)
+	s.gateway.On("GenerateToken").Return("random-token", nil)
+	s.gateway.On("HashToken", "random-token").Return("random-token-hash")
+	s.gateway.On("ForcePasswordReset", s.context, internal.PasswordReset{
+		UserID:    s.userID,
+		TokenHash: "random-token-hash",
+		ExpiresAt: s.now.Add(time.Hour),
+		CreatedAt: s.now,
+	}).Return(nil)
+	var sentMail helper.Mail
+	s.gateway.On("SendMail", s.context, mock.MatchedBy(func(mail helper.Mail) bool {
+		sentMail = mail
+		return mail.To == s.user.Email
+	})).Return(nil)
+	s.expectAuditEvent(internal.AuditEventForcePasswordReset, &s.userID, entity.AuditOutcomeSuccess, "")
+
+	err := s.usecase.ForcePasswordReset(s.context, internal.UserUsecaseInput{Actor: s.actor, UserID: s.userID})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Contains(sentMail.Body, "http://test.com/v1/password/reset?token=random-token")
+}
+
+func (s *UserAdminUsecaseSuite) TestAssignRoles_UnknownRole_AuditFailureAndReturnError() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(s.user, nil)
+	s.gateway.On("AssignRoles", s.context, s.userID, []string{"unknown"}, s.now).Return(internal.ErrRoleNotFound)
+	s.expectAuditEvent(internal.AuditEventAssignRoles, &s.userID, entity.AuditOutcomeFailure, "roles=unknown "+internal.ErrRoleNotFound.Error())
+
+	roles, err := s.usecase.AssignRoles(s.context, internal.AssignRolesUsecaseInput{Actor: s.actor, UserID: s.userID, Roles: []string{"unknown"}})
+
+	a := s.Assert()
+	a.ErrorIs(err, internal.ErrRoleNotFound)
+	a.Nil(roles)
+}
+
+func (s *UserAdminUsecaseSuite) TestAssignRoles_DuplicatedRoles_AssignSortedUniqueRoles() {
+	s.gateway.On("GetUserByID", s.context, s.userID).Return(s.user, nil)
+	s.gateway.On("AssignRoles", s.context, s.userID, []string{"admin", "support"}, s.now).Return(nil)
+	s.expectAuditEvent(internal.AuditEventAssignRoles, &s.userID, entity.AuditOutcomeSuccess, "roles=admin,support")
+
+	roles, err := s.usecase.AssignRoles(s.context, internal.AssignRolesUsecaseInput{
+		Actor:  s.actor,
+		UserID: s.userID,
+		Roles:  []string{"support", " admin", "", "support"},
+	})
+
+	a := s.Assert()
+	a.Nil(err)
+	a.Equal([]string{"admin", "support"}, roles)
+}